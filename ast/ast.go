@@ -6,6 +6,7 @@ func (*FuncDecl) declNode()          {}
 func (*dumpType) declNode()          {}
 func (*BadDecl) declNode()           {}
 func (*ConstDecl) declNode()         {}
+func (*GenDecl) declNode()           {}
 func (*VarDecl) declNode()           {}
 func (*BadType) declNode()           {}
 func (*StructDecl) declNode()        {}
@@ -16,29 +17,35 @@ func (*ComptimeBlockDecl) declNode() {}
 func (*ImplementsDecl) declNode()    {}
 func (*DefinedTypeDecl) declNode()   {}
 
-func (*dumpType) typeNode()  {}
-func (*BadType) typeNode()   {}
-func (*NamedType) typeNode() {}
-func (*SliceType) typeNode() {}
-func (*ArrayType) typeNode() {}
-func (*MapType) typeNode()   {}
-
-func (*dumpType) exprNode()      {}
-func (*IdentExpr) exprNode()     {}
-func (*IntLitExpr) exprNode()    {}
-func (*FloatLitExpr) exprNode()  {}
-func (*BoolLitExpr) exprNode()   {}
-func (*StringLitExpr) exprNode() {}
-func (*ParenExpr) exprNode()     {}
-func (*BadExpr) exprNode()       {}
-func (*BinaryExpr) exprNode()    {}
-func (*UnaryExpr) exprNode()     {}
-func (*SelectorExpr) exprNode()  {}
-func (*IndexExpr) exprNode()     {}
-func (*CallExpr) exprNode()      {}
-func (*SliceExpr) exprNode()     {}
-func (*MakeExpr) exprNode()      {}
-func (*SliceLitExpr) exprNode()  {}
+func (*dumpType) typeNode()   {}
+func (*BadType) typeNode()    {}
+func (*NamedType) typeNode()  {}
+func (*SliceType) typeNode()  {}
+func (*ArrayType) typeNode()  {}
+func (*MapType) typeNode()    {}
+func (*UnionType) typeNode()  {}
+func (*FuncType) typeNode()   {}
+func (*StructType) typeNode() {}
+
+func (*dumpType) exprNode()       {}
+func (*IdentExpr) exprNode()      {}
+func (*IntLitExpr) exprNode()     {}
+func (*FloatLitExpr) exprNode()   {}
+func (*BoolLitExpr) exprNode()    {}
+func (*StringLitExpr) exprNode()  {}
+func (*ParenExpr) exprNode()      {}
+func (*BadExpr) exprNode()        {}
+func (*BinaryExpr) exprNode()     {}
+func (*UnaryExpr) exprNode()      {}
+func (*SelectorExpr) exprNode()   {}
+func (*IndexExpr) exprNode()      {}
+func (*CallExpr) exprNode()       {}
+func (*SliceExpr) exprNode()      {}
+func (*MakeExpr) exprNode()       {}
+func (*SliceLitExpr) exprNode()   {}
+func (*NilExpr) exprNode()        {}
+func (*ConversionExpr) exprNode() {}
+func (*ComptimeExpr) exprNode()   {}
 
 func (*dumpType) stmtNode()        {}
 func (*BlockStmt) stmtNode()       {}
@@ -52,9 +59,16 @@ func (*RangeStmt) stmtNode()       {}
 func (*IncDecStmt) stmtNode()      {}
 func (*BreakStmt) stmtNode()       {}
 func (*ContinueStmt) stmtNode()    {}
+func (*LabeledStmt) stmtNode()     {}
+func (*DeferStmt) stmtNode()       {}
 func (*SwitchStmt) stmtNode()      {}
 func (*FallThroughStmt) stmtNode() {}
-func (*DeclStmt) stmtNode()        {}
+func (*MatchStmt) stmtNode()       {}
+
+func (*VariantPattern) patternNode()  {}
+func (*BindingPattern) patternNode()  {}
+func (*WildcardPattern) patternNode() {}
+func (*DeclStmt) stmtNode()           {}
 
 func (x *FuncDecl) Start() token.Token {
 	return x.FuncKW
@@ -82,7 +96,12 @@ func (x *StringLitExpr) Start() token.Token { return x.Name }
 func (x *StringLitExpr) End() token.Token   { return x.Name }
 
 func (x *ParenExpr) Start() token.Token { return x.Left }
-func (x *ParenExpr) End() token.Token   { return x.Right }
+func (x *ParenExpr) End() token.Token {
+	if x.Right == (token.Token{}) {
+		return x.Inner.End()
+	}
+	return x.Right
+}
 
 func (x *BinaryExpr) Start() token.Token { return x.Left.Start() }
 func (x *BinaryExpr) End() token.Token   { return x.Right.End() }
@@ -99,6 +118,15 @@ func (x *IndexExpr) End() token.Token   { return x.RBracket }
 func (x *CallExpr) Start() token.Token { return x.Callee.Start() }
 func (x *CallExpr) End() token.Token   { return x.RParen }
 
+func (x *NilExpr) Start() token.Token { return x.Nil }
+func (x *NilExpr) End() token.Token   { return x.Nil }
+
+func (x *ConversionExpr) Start() token.Token { return x.Type.Start() }
+func (x *ConversionExpr) End() token.Token   { return x.RParen }
+
+func (x *ComptimeExpr) Start() token.Token { return x.ComptimeKW }
+func (x *ComptimeExpr) End() token.Token   { return x.X.End() }
+
 func (x *ExprStmt) Start() token.Token { return x.Expr.Start() }
 func (x *ExprStmt) End() token.Token   { return x.Expr.End() }
 
@@ -119,14 +147,49 @@ func (x *ReturnStmt) End() token.Token {
 func (x *BlockStmt) Start() token.Token { return x.LBrace }
 func (x *BlockStmt) End() token.Token   { return x.RBrace }
 
-func (x *ConstDecl) Start() token.Token { return x.ConstKW }
-func (x *ConstDecl) End() token.Token   { return x.Init.End() }
+func (x *ConstDecl) Start() token.Token {
+	if x.ConstKW == (token.Token{}) {
+		return x.Name
+	}
+	return x.ConstKW
+}
+
+func (x *ConstDecl) End() token.Token {
+	if x.Implicit || x.Init == nil {
+		return x.Name
+	}
+	return x.Init.End()
+}
+
+func (x *GenDecl) Start() token.Token { return x.Keyword }
+func (x *GenDecl) End() token.Token   { return x.RParen }
+
+func (x *VarDecl) Start() token.Token {
+	if x.VarKW == (token.Token{}) && len(x.Names) > 0 {
+		return x.Names[0]
+	}
+	return x.VarKW
+}
+func (x *VarDecl) End() token.Token {
+	if len(x.Values) == 0 {
+		return x.Eq
+	}
+	return x.Values[len(x.Values)-1].End()
+}
 
-func (x *VarDecl) Start() token.Token { return x.VarKW }
-func (x *VarDecl) End() token.Token   { return x.Init.End() }
+func (x *AssignStmt) Start() token.Token {
+	if len(x.Left) == 0 {
+		return x.Operator
+	}
+	return x.Left[0].Start()
+}
 
-func (x *AssignStmt) Start() token.Token { return x.Left.Start() }
-func (x *AssignStmt) End() token.Token   { return x.Right.End() }
+func (x *AssignStmt) End() token.Token {
+	if len(x.Right) == 0 {
+		return x.Operator
+	}
+	return x.Right[len(x.Right)-1].End()
+}
 
 func (x *BadStmt) Start() token.Token { return x.From }
 func (x *BadStmt) End() token.Token   { return x.To }
@@ -165,10 +228,36 @@ func (x *IncDecStmt) Start() token.Token { return x.X.Start() }
 func (x *IncDecStmt) End() token.Token   { return x.Operator }
 
 func (x *BreakStmt) Start() token.Token { return x.Break }
-func (x *BreakStmt) End() token.Token   { return x.Break }
+func (x *BreakStmt) End() token.Token {
+	if x.Label != (token.Token{}) {
+		return x.Label
+	}
+	return x.Break
+}
 
 func (x *ContinueStmt) Start() token.Token { return x.Continue }
-func (x *ContinueStmt) End() token.Token   { return x.Continue }
+func (x *ContinueStmt) End() token.Token {
+	if x.Label != (token.Token{}) {
+		return x.Label
+	}
+	return x.Continue
+}
+
+func (x *DeferStmt) Start() token.Token { return x.Defer }
+func (x *DeferStmt) End() token.Token {
+	if x.Call != nil {
+		return x.Call.End()
+	}
+	return x.Defer
+}
+
+func (x *LabeledStmt) Start() token.Token { return x.Label }
+func (x *LabeledStmt) End() token.Token {
+	if x.Stmt != nil {
+		return x.Stmt.End()
+	}
+	return x.Colon
+}
 
 func (x *SwitchStmt) Start() token.Token { return x.Switch }
 func (x *SwitchStmt) End() token.Token   { return x.RBrace }
@@ -184,6 +273,23 @@ func (x *CaseClause) End() token.Token {
 func (x *FallThroughStmt) Start() token.Token { return x.FallThrough }
 func (x *FallThroughStmt) End() token.Token   { return x.FallThrough }
 
+func (x *MatchStmt) Start() token.Token { return x.Match }
+func (x *MatchStmt) End() token.Token   { return x.RBrace }
+
+func (x *VariantPattern) Start() token.Token { return x.Name }
+func (x *VariantPattern) End() token.Token {
+	if x.RParen != (token.Token{}) {
+		return x.RParen
+	}
+	return x.Name
+}
+
+func (x *BindingPattern) Start() token.Token { return x.Name }
+func (x *BindingPattern) End() token.Token   { return x.Name }
+
+func (x *WildcardPattern) Start() token.Token { return x.Underscore }
+func (x *WildcardPattern) End() token.Token   { return x.Underscore }
+
 func (x *StructDecl) Start() token.Token { return x.TypeDecl }
 func (x *StructDecl) End() token.Token   { return x.RBrace }
 
@@ -221,7 +327,12 @@ func (x *SliceLitExpr) Start() token.Token {
 	}
 	return token.Token{}
 }
-func (x *SliceLitExpr) End() token.Token { return x.RBrace }
+func (x *SliceLitExpr) End() token.Token {
+	if x.RBrace == (token.Token{}) && len(x.Elements) > 0 {
+		return x.Elements[len(x.Elements)-1].End()
+	}
+	return x.RBrace
+}
 
 func (x *NamedType) Start() token.Token {
 	if len(x.Parts) > 0 {
@@ -230,18 +341,67 @@ func (x *NamedType) Start() token.Token {
 	return token.Token{}
 }
 func (x *NamedType) End() token.Token {
+	if len(x.TypeArgs) > 0 {
+		return x.TypeArgs[len(x.TypeArgs)-1].End()
+	}
 	if len(x.Parts) > 0 {
 		return x.Parts[len(x.Parts)-1]
 	}
 	return token.Token{}
 }
 
+func (x *UnionType) Start() token.Token {
+	if len(x.Terms) > 0 {
+		return x.Terms[0].Start()
+	}
+	return token.Token{}
+}
+func (x *UnionType) End() token.Token {
+	if len(x.Terms) > 0 {
+		return x.Terms[len(x.Terms)-1].End()
+	}
+	return token.Token{}
+}
+
 func (x *ComptimeBlockDecl) Start() token.Token { return x.ComptimeKW }
-func (x *ComptimeBlockDecl) End() token.Token   { return x.ComptimeKW }
+func (x *ComptimeBlockDecl) End() token.Token {
+	if x.RBrace != (token.Token{}) {
+		return x.RBrace
+	}
+	if len(x.Decls) > 0 {
+		return x.Decls[len(x.Decls)-1].End()
+	}
+	return x.ComptimeKW
+}
+
+func (x *ImportDecl) Start() token.Token { return x.ImportKW }
+func (x *ImportDecl) End() token.Token {
+	if x.RParen != (token.Token{}) {
+		return x.RParen
+	}
+	if len(x.Specs) > 0 {
+		return x.Specs[len(x.Specs)-1].Path
+	}
+	return x.ImportKW
+}
 
 func (x *MapType) Start() token.Token { return x.KindKW }
 func (x *MapType) End() token.Token   { return x.ValueType.End() }
 
+func (x *FuncType) Start() token.Token { return x.Func }
+func (x *FuncType) End() token.Token {
+	if x.Results.RParen != (token.Token{}) {
+		return x.Results.RParen
+	}
+	if len(x.Results.List) > 0 {
+		return x.Results.List[len(x.Results.List)-1].Type.End()
+	}
+	return x.RParen
+}
+
+func (x *StructType) Start() token.Token { return x.Struct }
+func (x *StructType) End() token.Token   { return x.RBrace }
+
 func (x *MakeExpr) Start() token.Token { return x.MakeKW }
 func (x *MakeExpr) End() token.Token   { return x.RParen }
 
@@ -229,7 +229,7 @@ func TestAst_position(t *testing.T) {
 			Line:   1,
 			Column: 1,
 		}
-		x := &VarDecl{VarKW: z, Init: &IdentExpr{z}}
+		x := &VarDecl{VarKW: z, Values: []Expr{&IdentExpr{z}}}
 		assert.Equal(z, x.Start())
 		assert.Equal(z, x.End())
 	})
@@ -241,7 +241,7 @@ func TestAst_position(t *testing.T) {
 			Line:   1,
 			Column: 1,
 		}
-		x := &AssignStmt{Left: &IdentExpr{z}, Right: &IdentExpr{z}}
+		x := &AssignStmt{Left: []Expr{&IdentExpr{z}}, Right: []Expr{&IdentExpr{z}}}
 		assert.Equal(z, x.Start())
 		assert.Equal(z, x.End())
 	})
@@ -456,6 +456,77 @@ func TestAst_position(t *testing.T) {
 		assert.Equal(z, x.End())
 	})
 
+	t.Run("labeled_break_continue_stmt", func(t *testing.T) {
+		z := token.Token{
+			Kind:   token.KWBreak,
+			Value:  "break",
+			Line:   1,
+			Column: 1,
+		}
+		l := token.Token{
+			Kind:   token.Ident,
+			Value:  "L",
+			Line:   1,
+			Column: 7,
+		}
+		x := &BreakStmt{Break: z, Label: l}
+		assert.Equal(z, x.Start())
+		assert.Equal(l, x.End())
+
+		z.Kind, z.Value = token.KWContinue, "continue"
+		y := &ContinueStmt{Continue: z, Label: l}
+		assert.Equal(z, y.Start())
+		assert.Equal(l, y.End())
+	})
+
+	t.Run("defer_stmt", func(t *testing.T) {
+		z := token.Token{
+			Kind:   token.KWDefer,
+			Value:  "defer",
+			Line:   1,
+			Column: 1,
+		}
+		r := token.Token{
+			Kind:   token.RParen,
+			Value:  ")",
+			Line:   1,
+			Column: 9,
+		}
+		x := &DeferStmt{Defer: z}
+		assert.Equal(z, x.Start())
+		assert.Equal(z, x.End())
+
+		x.Call = &CallExpr{Callee: &IdentExpr{Name: token.Token{Kind: token.Ident, Value: "f"}}, RParen: r}
+		assert.Equal(r, x.End())
+	})
+
+	t.Run("labeled_stmt", func(t *testing.T) {
+		l := token.Token{
+			Kind:   token.Ident,
+			Value:  "L",
+			Line:   1,
+			Column: 1,
+		}
+		c := token.Token{
+			Kind:   token.Colon,
+			Value:  ":",
+			Line:   1,
+			Column: 2,
+		}
+		z := token.Token{
+			Kind:   token.KWBreak,
+			Value:  "break",
+			Line:   1,
+			Column: 4,
+		}
+		x := &LabeledStmt{Label: l, Colon: c}
+		assert.Equal(l, x.Start())
+		assert.Equal(c, x.End())
+
+		x.Stmt = &BreakStmt{Break: z}
+		assert.Equal(z, x.End())
+	})
+
 	t.Run("switch_stmt", func(t *testing.T) {
 		z := token.Token{
 			Kind:   token.KWSwitch,
@@ -842,6 +913,45 @@ func TestAst_position(t *testing.T) {
 		assert.Equal(x, st.End())
 	})
 
+	t.Run("func_type", func(t *testing.T) {
+		f := token.Token{Kind: token.KWFunc, Value: "func", Line: 1, Column: 1}
+		rp := token.Token{Kind: token.RParen, Value: ")", Line: 1, Column: 6}
+		x := token.Token{Kind: token.Ident, Value: "a", Line: 1, Column: 8}
+
+		st := &FuncType{Func: f, RParen: rp}
+		assert.Equal(f, st.Start())
+		assert.Equal(rp, st.End())
+
+		st.Results.List = []Param{{Type: &NamedType{Parts: []token.Token{x}}}}
+		assert.Equal(x, st.End())
+	})
+
+	t.Run("struct_type", func(t *testing.T) {
+		s := token.Token{Kind: token.KWStruct, Value: "struct", Line: 1, Column: 1}
+		rb := token.Token{Kind: token.RBrace, Value: "}", Line: 2, Column: 1}
+		st := &StructType{Struct: s, RBrace: rb}
+
+		assert.Equal(s, st.Start())
+		assert.Equal(rb, st.End())
+	})
+
+	t.Run("nil_expr", func(t *testing.T) {
+		n := token.Token{Kind: token.KWNil, Value: "nil", Line: 1, Column: 1}
+		st := &NilExpr{Nil: n}
+
+		assert.Equal(n, st.Start())
+		assert.Equal(n, st.End())
+	})
+
+	t.Run("conversion_expr", func(t *testing.T) {
+		x := token.Token{Kind: token.KWInt64, Value: "int64", Line: 1, Column: 1}
+		rp := token.Token{Kind: token.RParen, Value: ")", Line: 1, Column: 8}
+		st := &ConversionExpr{Type: &NamedType{Parts: []token.Token{x}}, RParen: rp}
+
+		assert.Equal(x, st.Start())
+		assert.Equal(rp, st.End())
+	})
+
 	t.Run("make_expr_x1", func(t *testing.T) {
 		m := token.Token{
 			Kind:   token.Ident,
@@ -0,0 +1,44 @@
+package ast
+
+import (
+	"strings"
+
+	"github.com/orilang/gori/token"
+)
+
+// Text returns the content of the comment group without
+// the comment markers, one line per comment line
+func (g *CommentGroup) Text() string {
+	if g == nil {
+		return ""
+	}
+
+	var lines []string
+	for _, c := range g.List {
+		v := c.Value
+		switch {
+		case strings.HasPrefix(v, "///"):
+			v = v[3:]
+		case strings.HasPrefix(v, "//"):
+			v = v[2:]
+		case strings.HasPrefix(v, "/*"):
+			v = strings.TrimSuffix(v[2:], "*/")
+		}
+
+		for line := range strings.SplitSeq(v, "\n") {
+			lines = append(lines, strings.TrimSpace(line))
+		}
+	}
+	return strings.Join(lines, "\n")
+}
+
+// ParseDirective returns the directive held by tok like //ori:build linux.
+// ok is false when tok is not a directive or when its name is missing
+func ParseDirective(tok token.Token) (d *Directive, ok bool) {
+	content, found := strings.CutPrefix(tok.Value, token.DirectivePrefix)
+	fields := strings.Fields(content)
+	if !found || len(fields) == 0 || content[0] == ' ' || content[0] == '\t' {
+		return nil, false
+	}
+	return &Directive{Token: tok, Name: fields[0], Args: fields[1:]}, true
+}
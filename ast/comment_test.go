@@ -0,0 +1,41 @@
+package ast
+
+import (
+	"testing"
+
+	"github.com/orilang/gori/token"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAst_comment(t *testing.T) {
+	assert := assert.New(t)
+
+	t.Run("nil_group", func(t *testing.T) {
+		var g *CommentGroup
+		assert.Equal("", g.Text())
+	})
+
+	t.Run("text", func(t *testing.T) {
+		g := &CommentGroup{
+			List: []token.Token{
+				{Kind: token.DocComment, Value: "/// A is documented"},
+				{Kind: token.Comment, Value: "// on two lines"},
+				{Kind: token.Comment, Value: "/* and a\r\n   block */"},
+			},
+		}
+		assert.Equal("A is documented\non two lines\nand a\nblock", g.Text())
+	})
+
+	t.Run("parse_directive", func(t *testing.T) {
+		d, ok := ParseDirective(token.Token{Kind: token.Directive, Value: "//ori:build linux  && amd64"})
+		if assert.True(ok) {
+			assert.Equal("build", d.Name)
+			assert.Equal([]string{"linux", "&&", "amd64"}, d.Args)
+		}
+
+		for _, input := range []string{"//ori:", "//ori: build linux", "// ori:build linux"} {
+			_, ok := ParseDirective(token.Token{Kind: token.Directive, Value: input})
+			assert.False(ok, input)
+		}
+	})
+}
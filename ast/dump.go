@@ -27,6 +27,23 @@ func (d *dumper) node(indent int, n any) {
 		d.kv(indent+1, "Package", v.PackageKW)
 		d.kv(indent+1, "Name", v.Name)
 
+		if len(v.Directives) > 0 {
+			d.line(indent+1, "Directives")
+			for _, dir := range v.Directives {
+				d.line(indent+2, "Directive")
+				d.kv(indent+3, "Token", dir.Token)
+				d.line(indent+3, fmt.Sprintf("Name: %s", dir.Name))
+				d.line(indent+3, fmt.Sprintf("Args: %q", dir.Args))
+			}
+		}
+
+		if len(v.Imports) > 0 {
+			d.line(indent+1, "Imports")
+			for _, imp := range v.Imports {
+				d.node(indent+2, imp)
+			}
+		}
+
 		if len(v.Decls) > 0 {
 			d.line(indent+1, "Decls")
 			for _, decl := range v.Decls {
@@ -34,10 +51,36 @@ func (d *dumper) node(indent int, n any) {
 			}
 		}
 
+	case *ImportDecl:
+		d.line(indent, "ImportDecl")
+		d.kv(indent+1, "Import", v.ImportKW)
+		if v.LParen != (token.Token{}) {
+			d.kv(indent+1, "LParen", v.LParen)
+		}
+		for _, spec := range v.Specs {
+			d.line(indent+1, "ImportSpec")
+			if spec.Name != (token.Token{}) {
+				d.kv(indent+2, "Name", spec.Name)
+			}
+			d.kv(indent+2, "Path", spec.Path)
+		}
+		if v.RParen != (token.Token{}) {
+			d.kv(indent+1, "RParen", v.RParen)
+		}
+
 	case *FuncDecl:
 		d.line(indent, "FuncDecl")
+		d.doc(indent+1, v.Doc)
 		d.kv(indent+1, "Function", v.FuncKW)
+		if v.Recv != nil {
+			d.line(indent+1, "Recv")
+			d.kv(indent+2, "Ident", v.Recv.Name)
+			d.qualifier(indent+2, v.Recv.Qualifier)
+			d.line(indent+2, "Type")
+			d.typ(indent+3, v.Recv.Type)
+		}
 		d.kv(indent+1, "Name", v.Name)
+		d.typeParams(indent+1, v.TypeParams)
 
 		d.line(indent+1, "Params")
 		if len(v.Params) == 0 {
@@ -46,6 +89,8 @@ func (d *dumper) node(indent int, n any) {
 			for _, p := range v.Params {
 				d.line(indent+2, "Param")
 				d.kv(indent+3, "Ident", p.Name)
+				d.qualifier(indent+3, p.Qualifier)
+				d.ellipsis(indent+3, p.Ellipsis)
 				d.line(indent+3, "Type")
 				d.typ(indent+4, p.Type)
 			}
@@ -62,6 +107,7 @@ func (d *dumper) node(indent int, n any) {
 				if p.Name != (token.Token{}) {
 					d.kv(indent+4, "Ident", p.Name)
 				}
+				d.qualifier(indent+4, p.Qualifier)
 				d.line(indent+4, "Type")
 				d.typ(indent+5, p.Type)
 			}
@@ -93,30 +139,56 @@ func (d *dumper) node(indent int, n any) {
 
 	case *ConstDecl:
 		d.line(indent, "ConstDecl")
-		d.kv(indent+1, "Const", v.ConstKW)
+		d.doc(indent+1, v.Doc)
+		if v.ConstKW != (token.Token{}) {
+			d.kv(indent+1, "Const", v.ConstKW)
+		}
 		d.kv(indent+1, "Name", v.Name)
+		if v.Implicit {
+			d.line(indent+1, "Implicit: true")
+		}
 
 		d.line(indent+1, "Type")
 		d.typ(indent+2, v.Type)
 
-		d.kv(indent+1, "Eq", v.Eq)
+		if !v.Implicit {
+			d.kv(indent+1, "Eq", v.Eq)
+		}
 		d.line(indent+1, "Init")
 		d.expr(indent+2, v.Init)
 
+	case *GenDecl:
+		d.line(indent, "GenDecl")
+		d.doc(indent+1, v.Doc)
+		d.kv(indent+1, "Keyword", v.Keyword)
+		d.kv(indent+1, "LParen", v.LParen)
+		if len(v.Specs) > 0 {
+			d.line(indent+1, "Specs")
+			for _, spec := range v.Specs {
+				d.decl(indent+2, spec)
+			}
+		}
+		d.kv(indent+1, "RParen", v.RParen)
+
 	case *VarDecl:
 		d.line(indent, "VarDecl")
-		d.kv(indent+1, "Var", v.VarKW)
-		d.kv(indent+1, "Name", v.Name)
-		if v.View != (token.Token{}) {
-			d.kv(indent+1, "View", v.View)
+		d.doc(indent+1, v.Doc)
+		if v.VarKW != (token.Token{}) {
+			d.kv(indent+1, "Var", v.VarKW)
 		}
+		for _, name := range v.Names {
+			d.kv(indent+1, "Name", name)
+		}
+		d.qualifier(indent+1, v.Qualifier)
 
 		d.line(indent+1, "Type")
 		d.typ(indent+2, v.Type)
 
 		d.kv(indent+1, "Eq", v.Eq)
 		d.line(indent+1, "Init")
-		d.expr(indent+2, v.Init)
+		for _, x := range v.Values {
+			d.expr(indent+2, x)
+		}
 
 	case *BadType:
 		d.line(indent+2, fmtBadType(v))
@@ -159,6 +231,18 @@ func (d *dumper) node(indent int, n any) {
 				d.kv(indent+1, "Dot", p)
 			}
 		}
+		if len(v.TypeArgs) > 0 {
+			d.line(indent+1, "TypeArgs")
+			for _, t := range v.TypeArgs {
+				d.typ(indent+2, t)
+			}
+		}
+
+	case *UnionType:
+		d.line(indent, "UnionType")
+		for _, t := range v.Terms {
+			d.typ(indent+1, t)
+		}
 
 	case *ParenExpr:
 		d.line(indent, "ParenExpr")
@@ -197,6 +281,12 @@ func (d *dumper) node(indent int, n any) {
 		d.line(indent, "CallExpr")
 		d.line(indent+1, "Callee")
 		d.expr(indent+2, v.Callee)
+		if len(v.TypeArgs) > 0 {
+			d.line(indent+1, "TypeArgs:")
+			for _, t := range v.TypeArgs {
+				d.typ(indent+2, t)
+			}
+		}
 		d.kv(indent+1, "LParent", v.LParen)
 		if len(v.Args) > 0 {
 			d.line(indent+1, "Args:")
@@ -204,15 +294,41 @@ func (d *dumper) node(indent int, n any) {
 				d.expr(indent+2, v)
 			}
 		}
+		d.ellipsis(indent+1, v.Ellipsis)
+		d.kv(indent+1, "RParent", v.RParen)
+
+	case *NilExpr:
+		d.line(indent, "NilExpr")
+		d.kv(indent+1, "Nil", v.Nil)
+
+	case *ConversionExpr:
+		d.line(indent, "ConversionExpr")
+		d.line(indent+1, "Type")
+		d.typ(indent+2, v.Type)
+		d.kv(indent+1, "LParent", v.LParen)
+		d.expr(indent+1, v.X)
 		d.kv(indent+1, "RParent", v.RParen)
 
+	case *ComptimeExpr:
+		d.line(indent, "ComptimeExpr")
+		d.kv(indent+1, "Comptime", v.ComptimeKW)
+		d.expr(indent+1, v.X)
+		if v.Result != nil {
+			d.line(indent+1, "Result")
+			d.expr(indent+2, v.Result)
+		}
+
 	case *AssignStmt:
 		d.line(indent, "AssignStmt")
 		d.line(indent+1, "Left")
-		d.expr(indent+2, v.Left)
+		for _, x := range v.Left {
+			d.expr(indent+2, x)
+		}
 		d.kv(indent+1, "Operator", v.Operator)
 		d.line(indent+1, "Right")
-		d.expr(indent+2, v.Right)
+		for _, x := range v.Right {
+			d.expr(indent+2, x)
+		}
 
 	case *ExprStmt:
 		d.expr(indent, v.Expr)
@@ -260,10 +376,12 @@ func (d *dumper) node(indent int, n any) {
 		d.kv(indent+1, "For", v.ForKW)
 		if v.Key != nil {
 			d.line(indent+1, "Key")
+			d.qualifier(indent+2, v.KeyQualifier)
 			d.expr(indent+2, v.Key)
 		}
 		if v.Value != nil {
 			d.line(indent+1, "Condition")
+			d.qualifier(indent+2, v.ValueQualifier)
 			d.expr(indent+2, v.Value)
 		}
 		d.kv(indent+1, "Op", v.Op)
@@ -280,10 +398,54 @@ func (d *dumper) node(indent int, n any) {
 	case *BreakStmt:
 		d.line(indent, "BreakStmt")
 		d.kv(indent+1, "Break", v.Break)
+		if v.Label != (token.Token{}) {
+			d.kv(indent+1, "Label", v.Label)
+		}
 
 	case *ContinueStmt:
 		d.line(indent, "ContinueStmt")
 		d.kv(indent+1, "Continue", v.Continue)
+		if v.Label != (token.Token{}) {
+			d.kv(indent+1, "Label", v.Label)
+		}
+
+	case *DeferStmt:
+		d.line(indent, "DeferStmt")
+		d.kv(indent+1, "Defer", v.Defer)
+		d.line(indent+1, "Call")
+		d.expr(indent+2, v.Call)
+
+	case *LabeledStmt:
+		d.line(indent, "LabeledStmt")
+		d.kv(indent+1, "Label", v.Label)
+		d.kv(indent+1, "Colon", v.Colon)
+		d.line(indent+1, "Stmt")
+		d.stmt(indent+2, v.Stmt)
+
+	case *MatchStmt:
+		d.line(indent, "MatchStmt")
+		d.kv(indent+1, "Match", v.Match)
+		d.line(indent+1, "X")
+		d.expr(indent+2, v.X)
+		d.kv(indent+1, "LBrace", v.LBrace)
+		for _, mc := range v.Cases {
+			d.kv(indent+1, "Case", mc.Case)
+			d.line(indent+2, "Pattern")
+			d.pattern(indent+3, mc.Pattern)
+			if mc.Guard != nil {
+				d.kv(indent+2, "If", mc.If)
+				d.line(indent+2, "Guard")
+				d.expr(indent+3, mc.Guard)
+			}
+			d.kv(indent+1, "Colon", mc.Colon)
+			if len(mc.Body) > 0 {
+				d.line(indent+2, "Body:")
+				for _, b := range mc.Body {
+					d.stmt(indent+3, b)
+				}
+			}
+		}
+		d.kv(indent+1, "RBrace", v.RBrace)
 
 	case *SwitchStmt:
 		d.line(indent, "SwitchStmt")
@@ -323,33 +485,24 @@ func (d *dumper) node(indent int, n any) {
 
 	case *StructDecl:
 		d.line(indent, "StructDecl:")
+		d.doc(indent+1, v.Doc)
 		d.kv(indent+1, "Type", v.TypeDecl)
 		d.kv(indent+1, "Name", v.Name)
+		d.typeParams(indent+1, v.TypeParams)
 		d.kv(indent+1, "Struct", v.Struct)
 		if v.Public {
 			d.line(indent+1, "Public: true")
 		}
 		d.kv(indent+1, "LBrace", v.LBrace)
-		if len(v.Fields) > 0 {
-			for _, f := range v.Fields {
-				d.kv(indent+2, "Name", f.Name)
-				if f.Public {
-					d.line(indent+2, "Public: true")
-				}
-				d.line(indent+2, "Type:")
-				d.typ(indent+3, f.Type)
-				if f.Eq != nil {
-					d.kv(indent+2, "Eq", *f.Eq)
-					d.expr(indent+2, f.Default)
-				}
-			}
-		}
+		d.fields(indent+2, v.Fields)
 		d.kv(indent+1, "RBrace", v.RBrace)
 
 	case *InterfaceDecl:
 		d.line(indent, "InterfaceDecl:")
+		d.doc(indent+1, v.Doc)
 		d.kv(indent+1, "Type", v.TypeDecl)
 		d.kv(indent+1, "Name", v.Name)
+		d.typeParams(indent+1, v.TypeParams)
 		d.kv(indent+1, "Interface", v.Interface)
 		if v.Public {
 			d.line(indent+1, "Public: true")
@@ -371,6 +524,8 @@ func (d *dumper) node(indent int, n any) {
 					for _, p := range f.Params {
 						d.line(indent+2, "Param")
 						d.kv(indent+3, "Ident", p.Name)
+						d.qualifier(indent+3, p.Qualifier)
+						d.ellipsis(indent+3, p.Ellipsis)
 						d.line(indent+3, "Type")
 						d.typ(indent+4, p.Type)
 					}
@@ -387,6 +542,7 @@ func (d *dumper) node(indent int, n any) {
 						if p.Name != (token.Token{}) {
 							d.kv(indent+4, "Ident", p.Name)
 						}
+						d.qualifier(indent+4, p.Qualifier)
 						d.line(indent+4, "Type")
 						d.typ(indent+5, p.Type)
 					}
@@ -408,6 +564,7 @@ func (d *dumper) node(indent int, n any) {
 
 	case *EnumDecl:
 		d.line(indent, "EnumDecl:")
+		d.doc(indent+1, v.Doc)
 		d.kv(indent+1, "Type", v.TypeDecl)
 		d.kv(indent+2, "Name", v.Name)
 		if v.Public {
@@ -423,8 +580,10 @@ func (d *dumper) node(indent int, n any) {
 
 	case *SumDecl:
 		d.line(indent, "SumDecl:")
+		d.doc(indent+1, v.Doc)
 		d.kv(indent+1, "Type", v.TypeDecl)
 		d.kv(indent+2, "Name", v.Name)
+		d.typeParams(indent+2, v.TypeParams)
 		d.kv(indent+2, "Sum", v.Sum)
 		if v.Public {
 			d.line(indent+1, "Public: true")
@@ -439,6 +598,7 @@ func (d *dumper) node(indent int, n any) {
 					for _, p := range f.Params {
 						d.line(indent+5, "Param")
 						d.kv(indent+6, "Ident", p.Name)
+						d.ellipsis(indent+6, p.Ellipsis)
 						d.line(indent+6, "Type")
 						d.typ(indent+7, p.Type)
 					}
@@ -450,12 +610,19 @@ func (d *dumper) node(indent int, n any) {
 	case *ComptimeBlockDecl:
 		d.line(indent, "CompTimeBlockDecl:")
 		d.kv(indent+1, "Comptime", v.ComptimeKW)
+		if v.LBrace != (token.Token{}) {
+			d.kv(indent+1, "LBrace", v.LBrace)
+		}
 		for _, dec := range v.Decls {
 			d.node(indent+2, dec)
 		}
+		if v.RBrace != (token.Token{}) {
+			d.kv(indent+1, "RBrace", v.RBrace)
+		}
 
 	case *DefinedTypeDecl:
 		d.line(indent, "DefinedTypeDecl:")
+		d.doc(indent+1, v.Doc)
 		d.kv(indent+1, "TypeDecl", v.TypeDecl)
 		d.kv(indent+2, "Name", v.Name)
 		d.line(indent+2, "Type")
@@ -474,6 +641,12 @@ func (d *dumper) node(indent int, n any) {
 		if v.High != nil {
 			d.expr(indent+1, v.High)
 		}
+		if v.Colon2 != (token.Token{}) {
+			d.kv(indent+2, "Colon", v.Colon2)
+		}
+		if v.Max != nil {
+			d.expr(indent+1, v.Max)
+		}
 		d.kv(indent+1, "RBracket", v.RBracket)
 
 	case *SliceType:
@@ -503,6 +676,34 @@ func (d *dumper) node(indent int, n any) {
 		d.line(indent+1, "ValueType:")
 		d.node(indent+2, v.ValueType)
 
+	case *FuncType:
+		d.line(indent, "FuncType")
+		d.kv(indent+1, "Func", v.Func)
+		d.kv(indent+1, "LParen", v.LParen)
+		d.line(indent+1, "Params")
+		if len(v.Params) == 0 {
+			d.line(indent+2, "(none)")
+		}
+		d.params(indent+2, v.Params)
+		d.kv(indent+1, "RParen", v.RParen)
+		if len(v.Results.List) > 0 {
+			d.line(indent+1, "Results")
+			if v.Results.LParen != (token.Token{}) {
+				d.kv(indent+2, "LParen", v.Results.LParen)
+			}
+			d.params(indent+2, v.Results.List)
+			if v.Results.RParen != (token.Token{}) {
+				d.kv(indent+2, "RParen", v.Results.RParen)
+			}
+		}
+
+	case *StructType:
+		d.line(indent, "StructType:")
+		d.kv(indent+1, "Struct", v.Struct)
+		d.kv(indent+1, "LBrace", v.LBrace)
+		d.fields(indent+2, v.Fields)
+		d.kv(indent+1, "RBrace", v.RBrace)
+
 	case *MakeExpr:
 		d.line(indent, "MakeExpr:")
 		d.kv(indent+1, "Make", v.MakeKW)
@@ -548,6 +749,85 @@ func (d *dumper) line(indent int, s string) {
 	d.w.WriteString("\n")
 }
 
+// typeParams writes the type parameters when there are some
+func (d *dumper) typeParams(indent int, params []TypeParam) {
+	if len(params) == 0 {
+		return
+	}
+
+	d.line(indent, "TypeParams")
+	for _, tp := range params {
+		d.line(indent+1, "TypeParam")
+		d.kv(indent+2, "Ident", tp.Name)
+		d.line(indent+2, "Constraint")
+		d.typ(indent+3, tp.Constraint)
+	}
+}
+
+// params writes the parameters with their optional name
+func (d *dumper) params(indent int, params []Param) {
+	for _, p := range params {
+		d.line(indent, "Param")
+		if p.Name != (token.Token{}) {
+			d.kv(indent+1, "Ident", p.Name)
+		}
+		d.qualifier(indent+1, p.Qualifier)
+		d.ellipsis(indent+1, p.Ellipsis)
+		d.line(indent+1, "Type")
+		d.typ(indent+2, p.Type)
+	}
+}
+
+// fields writes the struct fields with their optional default
+func (d *dumper) fields(indent int, fields []*FieldDecl) {
+	for _, f := range fields {
+		if f.Embedded {
+			d.line(indent, "Embedded: true")
+		} else {
+			d.kv(indent, "Name", f.Name)
+		}
+		if f.Public {
+			d.line(indent, "Public: true")
+		}
+		d.qualifier(indent, f.Qualifier)
+		d.line(indent, "Type:")
+		d.typ(indent+1, f.Type)
+		if f.Eq != nil {
+			d.kv(indent, "Eq", *f.Eq)
+			d.expr(indent, f.Default)
+		}
+	}
+}
+
+// doc writes the comments of the group when there is one
+func (d *dumper) doc(indent int, g *CommentGroup) {
+	if g == nil {
+		return
+	}
+
+	d.line(indent, "Doc")
+	for _, c := range g.List {
+		d.kv(indent+1, "Comment", c)
+	}
+}
+
+// qualifier writes the ownership qualifier when there is one
+func (d *dumper) qualifier(indent int, t token.Token) {
+	if t == (token.Token{}) {
+		return
+	}
+	d.kv(indent, "Qualifier", t)
+}
+
+// ellipsis writes the ellipsis of variadic parameters
+// and spread arguments when there is one
+func (d *dumper) ellipsis(indent int, t token.Token) {
+	if t == (token.Token{}) {
+		return
+	}
+	d.kv(indent, "Ellipsis", t)
+}
+
 func (d *dumper) kv(indent int, key string, t token.Token) {
 	d.line(indent, fmt.Sprintf("%s: %s", key, fmtTok(t)))
 }
@@ -597,7 +877,7 @@ func (d *dumper) decl(indent int, n Decl) {
 	case *FuncDecl, *BadDecl, *ConstDecl, *VarDecl, *InterfaceDecl, *EnumDecl, *SumDecl:
 		d.node(indent, v)
 
-	case *ComptimeBlockDecl, *StructDecl, *ImplementsDecl, *DefinedTypeDecl:
+	case *ComptimeBlockDecl, *StructDecl, *ImplementsDecl, *DefinedTypeDecl, *GenDecl:
 		d.node(indent, v)
 
 	default:
@@ -611,7 +891,7 @@ func (d *dumper) decl(indent int, n Decl) {
 
 func (d *dumper) typ(indent int, n Type) {
 	switch v := n.(type) {
-	case *NamedType, *BadType, *MapType, *ArrayType, *SliceType:
+	case *NamedType, *BadType, *MapType, *ArrayType, *SliceType, *UnionType, *FuncType, *StructType:
 		d.node(indent, v)
 
 	default:
@@ -631,7 +911,10 @@ func (d *dumper) stmt(indent int, n Stmt) {
 	case *ReturnStmt, *IfStmt, *ForStmt, *RangeStmt, *IncDecStmt:
 		d.node(indent, v)
 
-	case *BreakStmt, *ContinueStmt, *SwitchStmt, *FallThroughStmt, *DeclStmt:
+	case *BreakStmt, *ContinueStmt, *LabeledStmt, *DeferStmt, *SwitchStmt, *FallThroughStmt, *DeclStmt:
+		d.node(indent, v)
+
+	case *MatchStmt:
 		d.node(indent, v)
 
 	default:
@@ -643,6 +926,36 @@ func (d *dumper) stmt(indent int, n Stmt) {
 	}
 }
 
+func (d *dumper) pattern(indent int, n Pattern) {
+	switch v := n.(type) {
+	case *VariantPattern:
+		d.line(indent, "VariantPattern")
+		d.kv(indent+1, "Name", v.Name)
+		if v.LParen != (token.Token{}) {
+			d.kv(indent+1, "LParen", v.LParen)
+			for _, p := range v.Params {
+				d.pattern(indent+2, p)
+			}
+			d.kv(indent+1, "RParen", v.RParen)
+		}
+
+	case *BindingPattern:
+		d.line(indent, "BindingPattern")
+		d.kv(indent+1, "Name", v.Name)
+
+	case *WildcardPattern:
+		d.line(indent, "WildcardPattern")
+		d.kv(indent+1, "Underscore", v.Underscore)
+
+	default:
+		if n == nil {
+			d.line(indent, "(nil pattern)")
+			return
+		}
+		d.line(indent, fmt.Sprintf("<<unhandled pattern %T>>", n))
+	}
+}
+
 func (d *dumper) expr(indent int, n Expr) {
 	switch v := n.(type) {
 	case *IdentExpr, *IntLitExpr, *FloatLitExpr, *BoolLitExpr, *StringLitExpr:
@@ -654,6 +967,9 @@ func (d *dumper) expr(indent int, n Expr) {
 	case *IndexExpr, *CallExpr, *SliceExpr, *MakeExpr, *SliceLitExpr:
 		d.node(indent, v)
 
+	case *NilExpr, *ConversionExpr, *ComptimeExpr:
+		d.node(indent, v)
+
 	default:
 		if n == nil {
 			d.line(indent, "(nil expr)")
@@ -0,0 +1,254 @@
+package ast
+
+import "reflect"
+
+// Inspect traverses the AST in depth-first order starting with node.
+// f is called for each node and its children are only visited
+// when f returns true
+func Inspect(node any, f func(any) bool) {
+	if isNil(node) || !f(node) {
+		return
+	}
+
+	switch v := node.(type) {
+	case *File:
+		for _, decl := range v.Decls {
+			Inspect(decl, f)
+		}
+
+	case *FuncDecl:
+		if v.Recv != nil {
+			Inspect(v.Recv.Type, f)
+		}
+		inspectTypeParams(v.TypeParams, f)
+		inspectParams(v.Params, f)
+		inspectParams(v.Results.List, f)
+		Inspect(v.Body, f)
+
+	case *ConstDecl:
+		Inspect(v.Type, f)
+		Inspect(v.Init, f)
+
+	case *VarDecl:
+		Inspect(v.Type, f)
+		inspectExprs(v.Values, f)
+
+	case *GenDecl:
+		for _, spec := range v.Specs {
+			Inspect(spec, f)
+		}
+
+	case *StructDecl:
+		inspectTypeParams(v.TypeParams, f)
+		inspectFields(v.Fields, f)
+
+	case *InterfaceDecl:
+		inspectTypeParams(v.TypeParams, f)
+		for _, e := range v.Embeds {
+			Inspect(e, f)
+		}
+		for _, m := range v.Methods {
+			inspectParams(m.Params, f)
+			inspectParams(m.Results.List, f)
+		}
+
+	case *SumDecl:
+		inspectTypeParams(v.TypeParams, f)
+		for _, variant := range v.Variants {
+			inspectParams(variant.Params, f)
+		}
+
+	case *DefinedTypeDecl:
+		Inspect(v.Type, f)
+
+	case *ImplementsDecl:
+		Inspect(v.Interface, f)
+
+	case *ComptimeBlockDecl:
+		for _, decl := range v.Decls {
+			Inspect(decl, f)
+		}
+
+	case *BlockStmt:
+		inspectStmts(v.Stmts, f)
+
+	case *DeclStmt:
+		Inspect(v.Decl, f)
+
+	case *ExprStmt:
+		Inspect(v.Expr, f)
+
+	case *AssignStmt:
+		inspectExprs(v.Left, f)
+		inspectExprs(v.Right, f)
+
+	case *IncDecStmt:
+		Inspect(v.X, f)
+
+	case *ReturnStmt:
+		inspectExprs(v.Values, f)
+
+	case *LabeledStmt:
+		Inspect(v.Stmt, f)
+
+	case *DeferStmt:
+		Inspect(v.Call, f)
+
+	case *IfStmt:
+		Inspect(v.Condition, f)
+		Inspect(v.Then, f)
+		Inspect(v.Else, f)
+
+	case *ForStmt:
+		Inspect(v.Init, f)
+		Inspect(v.Condition, f)
+		Inspect(v.Post, f)
+		Inspect(v.Body, f)
+
+	case *RangeStmt:
+		Inspect(v.Key, f)
+		Inspect(v.Value, f)
+		Inspect(v.X, f)
+		Inspect(v.Body, f)
+
+	case *SwitchStmt:
+		Inspect(v.Init, f)
+		Inspect(v.Tag, f)
+		for _, c := range v.Cases {
+			inspectExprs(c.Values, f)
+			inspectStmts(c.Body, f)
+		}
+
+	case *MatchStmt:
+		Inspect(v.X, f)
+		for _, c := range v.Cases {
+			Inspect(c.Pattern, f)
+			Inspect(c.Guard, f)
+			inspectStmts(c.Body, f)
+		}
+
+	case *VariantPattern:
+		for _, p := range v.Params {
+			Inspect(p, f)
+		}
+
+	case *ParenExpr:
+		Inspect(v.Inner, f)
+
+	case *BinaryExpr:
+		Inspect(v.Left, f)
+		Inspect(v.Right, f)
+
+	case *UnaryExpr:
+		Inspect(v.Right, f)
+
+	case *SelectorExpr:
+		Inspect(v.X, f)
+
+	case *IndexExpr:
+		Inspect(v.X, f)
+		Inspect(v.Index, f)
+
+	case *SliceExpr:
+		Inspect(v.X, f)
+		Inspect(v.Low, f)
+		Inspect(v.High, f)
+		Inspect(v.Max, f)
+
+	case *CallExpr:
+		Inspect(v.Callee, f)
+		for _, t := range v.TypeArgs {
+			Inspect(t, f)
+		}
+		inspectExprs(v.Args, f)
+
+	case *ConversionExpr:
+		Inspect(v.Type, f)
+		Inspect(v.X, f)
+
+	case *ComptimeExpr:
+		Inspect(v.X, f)
+
+	case *SliceLitExpr:
+		Inspect(v.Type, f)
+		inspectExprs(v.Elements, f)
+
+	case *MakeExpr:
+		Inspect(v.Type, f)
+		inspectExprs(v.Args, f)
+
+	case *NamedType:
+		for _, t := range v.TypeArgs {
+			Inspect(t, f)
+		}
+
+	case *SliceType:
+		Inspect(v.Elem, f)
+
+	case *ArrayType:
+		Inspect(v.Len, f)
+		Inspect(v.Elem, f)
+
+	case *MapType:
+		Inspect(v.KeyType, f)
+		Inspect(v.ValueType, f)
+
+	case *FuncType:
+		inspectParams(v.Params, f)
+		inspectParams(v.Results.List, f)
+
+	case *StructType:
+		inspectFields(v.Fields, f)
+
+	case *UnionType:
+		for _, t := range v.Terms {
+			Inspect(t, f)
+		}
+	}
+}
+
+// inspectStmts inspects each statement of the list
+func inspectStmts(list []Stmt, f func(any) bool) {
+	for _, s := range list {
+		Inspect(s, f)
+	}
+}
+
+// inspectExprs inspects each expression of the list
+func inspectExprs(list []Expr, f func(any) bool) {
+	for _, x := range list {
+		Inspect(x, f)
+	}
+}
+
+// inspectParams inspects the type of each parameter
+func inspectParams(list []Param, f func(any) bool) {
+	for _, p := range list {
+		Inspect(p.Type, f)
+	}
+}
+
+// inspectFields inspects the type and the default of each field
+func inspectFields(list []*FieldDecl, f func(any) bool) {
+	for _, field := range list {
+		Inspect(field.Type, f)
+		Inspect(field.Default, f)
+	}
+}
+
+// inspectTypeParams inspects the constraint of each type parameter
+func inspectTypeParams(list []TypeParam, f func(any) bool) {
+	for _, tp := range list {
+		Inspect(tp.Constraint, f)
+	}
+}
+
+// isNil returns true when node is nil or a nil pointer
+// stored in an interface
+func isNil(node any) bool {
+	if node == nil {
+		return true
+	}
+	v := reflect.ValueOf(node)
+	return v.Kind() == reflect.Pointer && v.IsNil()
+}
@@ -0,0 +1,79 @@
+package ast
+
+import (
+	"testing"
+
+	"github.com/orilang/gori/token"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAst_inspect(t *testing.T) {
+	assert := assert.New(t)
+
+	ident := func(name string) *IdentExpr {
+		return &IdentExpr{Name: token.Token{Kind: token.Ident, Value: name}}
+	}
+
+	// func f(a int) { x = a[0] + g(b) }
+	f := &File{
+		Decls: []Decl{
+			&FuncDecl{
+				Name: token.Token{Kind: token.Ident, Value: "f"},
+				Params: []Param{
+					{Name: token.Token{Kind: token.Ident, Value: "a"}, Type: &NamedType{Parts: []token.Token{{Kind: token.KWInt, Value: "int"}}}},
+				},
+				Body: &BlockStmt{
+					Stmts: []Stmt{
+						&AssignStmt{
+							Left: []Expr{ident("x")},
+							Right: []Expr{&BinaryExpr{
+								Left:  &IndexExpr{X: ident("a"), Index: &IntLitExpr{Name: token.Token{Kind: token.IntLit, Value: "0"}}},
+								Right: &CallExpr{Callee: ident("g"), Args: []Expr{ident("b")}},
+							}},
+						},
+					},
+				},
+			},
+		},
+	}
+
+	t.Run("idents", func(t *testing.T) {
+		var names []string
+		Inspect(f, func(n any) bool {
+			if x, ok := n.(*IdentExpr); ok {
+				names = append(names, x.Name.Value)
+			}
+			return true
+		})
+		assert.Equal([]string{"x", "a", "g", "b"}, names)
+	})
+
+	t.Run("skip_children", func(t *testing.T) {
+		var names []string
+		Inspect(f, func(n any) bool {
+			if x, ok := n.(*IdentExpr); ok {
+				names = append(names, x.Name.Value)
+			}
+			_, call := n.(*CallExpr)
+			return !call
+		})
+		assert.Equal([]string{"x", "a"}, names)
+	})
+
+	t.Run("nil_nodes", func(t *testing.T) {
+		var count int
+		Inspect(&IfStmt{Then: nil, Else: nil}, func(n any) bool {
+			count++
+			return true
+		})
+		assert.Equal(1, count)
+
+		count = 0
+		var body *BlockStmt
+		Inspect(body, func(n any) bool {
+			count++
+			return true
+		})
+		assert.Equal(0, count)
+	})
+}
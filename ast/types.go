@@ -14,24 +14,59 @@ type dumper struct {
 
 // File holds requirements from parsed file
 type File struct {
-	PackageKW token.Token
-	Name      token.Token
-	Decls     []Decl
+	PackageKW  token.Token
+	Name       token.Token
+	Directives []*Directive
+	Imports    []*ImportDecl
+	Decls      []Decl
+}
+
+// ImportDecl holds import "path" or a group of them
+// like import ( ... )
+type ImportDecl struct {
+	ImportKW token.Token
+	LParen   token.Token // empty unless import ( ... )
+	Specs    []*ImportSpec
+	RParen   token.Token // empty unless import ( ... )
+}
+
+// ImportSpec holds an imported path with its optional name
+type ImportSpec struct {
+	Name token.Token // Ident, empty unless renamed
+	Path token.Token // StringLit
+}
+
+// CommentGroup holds comments without any blank line
+// or other token between them
+type CommentGroup struct {
+	List []token.Token // Comment or DocComment
+}
+
+// Directive holds a file directive like //ori:build linux
+type Directive struct {
+	Token token.Token // Directive
+	Name  string      // e.g build
+	Args  []string    // e.g linux
 }
 
 // FuncDecl holds function parsed content
 type FuncDecl struct {
-	FuncKW  token.Token
-	Name    token.Token
-	Params  []Param
-	Results ReturnTypes
-	Body    *BlockStmt
+	Doc        *CommentGroup // nil if no doc
+	FuncKW     token.Token
+	Recv       *Param // nil if not a method
+	Name       token.Token
+	TypeParams []TypeParam // nil if not generic
+	Params     []Param
+	Results    ReturnTypes
+	Body       *BlockStmt
 }
 
 // Params holds func parameter
 type Param struct {
-	Name token.Token
-	Type Type
+	Name      token.Token
+	Qualifier token.Token // view or shared, optional
+	Ellipsis  token.Token // empty if not variadic
+	Type      Type
 }
 
 type ReturnTypes struct {
@@ -49,21 +84,42 @@ type BlockStmt struct {
 
 // ConstDecl holds constant content
 type ConstDecl struct {
-	ConstKW token.Token // KWConst
-	Name    token.Token // Ident
-	Type    Type        // Optional
-	Eq      token.Token // Assign or Define
+	Doc     *CommentGroup // nil if no doc
+	ConstKW token.Token   // KWConst, empty inside a group
+	Name    token.Token   // Ident
+	Type    Type          // Optional
+	Eq      token.Token   // Assign or Define
 	Init    Expr
+
+	// Implicit is true when the type and the value are
+	// repeated from the previous declaration of the group
+	Implicit bool
+
+	// Iota is the index of the declaration in its group,
+	// it is the value of iota in Init
+	Iota int
 }
 
 // VarDec holds variable content
 type VarDecl struct {
-	VarKW token.Token // KWVar
-	Name  token.Token // Ident
-	View  token.Token // Optional
-	Type  Type
-	Eq    token.Token // Assign or Define
-	Init  Expr
+	Doc       *CommentGroup // nil if no doc
+	VarKW     token.Token   // KWVar, empty inside a group
+	Names     []token.Token // Ident
+	Qualifier token.Token   // view or shared, optional
+	Type      Type
+	Eq        token.Token // Assign or Define
+	Values    []Expr
+}
+
+// GenDecl holds grouped declarations like const ( ... ) or var ( ... ).
+// Inside a const group, iota is the position of the declaration
+// recorded in ConstDecl.Iota
+type GenDecl struct {
+	Doc     *CommentGroup // nil if no doc
+	Keyword token.Token   // KWConst or KWVar
+	LParen  token.Token
+	Specs   []Decl // *ConstDecl or *VarDecl
+	RParen  token.Token
 }
 
 // IdentExpr holds identifier content
@@ -158,17 +214,32 @@ type IndexExpr struct {
 
 // CallExpr handles function call
 type CallExpr struct {
-	Callee Expr
+	Callee   Expr
+	TypeArgs []Type // e.g int in f[int](x)
+	LParen   token.Token
+	Args     []Expr
+	Ellipsis token.Token // empty if the last argument is not spread
+	RParen   token.Token
+}
+
+// NilExpr holds the nil value
+type NilExpr struct {
+	Nil token.Token
+}
+
+// ConversionExpr handles conversions like int64(x) or []byte(s)
+type ConversionExpr struct {
+	Type   Type
 	LParen token.Token
-	Args   []Expr
+	X      Expr
 	RParen token.Token
 }
 
 // AssignStmt handles assignement expressions
 type AssignStmt struct {
-	Left     Expr
+	Left     []Expr
 	Operator token.Token
-	Right    Expr
+	Right    []Expr
 }
 
 // ExprStmt is used by Stmt
@@ -226,13 +297,15 @@ type ForStmt struct {
 }
 
 type RangeStmt struct {
-	ForKW token.Token
-	Key   *IdentExpr
-	Value *IdentExpr
-	Op    token.Token
-	Range token.Token
-	X     Expr
-	Body  *BlockStmt
+	ForKW          token.Token
+	KeyQualifier   token.Token // view or shared, optional
+	Key            *IdentExpr
+	ValueQualifier token.Token // view or shared, optional
+	Value          *IdentExpr
+	Op             token.Token
+	Range          token.Token
+	X              Expr
+	Body           *BlockStmt
 }
 
 type IncDecStmt struct {
@@ -242,10 +315,25 @@ type IncDecStmt struct {
 
 type BreakStmt struct {
 	Break token.Token
+	Label token.Token // empty if none
 }
 
 type ContinueStmt struct {
 	Continue token.Token
+	Label    token.Token // empty if none
+}
+
+// DeferStmt holds a call run when the function returns like defer f.Close()
+type DeferStmt struct {
+	Defer token.Token
+	Call  Expr
+}
+
+// LabeledStmt holds a statement with a label like L: for { ... }
+type LabeledStmt struct {
+	Label token.Token
+	Colon token.Token
+	Stmt  Stmt
 }
 
 type SwitchStmt struct {
@@ -268,38 +356,101 @@ type FallThroughStmt struct {
 	FallThrough token.Token
 }
 
+// MatchStmt holds pattern matching over a sum value like
+// match s { case Circle(r) if r > 0: ... case _: ... }
+type MatchStmt struct {
+	Match  token.Token
+	X      Expr
+	LBrace token.Token
+	Cases  []MatchClause
+	RBrace token.Token
+}
+
+// MatchClause holds a case of a match statement
+type MatchClause struct {
+	Case    token.Token
+	Pattern Pattern
+	If      token.Token // empty if no guard
+	Guard   Expr        // nil if no guard
+	Colon   token.Token
+	Body    []Stmt
+}
+
+type Pattern interface {
+	Position
+	patternNode()
+}
+
+// VariantPattern matches a sum variant and its params like Circle(r, _)
+type VariantPattern struct {
+	Name   token.Token
+	LParen token.Token // empty if the variant has no params
+	Params []Pattern
+	RParen token.Token // empty if the variant has no params
+}
+
+// BindingPattern binds the matched value to a name
+type BindingPattern struct {
+	Name token.Token
+}
+
+// WildcardPattern matches any value with _
+type WildcardPattern struct {
+	Underscore token.Token
+}
+
 type StructDecl struct {
-	TypeDecl token.Token
-	Name     token.Token
-	Struct   token.Token
-	Public   bool
-	LBrace   token.Token
-	Fields   []*FieldDecl
-	RBrace   token.Token
+	Doc        *CommentGroup // nil if no doc
+	TypeDecl   token.Token
+	Name       token.Token
+	TypeParams []TypeParam // nil if not generic
+	Struct     token.Token
+	Public     bool
+	LBrace     token.Token
+	Fields     []*FieldDecl
+	RBrace     token.Token
 }
 
 type FieldDecl struct {
-	Name    token.Token
-	Public  bool
-	Type    Type
-	Eq      *token.Token // nil if no default
-	Default Expr         // nil if no default
+	Name      token.Token // empty when embedded
+	Embedded  bool        // true when the field is only a type name
+	Public    bool
+	Qualifier token.Token // view or shared, optional
+	Type      Type
+	Eq        *token.Token // nil if no default
+	Default   Expr         // nil if no default
 }
 
 type NamedType struct {
 	// e.g "pkg.Type" or "Type"
 	Parts []token.Token // identifiers around dots
+
+	// e.g int in Box[int]
+	TypeArgs []Type // nil if not instantiated
+}
+
+// TypeParam holds a type parameter like T in [T any]
+type TypeParam struct {
+	Name       token.Token
+	Constraint Type
+}
+
+// UnionType holds a constraint like int | float64
+type UnionType struct {
+	Terms []Type
 }
 
 type InterfaceDecl struct {
-	TypeDecl  token.Token
-	Name      token.Token
-	Public    bool
-	Interface token.Token
-	LBrace    token.Token
-	Embeds    []Type
-	Methods   []InterfaceMethod
-	RBrace    token.Token
+	Doc        *CommentGroup // nil if no doc
+	TypeDecl   token.Token
+	Name       token.Token
+	TypeParams []TypeParam // nil if not generic
+	Public     bool
+	Interface  token.Token
+	LBrace     token.Token
+	Embeds     []Type
+	Methods    []InterfaceMethod
+	RBrace     token.Token
 }
 
 type InterfaceMethod struct {
@@ -315,6 +466,7 @@ type ImplementsDecl struct {
 }
 
 type EnumDecl struct {
+	Doc      *CommentGroup // nil if no doc
 	TypeDecl token.Token
 	Name     token.Token
 	Public   bool
@@ -325,13 +477,15 @@ type EnumDecl struct {
 }
 
 type SumDecl struct {
-	TypeDecl token.Token
-	Name     token.Token
-	Public   bool
-	Sum      token.Token
-	LBrace   token.Token
-	Variants []SumVariant
-	RBrace   token.Token
+	Doc        *CommentGroup // nil if no doc
+	TypeDecl   token.Token
+	Name       token.Token
+	TypeParams []TypeParam // nil if not generic
+	Public     bool
+	Sum        token.Token
+	LBrace     token.Token
+	Variants   []SumVariant
+	RBrace     token.Token
 }
 
 type SumVariant struct {
@@ -362,15 +516,28 @@ type SliceLitExpr struct {
 type SliceExpr struct {
 	X        Expr
 	LBracket token.Token
-	Low      Expr
+	Low      Expr // nil if omitted
 	Colon    token.Token
-	High     Expr
+	High     Expr        // nil if omitted
+	Colon2   token.Token // empty unless a[low:high:max]
+	Max      Expr        // nil unless a[low:high:max]
 	RBracket token.Token
 }
 
 type ComptimeBlockDecl struct {
 	ComptimeKW token.Token
+	LBrace     token.Token // empty unless comptime { ... }
 	Decls      []Decl
+	RBrace     token.Token // empty unless comptime { ... }
+}
+
+// ComptimeExpr holds an expression evaluated at compile time like comptime f(3)
+type ComptimeExpr struct {
+	ComptimeKW token.Token
+	X          Expr // CallExpr
+
+	// Result is the literal substituted once evaluated, nil until then
+	Result Expr
 }
 
 type MapType struct {
@@ -381,6 +548,23 @@ type MapType struct {
 	ValueType Type
 }
 
+// FuncType holds a function type like func(int, string) bool
+type FuncType struct {
+	Func    token.Token
+	LParen  token.Token
+	Params  []Param
+	RParen  token.Token
+	Results ReturnTypes
+}
+
+// StructType holds an anonymous struct type like struct { a int }
+type StructType struct {
+	Struct token.Token
+	LBrace token.Token
+	Fields []*FieldDecl
+	RBrace token.Token
+}
+
 type MakeExpr struct {
 	MakeKW token.Token
 	LParen token.Token
@@ -390,6 +574,7 @@ type MakeExpr struct {
 }
 
 type DefinedTypeDecl struct {
+	Doc      *CommentGroup // nil if no doc
 	TypeDecl token.Token
 	Name     token.Token
 	Type     Type
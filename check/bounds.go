@@ -0,0 +1,77 @@
+package check
+
+import (
+	"fmt"
+
+	"github.com/orilang/gori/ast"
+	"github.com/orilang/gori/constant"
+	"github.com/orilang/gori/token"
+)
+
+// checkIndex reports a constant index of an array
+// with a constant length which is out of range
+func (c *Checker) checkIndex(x *ast.IndexExpr) {
+	n, ok := c.arrayLen(x.X)
+	if !ok {
+		return
+	}
+	if i, ok := c.constInt(x.Index); ok && (i < 0 || i >= n) {
+		c.reportBounds(x.Index.Start(), i, n)
+	}
+}
+
+// checkSlice reports constant slice bounds of an array
+// with a constant length which are out of range.
+// Unlike indexes, a bound can be equal to the length
+func (c *Checker) checkSlice(x *ast.SliceExpr) {
+	n, ok := c.arrayLen(x.X)
+	if !ok {
+		return
+	}
+	for _, bound := range []ast.Expr{x.Low, x.High, x.Max} {
+		if i, ok := c.constInt(bound); ok && (i < 0 || i > n) {
+			c.reportBounds(bound.Start(), i, n)
+		}
+	}
+}
+
+// reportBounds records a diagnostic for index i out of range of length n
+func (c *Checker) reportBounds(tok token.Token, i, n int64) {
+	c.diagnostics = append(c.diagnostics, &Diagnostic{
+		Err:   fmt.Errorf("%w: index %d with length %d", ErrIndexOutOfRange, i, n),
+		Token: tok,
+	})
+}
+
+// arrayLen returns the length of x when it is a binding
+// declared with an array type of constant length like [3]int
+func (c *Checker) arrayLen(x ast.Expr) (int64, bool) {
+	ident, ok := x.(*ast.IdentExpr)
+	if !ok {
+		return 0, false
+	}
+	b := c.lookup(ident.Name.Value)
+	if b == nil {
+		return 0, false
+	}
+	at, ok := b.typ.(*ast.ArrayType)
+	if !ok {
+		return 0, false
+	}
+	return c.constInt(at.Len)
+}
+
+// constInt returns the value of x when it is a constant integer
+func (c *Checker) constInt(x ast.Expr) (int64, bool) {
+	if x == nil {
+		return 0, false
+	}
+	v, err := c.consts.Eval(x)
+	if err == nil {
+		v, err = constant.Represent(v, "int", x.Start())
+	}
+	if err != nil {
+		return 0, false
+	}
+	return v.Int.Int64(), true
+}
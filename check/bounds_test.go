@@ -0,0 +1,74 @@
+package check
+
+import (
+	"context"
+	"testing"
+
+	"github.com/orilang/gori/lexer"
+	"github.com/orilang/gori/parser"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCheck_bounds(t *testing.T) {
+	assert := assert.New(t)
+
+	tests := []struct {
+		name     string
+		input    string
+		expected []string
+	}{
+		{
+			name: "valid",
+			input: `package main
+
+func f(a [3]int, s []int) {
+  x := a[0] + a[2]
+  y := a[1:3]
+  z := a[:3:3]
+  w := s[10]
+  a[2] = 1
+}`,
+		},
+		{
+			name: "index_out_of_range",
+			input: `package main
+
+func f(a [3]int) {
+  x := a[3]
+  a[-1] = 1
+}`,
+			expected: []string{
+				`4:10: index out of range: index 3 with length 3, got "3"`,
+				`5:5: index out of range: index -1 with length 3, got "-"`,
+			},
+		},
+		{
+			name: "slice_out_of_range",
+			input: `package main
+
+func f(a [4]int) {
+  x := a[1:5]
+  y := a[0:2:8]
+}`,
+			expected: []string{
+				`4:12: index out of range: index 5 with length 4, got "5"`,
+				`5:14: index out of range: index 8 with length 4, got "8"`,
+			},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			lex, err := lexer.NewLexer(lexer.Config{StringOnly: true})
+			assert.Nil(err)
+			tree, err := parser.New(lex.FetchTokensFromString(tc.input)).ParseFile(context.Background())
+			assert.Nil(err)
+
+			var result []string
+			for _, d := range Check(tree) {
+				result = append(result, d.Error())
+			}
+			assert.Equal(tc.expected, result)
+		})
+	}
+}
@@ -0,0 +1,39 @@
+package check
+
+import (
+	"fmt"
+
+	"github.com/orilang/gori/ast"
+	"github.com/orilang/gori/token"
+)
+
+// checkBuiltinCall reports calls of builtin functions like len
+// with a wrong number of arguments.
+// Builtins shadowed by a binding are not taken into account
+func (c *Checker) checkBuiltinCall(callee *ast.IdentExpr, call *ast.CallExpr) {
+	b, ok := token.LookupBuiltin(callee.Name.Value)
+	if !ok || c.lookup(callee.Name.Value) != nil {
+		return
+	}
+
+	n := len(call.Args)
+	if n >= b.Min && (b.Max == -1 || n <= b.Max) {
+		return
+	}
+
+	c.diagnostics = append(c.diagnostics, &Diagnostic{
+		Err:   fmt.Errorf("%w to %s: want %s, have %d", ErrBuiltinArgs, b.Name, arity(b), n),
+		Token: callee.Name,
+	})
+}
+
+// arity returns the number of arguments accepted by b like 1, 2 or at least 1
+func arity(b token.Builtin) string {
+	switch b.Max {
+	case -1:
+		return fmt.Sprintf("at least %d", b.Min)
+	case b.Min:
+		return fmt.Sprintf("%d", b.Min)
+	}
+	return fmt.Sprintf("%d to %d", b.Min, b.Max)
+}
@@ -0,0 +1,74 @@
+package check
+
+import (
+	"context"
+	"testing"
+
+	"github.com/orilang/gori/lexer"
+	"github.com/orilang/gori/parser"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCheck_builtins(t *testing.T) {
+	assert := assert.New(t)
+
+	tests := []struct {
+		name     string
+		input    string
+		expected []string
+	}{
+		{
+			name: "valid",
+			input: `package main
+
+func f(s []int, m map[string]int) {
+  a := append(s, 1, 2)
+  n := len(s) + cap(s)
+  delete(m, "a")
+  copy(s, a)
+}`,
+		},
+		{
+			name: "wrong_arguments",
+			input: `package main
+
+func f(s []int) {
+  a := append()
+  n := len(s, s)
+  delete(s)
+}`,
+			expected: []string{
+				`4:8: wrong number of arguments to append: want at least 1, have 0, got "append"`,
+				`5:8: wrong number of arguments to len: want 1, have 2, got "len"`,
+				`6:3: wrong number of arguments to delete: want 2, have 1, got "delete"`,
+			},
+		},
+		{
+			name: "shadowed",
+			input: `package main
+
+func f(len int) int {
+  return len
+}
+
+func cap(a int, b int) int {
+  return cap(a, b)
+}`,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			lex, err := lexer.NewLexer(lexer.Config{StringOnly: true})
+			assert.Nil(err)
+			tree, err := parser.New(lex.FetchTokensFromString(tc.input)).ParseFile(context.Background())
+			assert.Nil(err)
+
+			var result []string
+			for _, d := range Check(tree) {
+				result = append(result, d.Error())
+			}
+			assert.Equal(tc.expected, result)
+		})
+	}
+}
@@ -0,0 +1,162 @@
+package check
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"slices"
+
+	"github.com/orilang/gori/ast"
+	"github.com/orilang/gori/constant"
+	"github.com/orilang/gori/lexer"
+	"github.com/orilang/gori/modfile"
+	"github.com/orilang/gori/parser"
+	"github.com/orilang/gori/pool"
+	"github.com/orilang/gori/token"
+	"github.com/orilang/gori/walk"
+)
+
+// NewChecker returns files config to StartChecking
+func NewChecker(config Config) (*Files, error) {
+	w, err := walk.Walk(walk.Config{File: config.File, Directory: config.Directory, Tags: config.Tags})
+	if err != nil {
+		return nil, err
+	}
+
+	return &Files{
+		Files:    w.Files,
+		jobs:     config.Jobs,
+		tabWidth: config.TabWidth,
+		fix:      config.Fix,
+	}, nil
+}
+
+// StartChecking checks files in parallel with at most jobs workers.
+// Lexing and parsing errors and diagnostics of all files are returned
+// joined together in files order
+func (f *Files) StartChecking(ctx context.Context) error {
+	var errs []error
+	err := pool.Run(ctx, f.jobs, len(f.Files),
+		func(ctx context.Context, i int) (result, error) {
+			return f.checkFile(ctx, f.Files[i])
+		},
+		func(i int, r result) error {
+			errs = append(errs, lexer.FileErrors(f.Files[i], r.errors)...)
+			return nil
+		},
+	)
+	if err != nil {
+		return err
+	}
+	return errors.Join(errs...)
+}
+
+// checkFile parses the provided file and returns
+// the lexing and parsing errors with the diagnostics found.
+// When fix is set and the file is free of lexing and parsing errors,
+// the suggested fixes are written to the file
+// and only the diagnostics without fix are returned.
+// .mod files are validated as module manifests
+func (f *Files) checkFile(ctx context.Context, file string) (result, error) {
+	src, err := os.ReadFile(file)
+	if err != nil {
+		return result{}, err
+	}
+
+	if filepath.Ext(file) == ".mod" {
+		_, errs := modfile.Parse(src)
+		return result{errors: errs}, nil
+	}
+
+	l := lexer.NewReader(bytes.NewReader(src))
+	l.TabWidth = f.tabWidth
+	p := parser.NewStream(l)
+	tree, err := p.ParseFile(ctx)
+	if err != nil {
+		return result{}, err
+	}
+
+	diagnostics := Check(tree)
+	if f.fix && len(l.Errors) == 0 && len(p.Errors()) == 0 {
+		if diagnostics, err = fixFile(file, src, diagnostics); err != nil {
+			return result{}, err
+		}
+	}
+
+	r := result{errors: append(slices.Clip(l.Errors), p.Errors()...)}
+	for _, d := range diagnostics {
+		r.errors = append(r.errors, d)
+	}
+	return r, nil
+}
+
+// fixFile writes src with the fixes of the diagnostics applied to file
+// and returns the diagnostics left
+func fixFile(file string, src []byte, diagnostics []*Diagnostic) ([]*Diagnostic, error) {
+	var left []*Diagnostic
+	for _, d := range diagnostics {
+		if d.Fix == nil {
+			left = append(left, d)
+		}
+	}
+	if len(left) == len(diagnostics) {
+		return diagnostics, nil
+	}
+
+	info, err := os.Stat(file)
+	if err != nil {
+		return nil, err
+	}
+	if err := os.WriteFile(file, ApplyFixes(src, diagnostics), info.Mode().Perm()); err != nil {
+		return nil, err
+	}
+	return left, nil
+}
+
+// Check runs the semantic analysis of the file
+// and returns the diagnostics found
+func Check(file *ast.File) []*Diagnostic {
+	c := &Checker{
+		funcs:    make(map[string]*ast.FuncDecl),
+		types:    make(map[string]ast.Decl),
+		consts:   constant.New(file.Decls),
+		reported: make(map[string]bool),
+	}
+	c.consts.Resolve = c.resolveConst
+	c.declareTypes(file.Decls)
+	c.checkStructs(file)
+	c.checkDecls(file.Decls)
+	fns := funcDecls(file.Decls)
+	for _, fn := range fns {
+		if fn.Recv == nil {
+			c.funcs[fn.Name.Value] = fn
+		}
+	}
+
+	for _, fn := range fns {
+		c.checkFunc(fn)
+	}
+	return c.diagnostics
+}
+
+// funcDecls returns the functions of decls including
+// the ones inside comptime blocks
+func funcDecls(decls []ast.Decl) []*ast.FuncDecl {
+	var result []*ast.FuncDecl
+	for _, decl := range decls {
+		switch v := decl.(type) {
+		case *ast.FuncDecl:
+			result = append(result, v)
+		case *ast.ComptimeBlockDecl:
+			result = append(result, funcDecls(v.Decls)...)
+		}
+	}
+	return result
+}
+
+// report records a diagnostic
+func (c *Checker) report(err error, tok, qualifier token.Token) {
+	c.diagnostics = append(c.diagnostics, &Diagnostic{Err: err, Token: tok, Qualifier: qualifier})
+}
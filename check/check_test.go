@@ -0,0 +1,141 @@
+package check
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/orilang/gori/lexer"
+	"github.com/orilang/gori/modfile"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCheck(t *testing.T) {
+	assert := assert.New(t)
+
+	t.Run("success", func(t *testing.T) {
+		c, err := NewChecker(Config{Directory: "../testdata/syntax/valid", Jobs: 2})
+		assert.Nil(err)
+		assert.Nil(c.StartChecking(context.Background()))
+	})
+
+	t.Run("diagnostics", func(t *testing.T) {
+		file := filepath.Join("../testdata", "ownership/borrow.ori")
+		c, err := NewChecker(Config{File: file})
+		assert.Nil(err)
+
+		err = c.StartChecking(context.Background())
+		assert.ErrorIs(err, ErrMutateWhileViewed)
+		var d *Diagnostic
+		if assert.True(errors.As(err, &d)) {
+			assert.Equal(6, d.Token.Line)
+			assert.Equal("view", d.Qualifier.Value)
+		}
+		assert.Equal(file+":6:3: cannot mutate value while a view of it is live, got \"x\" (view at 5:9)", err.Error())
+	})
+
+	t.Run("lexing_errors", func(t *testing.T) {
+		c, err := NewChecker(Config{File: "../testdata/illegal/string.ori"})
+		assert.Nil(err)
+		assert.ErrorIs(c.StartChecking(context.Background()), lexer.ErrUnterminatedString)
+	})
+
+	t.Run("parsing_errors", func(t *testing.T) {
+		file := filepath.Join("../testdata", "syntax/invalid/recovery.ori")
+		c, err := NewChecker(Config{File: file})
+		assert.Nil(err)
+
+		err = c.StartChecking(context.Background())
+		if assert.Error(err) {
+			assert.Contains(err.Error(), file+":4:5: unsupported type with")
+			assert.Contains(err.Error(), file+":9:12: unexpected expression")
+		}
+	})
+
+	t.Run("fix_skips_parsing_errors", func(t *testing.T) {
+		dir := t.TempDir()
+		file := filepath.Join(dir, "main.ori")
+		src := "package main\n\ntype Color enum {\n\tRed\n\tGreen\n}\n\nfunc f(c Color) {\n\tswitch c {\n\tcase Red:\n\t}\n\tvar x int = )\n}\n"
+		assert.Nil(os.WriteFile(file, []byte(src), 0o600))
+
+		c, err := NewChecker(Config{File: file, Fix: true})
+		assert.Nil(err)
+		assert.ErrorIs(c.StartChecking(context.Background()), ErrMissingCases)
+
+		b, err := os.ReadFile(file)
+		assert.Nil(err)
+		assert.Equal(src, string(b))
+	})
+
+	t.Run("error_no_such_file", func(t *testing.T) {
+		_, err := NewChecker(Config{File: "../testdata/main.ori"})
+		assert.Error(err)
+	})
+
+	t.Run("error_removed_file", func(t *testing.T) {
+		dir := t.TempDir()
+		file := filepath.Join(dir, "main.ori")
+		assert.Nil(os.WriteFile(file, []byte("package main\n"), 0o600))
+
+		c, err := NewChecker(Config{File: file})
+		assert.Nil(err)
+		assert.Nil(os.Remove(file))
+		assert.Error(c.StartChecking(context.Background()))
+	})
+
+	t.Run("module", func(t *testing.T) {
+		c, err := NewChecker(Config{Directory: "../testdata/module"})
+		assert.Nil(err)
+		assert.Nil(c.StartChecking(context.Background()))
+
+		file := filepath.Join("../testdata", "module_invalid/ori.mod")
+		c, err = NewChecker(Config{File: file})
+		assert.Nil(err)
+		err = c.StartChecking(context.Background())
+		assert.ErrorIs(err, modfile.ErrInvalidVersion)
+		assert.Equal(file+":3:5: invalid ori version, got \"v1\"\n"+file+":5:9: replaced module is not required, got \"github.com/orilang/lib\"", err.Error())
+	})
+
+	t.Run("cancelled", func(t *testing.T) {
+		c, err := NewChecker(Config{Directory: "../testdata/success"})
+		assert.Nil(err)
+
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+		assert.ErrorIs(c.StartChecking(ctx), context.Canceled)
+	})
+
+	t.Run("fix", func(t *testing.T) {
+		dir := t.TempDir()
+		file := filepath.Join(dir, "main.ori")
+		src := "package main\n\ntype Color enum {\n\tRed\n\tGreen\n}\n\nfunc f(c Color) {\n\tswitch c {\n\tcase Red:\n\t}\n}\n"
+		assert.Nil(os.WriteFile(file, []byte(src), 0o600))
+
+		c, err := NewChecker(Config{File: file})
+		assert.Nil(err)
+		assert.ErrorIs(c.StartChecking(context.Background()), ErrMissingCases)
+
+		c, err = NewChecker(Config{File: file, Fix: true})
+		assert.Nil(err)
+		assert.Nil(c.StartChecking(context.Background()))
+
+		b, err := os.ReadFile(file)
+		assert.Nil(err)
+		assert.Equal("package main\n\ntype Color enum {\n\tRed\n\tGreen\n}\n\nfunc f(c Color) {\n\tswitch c {\n\tcase Red:\n\tcase Green:\n\t}\n}\n", string(b))
+	})
+
+	t.Run("fix_keeps_other_diagnostics", func(t *testing.T) {
+		file := filepath.Join("../testdata", "ownership/borrow.ori")
+		c, err := NewChecker(Config{File: file, Fix: true})
+		assert.Nil(err)
+		assert.ErrorIs(c.StartChecking(context.Background()), ErrMutateWhileViewed)
+	})
+
+	t.Run("diagnostic_without_qualifier", func(t *testing.T) {
+		d := &Diagnostic{Err: ErrNotShared}
+		d.Token.Line, d.Token.Column, d.Token.Value = 1, 2, "x"
+		assert.Equal(`1:2: expected shared value, got "x"`, d.Error())
+	})
+}
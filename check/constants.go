@@ -0,0 +1,163 @@
+package check
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/orilang/gori/ast"
+	"github.com/orilang/gori/constant"
+	"github.com/orilang/gori/token"
+)
+
+// checkDecls reports the invalid constant expressions
+// of the top level declarations other than functions
+func (c *Checker) checkDecls(decls []ast.Decl) {
+	for _, decl := range decls {
+		switch v := decl.(type) {
+		case *ast.FuncDecl:
+		case *ast.ConstDecl:
+			c.checkConstDecl(v)
+		case *ast.VarDecl:
+			c.checkConstants(v.Type)
+			c.checkVarValues(v)
+		case *ast.GenDecl:
+			c.checkDecls(v.Specs)
+		case *ast.ComptimeBlockDecl:
+			c.checkDecls(v.Decls)
+		default:
+			c.checkConstants(v)
+		}
+	}
+}
+
+// declareConst checks the constant declared in a function
+// and makes it visible to the rest of the block
+func (c *Checker) declareConst(d *ast.ConstDecl) {
+	c.checkConstDecl(d)
+	b := c.declare(d.Name, token.Token{}, true)
+	b.typ = d.Type
+	b.konst = d
+}
+
+// resolveConst returns the constant declared in the function as name.
+// found is false when name is not declared in the function
+func (c *Checker) resolveConst(name string) (*ast.ConstDecl, bool) {
+	b := c.lookup(name)
+	if b == nil {
+		return nil, false
+	}
+	return b.konst, true
+}
+
+// checkConstDecl reports the constant when its value is
+// invalid or does not fit in its type
+func (c *Checker) checkConstDecl(d *ast.ConstDecl) {
+	if !d.Implicit {
+		c.checkConstants(d.Type)
+	}
+	_, err := c.consts.Const(d)
+	c.reportConst(err)
+}
+
+// checkVarValues reports the constant values of v which are
+// invalid or do not fit in its type.
+// Values which are not constant are only checked as expressions
+func (c *Checker) checkVarValues(v *ast.VarDecl) {
+	for _, x := range v.Values {
+		c.checkExpr(x)
+		value, err := c.consts.Eval(x)
+		if errors.Is(err, constant.ErrNotConstant) {
+			continue
+		}
+		if err == nil {
+			_, err = constant.Convert(value, v.Type, x.Start())
+		}
+		c.reportConst(err)
+	}
+}
+
+// checkConstants reports the array lengths, make arguments,
+// field defaults and comptime calls of node which are not valid constants
+func (c *Checker) checkConstants(node any) {
+	ast.Inspect(node, c.checkConstNode)
+}
+
+// checkConstNode reports n when the constants it requires are invalid.
+// It returns false when the children of n are already checked
+func (c *Checker) checkConstNode(n any) bool {
+	switch v := n.(type) {
+	case *ast.ArrayType:
+		c.checkLength(v.Len)
+	case *ast.MakeExpr:
+		for _, x := range v.Args {
+			c.checkLength(x)
+		}
+	case *ast.StructDecl:
+		c.checkFields(v.Fields)
+		return false
+	case *ast.StructType:
+		c.checkFields(v.Fields)
+		return false
+	case *ast.ComptimeExpr:
+		c.checkComptimeExpr(v)
+	}
+	return true
+}
+
+// checkComptimeExpr executes the call marked as compile-time.
+// The evaluator substitutes its result
+func (c *Checker) checkComptimeExpr(x *ast.ComptimeExpr) {
+	_, err := c.consts.Eval(x)
+	c.reportConst(err)
+}
+
+// checkFields reports the field defaults which are
+// not valid constants of the field type
+func (c *Checker) checkFields(fields []*ast.FieldDecl) {
+	for _, f := range fields {
+		c.checkConstants(f.Type)
+		if f.Default == nil {
+			continue
+		}
+
+		v, err := c.consts.Eval(f.Default)
+		if err == nil {
+			_, err = constant.Convert(v, f.Type, f.Default.Start())
+		}
+		c.reportConst(err)
+	}
+}
+
+// checkLength reports x when it is not a constant
+// integer usable as a length like in [3]int
+func (c *Checker) checkLength(x ast.Expr) {
+	if x == nil {
+		return
+	}
+
+	v, err := c.consts.Eval(x)
+	if err == nil {
+		v, err = constant.Represent(v, "int", x.Start())
+	}
+	if err != nil {
+		c.reportConst(err)
+		return
+	}
+
+	if v.Int.Sign() < 0 {
+		c.diagnostics = append(c.diagnostics, &Diagnostic{
+			Err:   fmt.Errorf("%w: %s", ErrNegativeLength, v),
+			Token: x.Start(),
+		})
+	}
+}
+
+// reportConst records the constant error err once
+func (c *Checker) reportConst(err error) {
+	var e *constant.Error
+	if !errors.As(err, &e) || c.reported[e.Error()] {
+		return
+	}
+	c.reported[e.Error()] = true
+	c.diagnostics = append(c.diagnostics, &Diagnostic{Err: e.Err, Token: e.Token})
+}
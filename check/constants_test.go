@@ -0,0 +1,190 @@
+package check
+
+import (
+	"context"
+	"testing"
+
+	"github.com/orilang/gori/ast"
+	"github.com/orilang/gori/lexer"
+	"github.com/orilang/gori/parser"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCheck_constants(t *testing.T) {
+	assert := assert.New(t)
+
+	tests := []struct {
+		name     string
+		input    string
+		expected []string
+	}{
+		{
+			name: "valid",
+			input: `package main
+
+const size int = 2 * 4
+
+type Point struct {
+  X int = size / 2
+  Y float = 1.5
+}
+
+func f(a [size]int, n int) {
+  const half int = size / 2
+  var b [half]int = a[:half]
+  var x int8 = -128
+  var y int = n * 300
+  s := make([]int, 2 * 2, 8)
+}`,
+		},
+		{
+			name: "overflow",
+			input: `package main
+
+var x int8 = 300
+
+func f() {
+  var y uint8 = 255 + 1
+}`,
+			expected: []string{
+				`3:14: constant overflow: 300 does not fit in int8, got "300"`,
+				`6:17: constant overflow: 256 does not fit in uint8, got "255"`,
+			},
+		},
+		{
+			name: "not_constant",
+			input: `package main
+
+type Point struct {
+  X int = f()
+}
+
+func f(n int) [n]int {
+  s := make([]int, 1 + n)
+}`,
+			expected: []string{
+				`4:11: value is not constant, got "f"`,
+				`7:16: value is not constant, got "n"`,
+				`8:24: value is not constant, got "n"`,
+			},
+		},
+		{
+			name: "invalid_length",
+			input: `package main
+
+const n int = 1 / 0
+
+func f(a [-1]int, b [2.5]int, c [n]int, d [n]int) {
+}`,
+			expected: []string{
+				`3:19: division by zero, got "0"`,
+				`5:11: negative length: -1, got "-"`,
+				`5:22: constant truncated to integer: 2.5, got "2.5"`,
+			},
+		},
+		{
+			name: "implicit",
+			input: `package main
+
+const (
+  a int8 = 200
+  b
+)`,
+			expected: []string{
+				`4:12: constant overflow: 200 does not fit in int8, got "200"`,
+			},
+		},
+		{
+			name: "iota",
+			input: `package main
+
+const (
+  A int = iota
+  B
+  C
+)
+
+var a [C]int
+`,
+		},
+		{
+			name: "iota_overflow",
+			input: `package main
+
+const (
+  a int8 = iota * 100
+  b
+  c
+)`,
+			expected: []string{
+				`4:12: constant overflow: 200 does not fit in int8, got "iota"`,
+			},
+		},
+		{
+			name: "comptime",
+			input: `package main
+
+var counter int = 0
+
+comptime {
+  func square(n int) int {
+    return n * n
+  }
+
+  func tick() int {
+    return counter
+  }
+}
+
+func f() {
+  var a [comptime square(3)]int = make([]int, 9)
+  var b int8 = comptime square(12)
+  var c int = comptime tick()
+}`,
+			expected: []string{
+				`17:16: constant overflow: 144 does not fit in int8, got "comptime"`,
+				`11:12: comptime code uses non-constant state, got "counter"`,
+			},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			lex, err := lexer.NewLexer(lexer.Config{StringOnly: true})
+			assert.Nil(err)
+			tree, err := parser.New(lex.FetchTokensFromString(tc.input)).ParseFile(context.Background())
+			assert.Nil(err)
+
+			var result []string
+			for _, d := range Check(tree) {
+				result = append(result, d.Error())
+			}
+			assert.Equal(tc.expected, result)
+		})
+	}
+
+	t.Run("comptime_result", func(t *testing.T) {
+		input := `package main
+
+comptime {
+  func sq(n int) int {
+    return n * n
+  }
+}
+
+const F int = comptime sq(5)`
+
+		lex, err := lexer.NewLexer(lexer.Config{StringOnly: true})
+		assert.Nil(err)
+		tree, err := parser.New(lex.FetchTokensFromString(input)).ParseFile(context.Background())
+		assert.Nil(err)
+		assert.Nil(Check(tree))
+
+		x := tree.Decls[1].(*ast.ConstDecl).Init.(*ast.ComptimeExpr)
+		if assert.NotNil(x.Result) {
+			assert.Equal("25", x.Result.Start().Value)
+			assert.Equal(9, x.Result.Start().Line)
+			assert.Equal(15, x.Result.Start().Column)
+		}
+	})
+}
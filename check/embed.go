@@ -0,0 +1,139 @@
+package check
+
+import (
+	"github.com/orilang/gori/ast"
+	"github.com/orilang/gori/token"
+)
+
+// checkStructs reports the duplicate fields of the structs of file
+// and the fields colliding with the ones promoted by embedded structs
+func (c *Checker) checkStructs(file *ast.File) {
+	ast.Inspect(file, func(n any) bool {
+		switch v := n.(type) {
+		case *ast.StructDecl:
+			c.checkFieldNames(v.Name.Value, v.Fields)
+		case *ast.StructType:
+			c.checkFieldNames("", v.Fields)
+		}
+		return true
+	})
+}
+
+// checkFieldNames reports the fields of the struct called owner
+// declared twice, the named fields hiding a promoted one and
+// the fields promoted by several embedded structs.
+// owner is empty for anonymous structs
+func (c *Checker) checkFieldNames(owner string, fields []*ast.FieldDecl) {
+	declared := make(map[string]token.Token)
+	var embedded []*ast.FieldDecl
+	for _, f := range fields {
+		name := fieldName(f)
+		if name.Value == "" || name.Value == "_" {
+			continue
+		}
+		if _, ok := declared[name.Value]; ok {
+			c.report(ErrDuplicateField, name, token.Token{})
+			continue
+		}
+		declared[name.Value] = name
+		if f.Embedded {
+			embedded = append(embedded, f)
+		}
+	}
+
+	promoted := make(map[string]token.Token)
+	for _, f := range embedded {
+		for _, name := range c.promotedFields(f.Type, map[string]bool{owner: true}) {
+			if tok, ok := declared[name.Value]; ok {
+				c.report(ErrFieldCollision, tok, name)
+				continue
+			}
+			if tok, ok := promoted[name.Value]; ok {
+				c.report(ErrFieldCollision, fieldName(f), tok)
+				continue
+			}
+			promoted[name.Value] = name
+		}
+	}
+}
+
+// promotedFields returns the names of the fields promoted by
+// the embedded type typ which are the fields of the struct it names
+// and the ones promoted to it.
+// visiting holds the structs being resolved to stop on cycles
+func (c *Checker) promotedFields(typ ast.Type, visiting map[string]bool) []token.Token {
+	s := c.structOf(typ)
+	if s == nil || visiting[s.Name.Value] {
+		return nil
+	}
+	visiting[s.Name.Value] = true
+
+	var result []token.Token
+	direct := make(map[string]bool)
+	for _, f := range s.Fields {
+		name := fieldName(f)
+		direct[name.Value] = true
+		result = append(result, name)
+	}
+	for _, f := range s.Fields {
+		if !f.Embedded {
+			continue
+		}
+		for _, name := range c.promotedFields(f.Type, visiting) {
+			if !direct[name.Value] {
+				result = append(result, name)
+			}
+		}
+	}
+	return result
+}
+
+// fieldType returns the type of the field called name of s
+// including the fields promoted by its embedded structs
+// or nil when s has no such field
+func (c *Checker) fieldType(s *ast.StructDecl, name string, visiting map[string]bool) ast.Type {
+	if visiting[s.Name.Value] {
+		return nil
+	}
+	visiting[s.Name.Value] = true
+
+	for _, f := range s.Fields {
+		if fieldName(f).Value == name {
+			return f.Type
+		}
+	}
+	for _, f := range s.Fields {
+		if !f.Embedded {
+			continue
+		}
+		if e := c.structOf(f.Type); e != nil {
+			if typ := c.fieldType(e, name, visiting); typ != nil {
+				return typ
+			}
+		}
+	}
+	return nil
+}
+
+// structOf returns the struct declared in the file
+// and named by typ or nil when there is none
+func (c *Checker) structOf(typ ast.Type) *ast.StructDecl {
+	nt, ok := typ.(*ast.NamedType)
+	if !ok || len(nt.Parts) != 1 {
+		return nil
+	}
+	s, _ := c.types[nt.Parts[0].Value].(*ast.StructDecl)
+	return s
+}
+
+// fieldName returns the name of the field which is
+// the last identifier of the type when it is embedded
+func fieldName(f *ast.FieldDecl) token.Token {
+	if !f.Embedded {
+		return f.Name
+	}
+	if nt, ok := f.Type.(*ast.NamedType); ok && len(nt.Parts) > 0 {
+		return nt.Parts[len(nt.Parts)-1]
+	}
+	return token.Token{}
+}
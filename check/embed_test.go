@@ -0,0 +1,129 @@
+package check
+
+import (
+	"context"
+	"testing"
+
+	"github.com/orilang/gori/lexer"
+	"github.com/orilang/gori/parser"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCheck_embed(t *testing.T) {
+	assert := assert.New(t)
+
+	tests := []struct {
+		name     string
+		input    string
+		expected []string
+	}{
+		{
+			name: "promoted",
+			input: `package main
+
+type Color enum {
+  Red
+  Green
+}
+
+type Base struct {
+  color Color
+}
+
+type Pixel struct {
+  Base
+  x int
+}
+
+type Sprite struct {
+  Pixel
+}
+
+func f(s Sprite) {
+  switch s.color {
+  case Red:
+  }
+}`,
+			expected: []string{`22:3: missing cases: Green, got "switch"`},
+		},
+		{
+			name: "collision",
+			input: `package main
+
+type Base struct {
+  x int
+}
+
+type Point struct {
+  Base
+  x int
+}`,
+			expected: []string{`9:3: field collides with a promoted field, got "x" (x at 4:3)`},
+		},
+		{
+			name: "ambiguous",
+			input: `package main
+
+type A struct {
+  id int
+}
+
+type B struct {
+  id string
+}
+
+type C struct {
+  A
+  B
+}`,
+			expected: []string{`13:3: field collides with a promoted field, got "B" (id at 4:3)`},
+		},
+		{
+			name: "duplicate",
+			input: `package main
+
+type Base struct {
+  x int
+}
+
+type Point struct {
+  Base
+  x int
+  x float
+  Base
+}`,
+			expected: []string{
+				`10:3: duplicate field, got "x"`,
+				`11:3: duplicate field, got "Base"`,
+				`9:3: field collides with a promoted field, got "x" (x at 4:3)`,
+			},
+		},
+		{
+			name: "cycle",
+			input: `package main
+
+type A struct {
+  B
+}
+
+type B struct {
+  A
+}`,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			lex, err := lexer.NewLexer(lexer.Config{StringOnly: true})
+			assert.Nil(err)
+			tree, err := parser.New(lex.FetchTokensFromString(tc.input)).ParseFile(context.Background())
+			assert.Nil(err)
+
+			var result []string
+			for _, d := range Check(tree) {
+				result = append(result, d.Error())
+			}
+			assert.Equal(tc.expected, result)
+		})
+	}
+}
@@ -0,0 +1,38 @@
+package check
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/orilang/gori/token"
+)
+
+var (
+	ErrAssignThroughView = errors.New("cannot assign through view")
+	ErrMutateWhileViewed = errors.New("cannot mutate value while a view of it is live")
+	ErrReturnViewOfLocal = errors.New("cannot return a view of a local value")
+	ErrNotShared         = errors.New("expected shared value")
+	ErrMissingCases      = errors.New("missing cases")
+	ErrDuplicateCase     = errors.New("duplicate case")
+	ErrUnreachableCase   = errors.New("unreachable case")
+	ErrUnknownVariant    = errors.New("case is not a variant")
+	ErrBuiltinArgs       = errors.New("wrong number of arguments")
+	ErrIndexOutOfRange   = errors.New("index out of range")
+	ErrNegativeLength    = errors.New("negative length")
+	ErrDuplicateField    = errors.New("duplicate field")
+	ErrFieldCollision    = errors.New("field collides with a promoted field")
+)
+
+// Error returns the reason with the position of the failure
+// and the position of the qualifier involved if any
+func (d *Diagnostic) Error() string {
+	if d.Qualifier == (token.Token{}) {
+		return fmt.Sprintf("%d:%d: %v, got %q", d.Token.Line, d.Token.Column, d.Err, d.Token.Value)
+	}
+	return fmt.Sprintf("%d:%d: %v, got %q (%s at %d:%d)", d.Token.Line, d.Token.Column, d.Err, d.Token.Value, d.Qualifier.Value, d.Qualifier.Line, d.Qualifier.Column)
+}
+
+// Unwrap returns the reason of the failure
+func (d *Diagnostic) Unwrap() error {
+	return d.Err
+}
@@ -0,0 +1,264 @@
+package check
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/orilang/gori/ast"
+	"github.com/orilang/gori/token"
+)
+
+// variant holds a member of an enum or a sum type
+type variant struct {
+	name   string
+	params int
+}
+
+// declareTypes records the enums, sums and structs of decls
+// including the ones inside comptime blocks
+func (c *Checker) declareTypes(decls []ast.Decl) {
+	for _, decl := range decls {
+		if v, ok := decl.(*ast.ComptimeBlockDecl); ok {
+			c.declareTypes(v.Decls)
+			continue
+		}
+		c.declareType(decl)
+	}
+}
+
+// declareType records the decl when it is an enum, a sum or a struct
+func (c *Checker) declareType(decl ast.Decl) {
+	switch v := decl.(type) {
+	case *ast.EnumDecl:
+		c.types[v.Name.Value] = decl
+	case *ast.SumDecl:
+		c.types[v.Name.Value] = decl
+	case *ast.StructDecl:
+		c.types[v.Name.Value] = decl
+	}
+}
+
+// typeOf returns the declared type of x or nil when unknown.
+// x is a binding, a variant selected from its type like Color.Red,
+// a struct field like p.color, promoted fields included,
+// or a call of a function with a single result
+func (c *Checker) typeOf(x ast.Expr) ast.Type {
+	switch v := x.(type) {
+	case *ast.IdentExpr:
+		if b := c.lookup(v.Name.Value); b != nil {
+			return b.typ
+		}
+
+	case *ast.ParenExpr:
+		return c.typeOf(v.Inner)
+
+	case *ast.SelectorExpr:
+		if ident, ok := v.X.(*ast.IdentExpr); ok && c.lookup(ident.Name.Value) == nil {
+			if _, ok := c.types[ident.Name.Value]; ok {
+				return &ast.NamedType{Parts: []token.Token{ident.Name}}
+			}
+		}
+
+		if s := c.structOf(c.typeOf(v.X)); s != nil {
+			return c.fieldType(s, v.Selector.Value, make(map[string]bool))
+		}
+
+	case *ast.CallExpr:
+		if ident, ok := v.Callee.(*ast.IdentExpr); ok {
+			if fn, ok := c.funcs[ident.Name.Value]; ok && len(fn.Results.List) == 1 {
+				return fn.Results.List[0].Type
+			}
+		}
+	}
+	return nil
+}
+
+// variants returns the name of the enum or sum type of x
+// with its variants or false when x is not one of them
+func (c *Checker) variants(x ast.Expr) (string, []variant, bool) {
+	nt, ok := c.typeOf(x).(*ast.NamedType)
+	if !ok || len(nt.Parts) != 1 {
+		return "", nil, false
+	}
+
+	var result []variant
+	switch v := c.types[nt.Parts[0].Value].(type) {
+	case *ast.EnumDecl:
+		for _, name := range v.Variants {
+			result = append(result, variant{name: name.Value})
+		}
+	case *ast.SumDecl:
+		for _, sv := range v.Variants {
+			result = append(result, variant{name: sv.Name.Value, params: len(sv.Params)})
+		}
+	default:
+		return "", nil, false
+	}
+	return nt.Parts[0].Value, result, true
+}
+
+// checkSwitchCases reports missing, duplicate, unknown and unreachable cases
+// of a switch over an enum or a sum.
+// Values are either the variant like Red or selected from the type like Color.Red
+func (c *Checker) checkSwitchCases(s *ast.SwitchStmt) {
+	typeName, variants, ok := c.variants(s.Tag)
+	if !ok {
+		return
+	}
+
+	seen := make(map[string]bool)
+	var dflt token.Token
+	for _, cc := range s.Cases {
+		if cc.Case.Kind == token.KWDefault {
+			dflt = cc.Case
+			continue
+		}
+		for _, value := range cc.Values {
+			name, ok := variantName(value, typeName)
+			if !ok || !hasVariant(variants, name.Value) {
+				tok := value.Start()
+				if ok {
+					tok = name
+				}
+				c.report(fmt.Errorf("%w of %s", ErrUnknownVariant, typeName), tok, token.Token{})
+				continue
+			}
+			if seen[name.Value] {
+				c.report(ErrDuplicateCase, name, token.Token{})
+				continue
+			}
+			seen[name.Value] = true
+		}
+	}
+
+	missing := missingVariants(variants, seen)
+	switch {
+	case len(missing) == 0 && dflt.Kind == token.KWDefault:
+		c.report(ErrUnreachableCase, dflt, token.Token{})
+	case len(missing) > 0 && dflt.Kind != token.KWDefault:
+		var text strings.Builder
+		for _, v := range missing {
+			fmt.Fprintf(&text, "case %s:\n", v.name)
+		}
+		c.reportMissing(s.Switch, s.RBrace, missing, text.String())
+	}
+}
+
+// checkMatchCases reports missing, duplicate, unknown and unreachable cases
+// of a match over an enum or a sum.
+// Guarded cases and cases with nested variants only cover a part
+// of a variant so they are not taken into account
+func (c *Checker) checkMatchCases(m *ast.MatchStmt) {
+	typeName, variants, ok := c.variants(m.X)
+	if !ok {
+		return
+	}
+
+	seen := make(map[string]bool)
+	wildcard := false
+	for _, mc := range m.Cases {
+		if wildcard || len(missingVariants(variants, seen)) == 0 {
+			c.report(ErrUnreachableCase, mc.Case, token.Token{})
+			continue
+		}
+
+		switch v := mc.Pattern.(type) {
+		case *ast.WildcardPattern:
+			wildcard = mc.Guard == nil
+
+		case *ast.VariantPattern:
+			if !hasVariant(variants, v.Name.Value) {
+				c.report(fmt.Errorf("%w of %s", ErrUnknownVariant, typeName), v.Name, token.Token{})
+				continue
+			}
+			if mc.Guard != nil || !irrefutable(v.Params) {
+				continue
+			}
+			if seen[v.Name.Value] {
+				c.report(ErrDuplicateCase, v.Name, token.Token{})
+				continue
+			}
+			seen[v.Name.Value] = true
+		}
+	}
+
+	missing := missingVariants(variants, seen)
+	if wildcard || len(missing) == 0 {
+		return
+	}
+
+	var text strings.Builder
+	for _, v := range missing {
+		if v.params == 0 {
+			fmt.Fprintf(&text, "case %s:\n", v.name)
+			continue
+		}
+		fmt.Fprintf(&text, "case %s(%s):\n", v.name, strings.Repeat("_, ", v.params-1)+"_")
+	}
+	c.reportMissing(m.Match, m.RBrace, missing, text.String())
+}
+
+// reportMissing records the missing variants with a fix
+// inserting their cases before rbrace
+func (c *Checker) reportMissing(tok, rbrace token.Token, missing []variant, text string) {
+	names := make([]string, 0, len(missing))
+	for _, v := range missing {
+		names = append(names, v.name)
+	}
+
+	c.diagnostics = append(c.diagnostics, &Diagnostic{
+		Err:   fmt.Errorf("%w: %s", ErrMissingCases, strings.Join(names, ", ")),
+		Token: tok,
+		Fix: &Fix{
+			Message: "add missing cases",
+			Edits:   []Edit{{Pos: rbrace, NewText: text}},
+		},
+	})
+}
+
+// variantName returns the variant token of a case value
+// like Red or Color.Red
+func variantName(x ast.Expr, typeName string) (token.Token, bool) {
+	switch v := x.(type) {
+	case *ast.IdentExpr:
+		return v.Name, true
+	case *ast.SelectorExpr:
+		if ident, ok := v.X.(*ast.IdentExpr); ok && ident.Name.Value == typeName {
+			return v.Selector, true
+		}
+	}
+	return token.Token{}, false
+}
+
+// hasVariant returns true when name is one of the variants
+func hasVariant(variants []variant, name string) bool {
+	for _, v := range variants {
+		if v.name == name {
+			return true
+		}
+	}
+	return false
+}
+
+// missingVariants returns the variants not seen in declaration order
+func missingVariants(variants []variant, seen map[string]bool) []variant {
+	var result []variant
+	for _, v := range variants {
+		if !seen[v.name] {
+			result = append(result, v)
+		}
+	}
+	return result
+}
+
+// irrefutable returns true when all patterns match any value
+func irrefutable(patterns []ast.Pattern) bool {
+	for _, p := range patterns {
+		switch p.(type) {
+		case *ast.BindingPattern, *ast.WildcardPattern:
+		default:
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,347 @@
+package check
+
+import (
+	"context"
+	"testing"
+
+	"github.com/orilang/gori/lexer"
+	"github.com/orilang/gori/parser"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCheck_exhaustive(t *testing.T) {
+	assert := assert.New(t)
+
+	tests := []struct {
+		name     string
+		input    string
+		expected []string
+		fixed    string
+	}{
+		{
+			name: "switch_enum_complete",
+			input: `package main
+
+type Color enum {
+  Red
+  Green
+}
+
+func f(c Color) {
+  switch c {
+  case Red:
+  case Color.Green:
+  }
+}`,
+		},
+		{
+			name: "switch_enum_missing",
+			input: `package main
+
+type Color enum {
+  Red
+  Green
+  Blue
+}
+
+func f(c Color) {
+  switch c {
+  case Green:
+  }
+}`,
+			expected: []string{`10:3: missing cases: Red, Blue, got "switch"`},
+			fixed: `package main
+
+type Color enum {
+  Red
+  Green
+  Blue
+}
+
+func f(c Color) {
+  switch c {
+  case Green:
+  case Red:
+  case Blue:
+  }
+}`,
+		},
+		{
+			name: "switch_enum_default",
+			input: `package main
+
+type Color enum {
+  Red
+  Green
+}
+
+func f() {
+  var c Color = Red
+  switch c {
+  case Red:
+  default:
+  }
+}`,
+		},
+		{
+			name: "switch_enum_duplicate",
+			input: `package main
+
+type Color enum {
+  Red
+  Green
+}
+
+func f(c Color) {
+  switch c {
+  case Red, Green:
+  case Color.Red:
+  }
+}`,
+			expected: []string{`11:14: duplicate case, got "Red"`},
+		},
+		{
+			name: "switch_enum_unreachable_default",
+			input: `package main
+
+type Color enum {
+  Red
+  Green
+}
+
+func f(c Color) {
+  switch c {
+  case Red, Green:
+  default:
+  }
+}`,
+			expected: []string{`11:3: unreachable case, got "default"`},
+		},
+		{
+			name: "switch_local_sum_missing",
+			input: `package main
+
+func f(s int) {
+  type Shape sum {
+    Circle(radius float)
+    Square(side float)
+  }
+  var x Shape = s
+  switch x {
+  case Circle:
+  }
+}`,
+			expected: []string{`9:3: missing cases: Square, got "switch"`},
+		},
+		{
+			name: "switch_unknown_type",
+			input: `package main
+
+func f(c int) {
+  switch c {
+  case 1:
+  }
+}`,
+		},
+		{
+			name: "match_sum_missing",
+			input: `package main
+
+type Shape sum {
+  Circle(radius float)
+  Rect(w float, h float)
+  Empty
+}
+
+func f(s Shape) {
+  match s {
+  case Circle(r) if r > 0:
+  }
+}`,
+			expected: []string{`10:3: missing cases: Circle, Rect, Empty, got "match"`},
+			fixed: `package main
+
+type Shape sum {
+  Circle(radius float)
+  Rect(w float, h float)
+  Empty
+}
+
+func f(s Shape) {
+  match s {
+  case Circle(r) if r > 0:
+  case Circle(_):
+  case Rect(_, _):
+  case Empty:
+  }
+}`,
+		},
+		{
+			name: "match_sum_wildcard",
+			input: `package main
+
+type Shape sum {
+  Circle(radius float)
+  Empty
+}
+
+func f(s Shape) {
+  match s {
+  case Circle(_):
+  case _:
+  }
+}`,
+		},
+		{
+			name: "match_sum_duplicate_and_unreachable",
+			input: `package main
+
+type Shape sum {
+  Circle(radius float)
+  Empty
+}
+
+func f(s Shape) {
+  match s {
+  case Circle(r):
+  case Circle(_):
+  case Empty:
+  case _:
+  }
+}`,
+			expected: []string{
+				`11:8: duplicate case, got "Circle"`,
+				`13:3: unreachable case, got "case"`,
+			},
+		},
+		{
+			name: "match_after_wildcard",
+			input: `package main
+
+type Shape sum {
+  Circle(radius float)
+  Empty
+}
+
+func f(s Shape) {
+  match s {
+  case _:
+  case Empty:
+  }
+}`,
+			expected: []string{`11:3: unreachable case, got "case"`},
+		},
+		{
+			name: "match_on_one_line",
+			input: `package main
+
+type Color enum {
+  Red
+  Green
+}
+
+func f(c Color) {
+  match c { case Red: }
+}`,
+			expected: []string{`9:3: missing cases: Green, got "match"`},
+			fixed:    "package main\n\ntype Color enum {\n  Red\n  Green\n}\n\nfunc f(c Color) {\n  match c { case Red: \ncase Green:\n}\n}",
+		},
+		{
+			name: "switch_enum_unknown_case",
+			input: `package main
+
+type Color enum {
+  Red
+  Green
+}
+
+func f(c Color) {
+  switch c {
+  case Red, Purple:
+  case Color.Blue, 1:
+  case Green:
+  }
+}`,
+			expected: []string{
+				`10:13: case is not a variant of Color, got "Purple"`,
+				`11:14: case is not a variant of Color, got "Blue"`,
+				`11:20: case is not a variant of Color, got "1"`,
+			},
+		},
+		{
+			name: "match_sum_unknown_case",
+			input: `package main
+
+type Shape sum {
+  Circle(radius float)
+  Empty
+}
+
+func f(s Shape) {
+  match s {
+  case Square(_):
+  case Circle(_):
+  case Empty:
+  }
+}`,
+			expected: []string{`10:8: case is not a variant of Shape, got "Square"`},
+		},
+		{
+			name: "switch_operands",
+			input: `package main
+
+type Color enum {
+  Red
+  Green
+}
+
+type Pixel struct {
+  color Color
+}
+
+func color() Color {
+  return Red
+}
+
+func f(p Pixel) {
+  switch p.color {
+  case Red:
+  }
+  switch color() {
+  case Green:
+  }
+  switch (p.color) {
+  case Red, Green:
+  }
+  switch Color.Red {
+  case Green:
+  }
+}`,
+			expected: []string{
+				`17:3: missing cases: Green, got "switch"`,
+				`20:3: missing cases: Red, got "switch"`,
+				`26:3: missing cases: Red, got "switch"`,
+			},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			lex, err := lexer.NewLexer(lexer.Config{StringOnly: true})
+			assert.Nil(err)
+			tree, err := parser.New(lex.FetchTokensFromString(tc.input)).ParseFile(context.Background())
+			assert.Nil(err)
+
+			diagnostics := Check(tree)
+			var result []string
+			for _, d := range diagnostics {
+				result = append(result, d.Error())
+			}
+			assert.Equal(tc.expected, result)
+
+			if tc.fixed != "" {
+				assert.Equal(tc.fixed, string(ApplyFixes([]byte(tc.input), diagnostics)))
+			}
+		})
+	}
+}
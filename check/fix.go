@@ -0,0 +1,50 @@
+package check
+
+import (
+	"bytes"
+	"sort"
+	"strings"
+)
+
+// ApplyFixes returns src with the fixes of the diagnostics applied.
+// Each line of an edit is indented like the line of its position
+// and inserted before it when the position starts the line
+func ApplyFixes(src []byte, diagnostics []*Diagnostic) []byte {
+	var edits []Edit
+	for _, d := range diagnostics {
+		if d.Fix != nil {
+			edits = append(edits, d.Fix.Edits...)
+		}
+	}
+	sort.SliceStable(edits, func(i, j int) bool {
+		return edits[i].Pos.Offset > edits[j].Pos.Offset
+	})
+
+	result := bytes.Clone(src)
+	for _, e := range edits {
+		offset := e.Pos.Offset
+		if offset < 0 || offset > len(result) {
+			continue
+		}
+
+		start := bytes.LastIndexByte(result[:offset], '\n') + 1
+		indent := result[start:offset]
+		lines := strings.SplitAfter(e.NewText, "\n")
+
+		var text bytes.Buffer
+		if len(bytes.Trim(indent, " \t")) == 0 {
+			for _, line := range lines {
+				if line != "" {
+					text.Write(indent)
+					text.WriteString(line)
+				}
+			}
+			offset = start
+		} else {
+			text.WriteString("\n")
+			text.WriteString(e.NewText)
+		}
+		result = append(result[:offset], append(text.Bytes(), result[offset:]...)...)
+	}
+	return result
+}
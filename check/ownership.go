@@ -0,0 +1,418 @@
+package check
+
+import (
+	"github.com/orilang/gori/ast"
+	"github.com/orilang/gori/token"
+)
+
+// checkFunc checks the ownership rules of the function body
+func (c *Checker) checkFunc(fn *ast.FuncDecl) {
+	if fn.Body == nil {
+		return
+	}
+
+	c.results = fn.Results.List
+	c.scopes = nil
+	c.index = nil
+	c.borrows = nil
+
+	c.openScope()
+	if fn.Recv != nil {
+		c.declare(fn.Recv.Name, fn.Recv.Qualifier, false).typ = fn.Recv.Type
+	}
+	for _, p := range fn.Params {
+		c.checkConstants(p.Type)
+		c.declare(p.Name, p.Qualifier, false).typ = p.Type
+	}
+	for _, p := range fn.Results.List {
+		c.checkConstants(p.Type)
+	}
+	c.checkStmts(fn.Body.Stmts)
+	c.closeScope()
+}
+
+// openScope starts a new block
+func (c *Checker) openScope() {
+	c.scopes = append(c.scopes, make(map[string]*binding))
+	c.index = append(c.index, 0)
+}
+
+// closeScope ends the current block and
+// drops the views declared inside it
+func (c *Checker) closeScope() {
+	level := len(c.scopes) - 1
+	borrows := c.borrows[:0]
+	for _, b := range c.borrows {
+		if b.level < level {
+			borrows = append(borrows, b)
+		}
+	}
+	c.borrows = borrows
+	c.scopes = c.scopes[:level]
+	c.index = c.index[:level]
+}
+
+// declare adds the name to the current block
+func (c *Checker) declare(name, qualifier token.Token, local bool) *binding {
+	b := &binding{name: name, qualifier: qualifier, local: local}
+	if name.Value != "_" {
+		c.scopes[len(c.scopes)-1][name.Value] = b
+	}
+	return b
+}
+
+// lookup returns the binding of the name from
+// the innermost block or nil if not found
+func (c *Checker) lookup(name string) *binding {
+	for i := len(c.scopes) - 1; i >= 0; i-- {
+		if b, ok := c.scopes[i][name]; ok {
+			return b
+		}
+	}
+	return nil
+}
+
+// checkStmts checks statements of a block in a new scope
+func (c *Checker) checkStmts(stmts []ast.Stmt) {
+	c.openScope()
+	for i, stmt := range stmts {
+		c.index[len(c.index)-1] = i
+		c.checkStmt(stmt, stmts, i)
+	}
+	c.closeScope()
+}
+
+// checkStmt checks the statement located at i in stmts
+func (c *Checker) checkStmt(stmt ast.Stmt, stmts []ast.Stmt, i int) {
+	switch v := stmt.(type) {
+	case *ast.DeclStmt:
+		switch x := v.Decl.(type) {
+		case *ast.VarDecl:
+			c.checkVarDecl(x, stmts, i)
+		case *ast.ConstDecl:
+			c.declareConst(x)
+		case *ast.GenDecl:
+			for _, spec := range x.Specs {
+				switch y := spec.(type) {
+				case *ast.VarDecl:
+					c.checkVarDecl(y, stmts, i)
+				case *ast.ConstDecl:
+					c.declareConst(y)
+				}
+			}
+		default:
+			c.declareType(x)
+			c.checkConstants(x)
+		}
+
+	case *ast.AssignStmt:
+		for _, x := range v.Right {
+			c.checkExpr(x)
+		}
+		for _, x := range v.Left {
+			if ident, ok := x.(*ast.IdentExpr); ok && v.Operator.Kind == token.Define {
+				c.declare(ident.Name, token.Token{}, true)
+				continue
+			}
+			c.checkMutation(x)
+		}
+
+	case *ast.IncDecStmt:
+		c.checkMutation(v.X)
+
+	case *ast.ExprStmt:
+		c.checkExpr(v.Expr)
+
+	case *ast.DeferStmt:
+		c.checkExpr(v.Call)
+
+	case *ast.ReturnStmt:
+		c.checkReturn(v)
+
+	case *ast.BlockStmt:
+		c.checkStmts(v.Stmts)
+
+	case *ast.LabeledStmt:
+		c.checkStmt(v.Stmt, stmts, i)
+
+	case *ast.IfStmt:
+		c.checkExpr(v.Condition)
+		if v.Then != nil {
+			c.checkStmts(v.Then.Stmts)
+		}
+		c.checkStmt(v.Else, nil, 0)
+
+	case *ast.ForStmt:
+		c.openScope()
+		c.checkStmt(v.Init, nil, 0)
+		c.checkExpr(v.Condition)
+		c.checkStmt(v.Post, nil, 0)
+		if v.Body != nil {
+			c.checkStmts(v.Body.Stmts)
+		}
+		c.closeScope()
+
+	case *ast.RangeStmt:
+		c.checkRange(v, i)
+
+	case *ast.MatchStmt:
+		c.checkExpr(v.X)
+		c.checkMatchCases(v)
+		for _, mc := range v.Cases {
+			c.openScope()
+			ast.Inspect(mc.Pattern, func(n any) bool {
+				if x, ok := n.(*ast.BindingPattern); ok {
+					c.declare(x.Name, token.Token{}, true)
+				}
+				return true
+			})
+			c.checkExpr(mc.Guard)
+			c.checkStmts(mc.Body)
+			c.closeScope()
+		}
+
+	case *ast.SwitchStmt:
+		c.openScope()
+		c.checkStmt(v.Init, nil, 0)
+		c.checkExpr(v.Tag)
+		c.checkSwitchCases(v)
+		for _, cc := range v.Cases {
+			c.checkStmts(cc.Body)
+		}
+		c.closeScope()
+	}
+}
+
+// checkVarDecl declares the variable located at i in stmts.
+// A view borrows the value it is initialized from until
+// its last use in the block
+func (c *Checker) checkVarDecl(v *ast.VarDecl, stmts []ast.Stmt, i int) {
+	c.checkConstants(v.Type)
+	c.checkVarValues(v)
+
+	for j, name := range v.Names {
+		if v.Qualifier.Kind != token.KWView {
+			c.declare(name, v.Qualifier, true).typ = v.Type
+			continue
+		}
+
+		var target *binding
+		if len(v.Values) == len(v.Names) {
+			target = c.lookupRoot(v.Values[j])
+		}
+		local := target == nil || target.local
+		view := c.declare(name, v.Qualifier, local)
+		view.typ = v.Type
+		if target != nil {
+			c.borrows = append(c.borrows, borrow{
+				view:    view,
+				target:  target,
+				level:   len(c.scopes) - 1,
+				lastUse: lastUse(stmts, i, name.Value),
+			})
+		}
+	}
+}
+
+// checkRange declares range variables located at i in the block.
+// A view of the elements borrows the ranged value during the loop
+func (c *Checker) checkRange(v *ast.RangeStmt, i int) {
+	c.checkExpr(v.X)
+	target := c.lookupRoot(v.X)
+
+	c.openScope()
+	vars := []struct {
+		ident     *ast.IdentExpr
+		qualifier token.Token
+	}{
+		{v.Key, v.KeyQualifier},
+		{v.Value, v.ValueQualifier},
+	}
+	for _, x := range vars {
+		if x.ident == nil {
+			continue
+		}
+
+		local := x.qualifier.Kind != token.KWView || target == nil || target.local
+		b := c.declare(x.ident.Name, x.qualifier, local)
+		if x.qualifier.Kind == token.KWView && target != nil {
+			c.borrows = append(c.borrows, borrow{
+				view:    b,
+				target:  target,
+				level:   len(c.scopes) - 2,
+				lastUse: i,
+			})
+		}
+	}
+	if v.Body != nil {
+		c.checkStmts(v.Body.Stmts)
+	}
+	c.closeScope()
+}
+
+// checkMutation reports assignments through a view and
+// mutations of a value while a view of it is live
+func (c *Checker) checkMutation(x ast.Expr) {
+	c.checkExpr(x)
+	root := rootIdent(x)
+	if root == nil {
+		return
+	}
+
+	b := c.lookup(root.Name.Value)
+	if b == nil {
+		return
+	}
+
+	if b.qualifier.Kind == token.KWView {
+		c.report(ErrAssignThroughView, root.Name, b.qualifier)
+		return
+	}
+
+	for _, br := range c.borrows {
+		if br.target == b && c.live(br) {
+			c.report(ErrMutateWhileViewed, root.Name, br.view.qualifier)
+			return
+		}
+	}
+}
+
+// live returns true when the statement being checked in the
+// block of the view is located before its last use
+func (c *Checker) live(br borrow) bool {
+	return br.level < len(c.index) && c.index[br.level] <= br.lastUse
+}
+
+// checkReturn reports views of local values being returned
+func (c *Checker) checkReturn(r *ast.ReturnStmt) {
+	for i, value := range r.Values {
+		c.checkExpr(value)
+		root := rootIdent(value)
+		if root == nil {
+			continue
+		}
+
+		b := c.lookup(root.Name.Value)
+		if b == nil || !b.local {
+			continue
+		}
+
+		if b.qualifier.Kind == token.KWView {
+			c.report(ErrReturnViewOfLocal, root.Name, b.qualifier)
+			continue
+		}
+		if i < len(c.results) && c.results[i].Qualifier.Kind == token.KWView {
+			c.report(ErrReturnViewOfLocal, root.Name, c.results[i].Qualifier)
+		}
+	}
+}
+
+// checkExpr reports non shared values passed to
+// shared parameters of the calls found in x,
+// constant array indexes out of range and
+// invalid constant expressions
+func (c *Checker) checkExpr(x ast.Expr) {
+	ast.Inspect(x, func(n any) bool {
+		switch v := n.(type) {
+		case *ast.IndexExpr:
+			c.checkIndex(v)
+		case *ast.SliceExpr:
+			c.checkSlice(v)
+		}
+		if !c.checkConstNode(n) {
+			return false
+		}
+
+		call, ok := n.(*ast.CallExpr)
+		if !ok {
+			return true
+		}
+
+		callee, ok := call.Callee.(*ast.IdentExpr)
+		if !ok {
+			return true
+		}
+		fn := c.funcs[callee.Name.Value]
+		if fn == nil {
+			c.checkBuiltinCall(callee, call)
+			return true
+		}
+
+		for i, arg := range call.Args {
+			param, ok := paramAt(fn.Params, i)
+			if !ok || param.Qualifier.Kind != token.KWShared {
+				continue
+			}
+
+			ident, ok := arg.(*ast.IdentExpr)
+			if !ok {
+				continue
+			}
+			b := c.lookup(ident.Name.Value)
+			if b != nil && b.qualifier.Kind != token.KWShared {
+				c.report(ErrNotShared, ident.Name, param.Qualifier)
+			}
+		}
+		return true
+	})
+}
+
+// paramAt returns the parameter receiving the argument i
+// which is the variadic one for the trailing arguments
+func paramAt(params []ast.Param, i int) (ast.Param, bool) {
+	if i < len(params) {
+		return params[i], true
+	}
+	if n := len(params); n > 0 && params[n-1].Ellipsis.Kind == token.Ellipsis {
+		return params[n-1], true
+	}
+	return ast.Param{}, false
+}
+
+// lookupRoot returns the binding of the root identifier of x
+func (c *Checker) lookupRoot(x ast.Expr) *binding {
+	root := rootIdent(x)
+	if root == nil {
+		return nil
+	}
+	return c.lookup(root.Name.Value)
+}
+
+// rootIdent returns the identifier x is accessed from
+// like a in a.b[1] or a[1:] or nil when there is none
+func rootIdent(x ast.Expr) *ast.IdentExpr {
+	for {
+		switch v := x.(type) {
+		case *ast.IdentExpr:
+			return v
+		case *ast.SelectorExpr:
+			x = v.X
+		case *ast.IndexExpr:
+			x = v.X
+		case *ast.SliceExpr:
+			x = v.X
+		case *ast.ParenExpr:
+			x = v.Inner
+		default:
+			return nil
+		}
+	}
+}
+
+// lastUse returns the position of the last statement of stmts
+// after i using name or i when there is none
+func lastUse(stmts []ast.Stmt, i int, name string) int {
+	for j := len(stmts) - 1; j > i; j-- {
+		found := false
+		ast.Inspect(stmts[j], func(n any) bool {
+			if x, ok := n.(*ast.IdentExpr); ok && x.Name.Value == name {
+				found = true
+			}
+			return !found
+		})
+		if found {
+			return j
+		}
+	}
+	return i
+}
@@ -0,0 +1,208 @@
+package check
+
+import (
+	"context"
+	"testing"
+
+	"github.com/orilang/gori/lexer"
+	"github.com/orilang/gori/parser"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCheck_ownership(t *testing.T) {
+	assert := assert.New(t)
+
+	tests := []struct {
+		name     string
+		input    string
+		expected []string
+	}{
+		{
+			name: "assign_through_view_param",
+			input: `package main
+
+func f(x view []int) {
+  x[0] = 1
+}`,
+			expected: []string{`4:3: cannot assign through view, got "x" (view at 3:10)`},
+		},
+		{
+			name: "assign_through_view_var",
+			input: `package main
+
+func f(x []int) {
+  var y view []int = x[1:]
+  y[0] = 1
+  y++
+}`,
+			expected: []string{
+				`5:3: cannot assign through view, got "y" (view at 4:9)`,
+				`6:3: cannot assign through view, got "y" (view at 4:9)`,
+			},
+		},
+		{
+			name: "assign_through_view_list",
+			input: `package main
+
+func f(x []int, y view []int) {
+  x, y = y, x
+}`,
+			expected: []string{`4:6: cannot assign through view, got "y" (view at 3:19)`},
+		},
+		{
+			name: "mutate_while_viewed",
+			input: `package main
+
+func f() {
+  var x []int = make([]int, 3)
+  var y view []int = x[1:]
+  x[0] = 1
+  print(y)
+}`,
+			expected: []string{`6:3: cannot mutate value while a view of it is live, got "x" (view at 5:9)`},
+		},
+		{
+			name: "mutate_in_nested_block_while_viewed",
+			input: `package main
+
+func f() {
+  var x []int = make([]int, 3)
+  var y view []int = x[1:]
+  if true {
+    x[0] = 1
+  }
+  print(y)
+}`,
+			expected: []string{`7:5: cannot mutate value while a view of it is live, got "x" (view at 5:9)`},
+		},
+		{
+			name: "mutate_after_last_use",
+			input: `package main
+
+func f() {
+  var x []int = make([]int, 3)
+  var y view []int = x[1:]
+  print(y)
+  x[0] = 1
+}`,
+		},
+		{
+			name: "mutate_after_block",
+			input: `package main
+
+func f() {
+  var x []int = make([]int, 3)
+  if true {
+    var y view []int = x[1:]
+    print(y)
+  }
+  x[0] = 1
+}`,
+		},
+		{
+			name: "mutate_while_ranging_view",
+			input: `package main
+
+func f(x []int) {
+  for _, view v := range x {
+    x[0] = v
+  }
+  x[0] = 1
+}`,
+			expected: []string{`5:5: cannot mutate value while a view of it is live, got "x" (view at 4:10)`},
+		},
+		{
+			name: "return_view_of_local",
+			input: `package main
+
+func f() view []int {
+  var x []int = make([]int, 3)
+  var y view []int = x[1:]
+  return y
+}`,
+			expected: []string{`6:10: cannot return a view of a local value, got "y" (view at 5:9)`},
+		},
+		{
+			name: "return_local_as_view",
+			input: `package main
+
+func f() view []int {
+  var x []int = make([]int, 3)
+  return x
+}`,
+			expected: []string{`5:10: cannot return a view of a local value, got "x" (view at 3:10)`},
+		},
+		{
+			name: "return_view_of_param",
+			input: `package main
+
+func f(x []int) view []int {
+  var y view []int = x[1:]
+  return y
+}`,
+		},
+		{
+			name: "not_shared",
+			input: `package main
+
+func keep(n shared Node) {}
+
+func f(a Node, b shared Node) {
+  keep(a)
+  keep(b)
+}`,
+			expected: []string{`6:8: expected shared value, got "a" (shared at 3:13)`},
+		},
+		{
+			name: "not_shared_variadic",
+			input: `package main
+
+func keep(a int, n shared ...Node) {}
+
+func f(a Node, b shared Node) {
+  keep(1, b, a)
+}`,
+			expected: []string{`6:14: expected shared value, got "a" (shared at 3:20)`},
+		},
+		{
+			name: "match_binding_shadows_view",
+			input: `package main
+
+func f(r view []int, s Shape) {
+  match s {
+  case Circle(r) if r > 0:
+    r = 1
+  case _:
+    r[0] = 1
+  }
+}`,
+			expected: []string{`8:5: cannot assign through view, got "r" (view at 3:10)`},
+		},
+		{
+			name: "define_shadows_view",
+			input: `package main
+
+func f(x view []int) {
+  if true {
+    y := 1
+    y = 2
+  }
+}`,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			lex, err := lexer.NewLexer(lexer.Config{StringOnly: true})
+			assert.Nil(err)
+			tree, err := parser.New(lex.FetchTokensFromString(tc.input)).ParseFile(context.Background())
+			assert.Nil(err)
+
+			var result []string
+			for _, d := range Check(tree) {
+				result = append(result, d.Error())
+			}
+			assert.Equal(tc.expected, result)
+		})
+	}
+}
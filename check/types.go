@@ -0,0 +1,142 @@
+package check
+
+import (
+	"github.com/orilang/gori/ast"
+	"github.com/orilang/gori/constant"
+	"github.com/orilang/gori/token"
+)
+
+// Config holds file or directory to check
+type Config struct {
+	// File to check
+	File string
+
+	// Directory to take as input and list files to check
+	Directory string
+
+	// Jobs is the number of files processed in parallel.
+	// When lower than 1, the number of CPUs is used
+	Jobs int
+
+	// TabWidth is the number of columns a tab advances to.
+	// When lower than 2, a tab counts as a single column
+	TabWidth int
+
+	// Tags are the build tags used to evaluate //ori:build constraints
+	Tags []string
+
+	// Fix applies the suggested fixes to the files
+	Fix bool
+}
+
+// Files holds all files to check
+type Files struct {
+	// Files holds the list of files to check
+	Files []string
+
+	// jobs is the number of files processed in parallel
+	jobs int
+
+	// tabWidth is the number of columns a tab advances to
+	tabWidth int
+
+	// fix applies the suggested fixes to the files
+	fix bool
+}
+
+// result holds the outcome of a checked file
+type result struct {
+	// errors holds the lexing and parsing errors and the diagnostics
+	errors []error
+}
+
+// Diagnostic holds a semantic failure with its position
+type Diagnostic struct {
+	// Err is the reason of the failure like ErrAssignThroughView
+	Err error
+
+	// Token is where the failure happens
+	Token token.Token
+
+	// Qualifier is the view or shared token of the
+	// declaration involved when there is one
+	Qualifier token.Token
+
+	// Fix is the suggested change resolving the failure
+	Fix *Fix // nil if none
+}
+
+// Fix holds a suggested change resolving a diagnostic
+type Fix struct {
+	// Message describes the change
+	Message string
+
+	// Edits holds the text to insert
+	Edits []Edit
+}
+
+// Edit holds text inserted before a token
+type Edit struct {
+	// Pos is the token the text is inserted before
+	Pos token.Token
+
+	// NewText holds the lines to insert
+	NewText string
+}
+
+// Checker holds requirements to run semantic analysis
+// on a parsed file
+type Checker struct {
+	diagnostics []*Diagnostic
+
+	// funcs holds top level functions by name
+	funcs map[string]*ast.FuncDecl
+
+	// types holds the enums, sums and structs by name
+	types map[string]ast.Decl
+
+	// consts evaluates the constant expressions
+	consts *constant.Evaluator
+
+	// reported holds the constant errors already reported
+	// as a failing constant is met at each of its uses
+	reported map[string]bool
+
+	// results holds the results of the function being checked
+	results []ast.Param
+
+	// scopes holds the bindings of each nested block
+	scopes []map[string]*binding
+
+	// index holds the position of the statement being
+	// checked in each nested block
+	index []int
+
+	// borrows holds the views declared so far
+	borrows []borrow
+}
+
+// binding holds a name declared in a function
+type binding struct {
+	name      token.Token
+	qualifier token.Token
+
+	// typ is the declared type or nil if unknown
+	typ ast.Type
+
+	// konst is the declaration when the binding is a constant
+	konst *ast.ConstDecl
+
+	// local is true when the value is owned by the function.
+	// A view is local when what it borrows from is local
+	local bool
+}
+
+// borrow holds a view of target declared in the block at level.
+// It is live until the statement at lastUse of that block
+type borrow struct {
+	view    *binding
+	target  *binding
+	level   int
+	lastUse int
+}
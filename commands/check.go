@@ -0,0 +1,66 @@
+package commands
+
+import (
+	"context"
+
+	"github.com/orilang/gori/check"
+	"github.com/orilang/gori/walk"
+	"github.com/urfave/cli/v3"
+)
+
+func Check() *cli.Command {
+	var app check.Config
+
+	return &cli.Command{
+		Name:  "check",
+		Usage: "option to check ownership rules and switch exhaustiveness of file or directory",
+		Flags: []cli.Flag{
+			&cli.StringFlag{
+				Name:        "file",
+				Aliases:     []string{"f"},
+				Usage:       "file to use",
+				Destination: &app.File,
+			},
+			&cli.StringFlag{
+				Name:        "directory",
+				Aliases:     []string{"d"},
+				Usage:       "directory to use",
+				Destination: &app.Directory,
+			},
+			&cli.IntFlag{
+				Name:        "jobs",
+				Aliases:     []string{"j"},
+				Usage:       "number of files processed in parallel, defaults to the number of CPUs",
+				Destination: &app.Jobs,
+			},
+			&cli.IntFlag{
+				Name:        "tab-width",
+				Usage:       "number of columns a tab advances to when reporting positions",
+				Destination: &app.TabWidth,
+				Value:       1,
+			},
+			&cli.StringSliceFlag{
+				Name:        "tags",
+				Usage:       "build tags used to evaluate //ori:build constraints, defaults to the current OS and architecture",
+				Destination: &app.Tags,
+			},
+			&cli.BoolFlag{
+				Name:        "fix",
+				Usage:       "apply suggested fixes like inserting missing switch cases",
+				Destination: &app.Fix,
+			},
+		},
+		Action: func(ctx context.Context, _ *cli.Command) error {
+			if app.File == "" && app.Directory == "" {
+				return walk.ErrNoFileOrDirectoryPassed
+			}
+
+			c, err := check.NewChecker(app)
+			if err != nil {
+				return err
+			}
+
+			return c.StartChecking(ctx)
+		},
+	}
+}
@@ -0,0 +1,76 @@
+package commands
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/orilang/gori/check"
+	"github.com/orilang/gori/lexer"
+	"github.com/orilang/gori/modfile"
+	"github.com/orilang/gori/walk"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCommandsCheck(t *testing.T) {
+	assert := assert.New(t)
+
+	t.Run("success", func(t *testing.T) {
+		configDir := "../testdata/syntax/valid"
+
+		cmd := Check()
+		assert.NoError(cmd.Run(context.Background(), []string{"check", "--directory", configDir, "--jobs", "2"}))
+	})
+
+	t.Run("error_module", func(t *testing.T) {
+		configFile := filepath.Join("../testdata", "module_invalid/ori.mod")
+
+		cmd := Check()
+		assert.ErrorIs(cmd.Run(context.Background(), []string{"check", "--file", configFile}), modfile.ErrNotRequired)
+	})
+
+	t.Run("error_no_such_file_or_directory", func(t *testing.T) {
+		configDir := "../testdata"
+		configFile := filepath.Join(configDir, "main.ori")
+
+		cmd := Check()
+		assert.Error(cmd.Run(context.Background(), []string{"check", "--file", configFile}))
+	})
+
+	t.Run("error_ownership", func(t *testing.T) {
+		configDir := "../testdata"
+		configFile := filepath.Join(configDir, "ownership/borrow.ori")
+
+		cmd := Check()
+		assert.ErrorIs(cmd.Run(context.Background(), []string{"check", "--file", configFile, "--tab-width", "4"}), check.ErrMutateWhileViewed)
+	})
+
+	t.Run("fix", func(t *testing.T) {
+		configFile := filepath.Join(t.TempDir(), "main.ori")
+		assert.NoError(os.WriteFile(configFile, []byte("package main\n\ntype Color enum {\n  Red\n}\n\nfunc f(c Color) {\n  switch c {\n  }\n}\n"), 0o600))
+
+		cmd := Check()
+		assert.ErrorIs(cmd.Run(context.Background(), []string{"check", "--file", configFile}), check.ErrMissingCases)
+
+		cmd = Check()
+		assert.NoError(cmd.Run(context.Background(), []string{"check", "--file", configFile, "--fix"}))
+
+		b, err := os.ReadFile(configFile)
+		assert.NoError(err)
+		assert.Contains(string(b), "  case Red:\n  }")
+	})
+
+	t.Run("error_illegal", func(t *testing.T) {
+		configDir := "../testdata"
+		configFile := filepath.Join(configDir, "illegal/string.ori")
+
+		cmd := Check()
+		assert.ErrorIs(cmd.Run(context.Background(), []string{"check", "--file", configFile, "--tags", "linux"}), lexer.ErrUnterminatedString)
+	})
+
+	t.Run("error_no_file_or_directory", func(t *testing.T) {
+		cmd := Check()
+		assert.ErrorIs(walk.ErrNoFileOrDirectoryPassed, cmd.Run(context.Background(), []string{"check"}))
+	})
+}
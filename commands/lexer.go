@@ -34,6 +34,23 @@ func Lexer() *cli.Command {
 				Destination: &app.Output,
 				Value:       true,
 			},
+			&cli.IntFlag{
+				Name:        "jobs",
+				Aliases:     []string{"j"},
+				Usage:       "number of files processed in parallel, defaults to the number of CPUs",
+				Destination: &app.Jobs,
+			},
+			&cli.IntFlag{
+				Name:        "tab-width",
+				Usage:       "number of columns a tab advances to when reporting positions",
+				Destination: &app.TabWidth,
+				Value:       1,
+			},
+			&cli.StringSliceFlag{
+				Name:        "tags",
+				Usage:       "build tags used to evaluate //ori:build constraints, defaults to the current OS and architecture",
+				Destination: &app.Tags,
+			},
 		},
 		Action: func(ctx context.Context, _ *cli.Command) error {
 			if app.File == "" && app.Directory == "" {
@@ -45,7 +62,7 @@ func Lexer() *cli.Command {
 				return err
 			}
 
-			return lex.StartLexing()
+			return lex.StartLexing(ctx)
 		},
 	}
 }
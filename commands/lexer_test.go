@@ -6,6 +6,7 @@ import (
 	"testing"
 	"time"
 
+	"github.com/orilang/gori/lexer"
 	"github.com/orilang/gori/walk"
 	"github.com/stretchr/testify/assert"
 )
@@ -44,6 +45,35 @@ func TestCommandsLex(t *testing.T) {
 		assert.Error(cmd.Run(context.Background(), []string{"lex", "--file", configFile}))
 	})
 
+	t.Run("success_jobs", func(t *testing.T) {
+		configDir := "../testdata/success"
+
+		cmd := Lexer()
+		assert.NoError(cmd.Run(context.Background(), []string{"lex", "--directory", configDir, "--jobs", "2", "--output=false"}))
+	})
+
+	t.Run("success_tab_width", func(t *testing.T) {
+		configDir := "../testdata/success"
+
+		cmd := Lexer()
+		assert.NoError(cmd.Run(context.Background(), []string{"lex", "--directory", configDir, "--tab-width", "4", "--output=false"}))
+	})
+
+	t.Run("success_tags", func(t *testing.T) {
+		configDir := "../testdata/build"
+
+		cmd := Lexer()
+		assert.NoError(cmd.Run(context.Background(), []string{"lex", "--directory", configDir, "--tags", "windows,arm64", "--output=false"}))
+	})
+
+	t.Run("error_illegal", func(t *testing.T) {
+		configDir := "../testdata"
+		configFile := filepath.Join(configDir, "illegal/string.ori")
+
+		cmd := Lexer()
+		assert.ErrorIs(cmd.Run(context.Background(), []string{"lex", "--file", configFile, "--output=false"}), lexer.ErrUnterminatedString)
+	})
+
 	t.Run("error_no_file_or_directory", func(t *testing.T) {
 		cmd := Lexer()
 		assert.ErrorIs(walk.ErrNoFileOrDirectoryPassed, cmd.Run(context.Background(), []string{"lex"}))
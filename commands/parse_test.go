@@ -6,6 +6,7 @@ import (
 	"testing"
 	"time"
 
+	"github.com/orilang/gori/lexer"
 	"github.com/orilang/gori/walk"
 	"github.com/stretchr/testify/assert"
 )
@@ -44,6 +45,53 @@ func TestCommandsParse(t *testing.T) {
 		assert.Error(cmd.Run(context.Background(), []string{"lex", "--file", configFile}))
 	})
 
+	t.Run("success_jobs", func(t *testing.T) {
+		configDir := "../testdata/syntax/valid"
+
+		cmd := Parse()
+		assert.NoError(cmd.Run(context.Background(), []string{"lex", "--directory", configDir, "--jobs", "2", "--output=false"}))
+	})
+
+	t.Run("success_tab_width", func(t *testing.T) {
+		configDir := "../testdata/syntax/valid"
+
+		cmd := Parse()
+		assert.NoError(cmd.Run(context.Background(), []string{"lex", "--directory", configDir, "--tab-width", "4", "--output=false"}))
+	})
+
+	t.Run("success_tags", func(t *testing.T) {
+		configDir := "../testdata/build"
+
+		cmd := Parse()
+		assert.NoError(cmd.Run(context.Background(), []string{"lex", "--directory", configDir, "--tags", "windows,arm64", "--output=false"}))
+	})
+
+	t.Run("success_module", func(t *testing.T) {
+		configDir := "../testdata/module"
+
+		cmd := Parse()
+		assert.NoError(cmd.Run(context.Background(), []string{"lex", "--directory", configDir, "--output=false"}))
+	})
+
+	t.Run("error_syntax", func(t *testing.T) {
+		configDir := "../testdata"
+		configFile := filepath.Join(configDir, "syntax/invalid/recovery.ori")
+
+		cmd := Parse()
+		err := cmd.Run(context.Background(), []string{"lex", "--file", configFile, "--output=false"})
+		if assert.Error(err) {
+			assert.Equal(configFile+":4:5: unsupported type with 4 \"1\"\n"+configFile+":9:12: unexpected expression, got 40 \")\"", err.Error())
+		}
+	})
+
+	t.Run("error_illegal", func(t *testing.T) {
+		configDir := "../testdata"
+		configFile := filepath.Join(configDir, "illegal/string.ori")
+
+		cmd := Parse()
+		assert.ErrorIs(cmd.Run(context.Background(), []string{"lex", "--file", configFile, "--output=false"}), lexer.ErrUnterminatedString)
+	})
+
 	t.Run("error_no_file_or_directory", func(t *testing.T) {
 		cmd := Parse()
 		assert.ErrorIs(walk.ErrNoFileOrDirectoryPassed, cmd.Run(context.Background(), []string{"lex"}))
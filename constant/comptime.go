@@ -0,0 +1,572 @@
+package constant
+
+import (
+	"fmt"
+	"math/big"
+	"strconv"
+	"strings"
+
+	"github.com/orilang/gori/ast"
+	"github.com/orilang/gori/token"
+)
+
+// DefaultSteps is the number of statements a comptime call
+// can execute when Evaluator.Steps is not set
+const DefaultSteps = 100_000
+
+// assignOperators holds the operator applied by each compound assignment
+var assignOperators = map[token.Kind]token.Kind{
+	token.PlusEq:  token.Plus,
+	token.MinusEq: token.Minus,
+	token.StarEq:  token.Star,
+	token.SlashEq: token.Slash,
+}
+
+// call returns the result of the comptime function called by x
+// with constant arguments. The len builtin is evaluated on strings
+func (e *Evaluator) call(x *ast.CallExpr) (Value, error) {
+	callee, ok := x.Callee.(*ast.IdentExpr)
+	if !ok || e.shadowed(callee.Name.Value) {
+		return Value{}, e.nonConstant(x.Callee.Start())
+	}
+
+	if callee.Name.Value == "len" && len(x.Args) == 1 {
+		v, err := e.Eval(x.Args[0])
+		if err != nil {
+			return Value{}, err
+		}
+		if v.Kind != String {
+			return Value{}, e.nonConstant(x.Args[0].Start())
+		}
+		return Value{Kind: Int, Int: big.NewInt(int64(len(v.Str)))}, nil
+	}
+
+	fn := e.funcs[callee.Name.Value]
+	if fn == nil {
+		return Value{}, e.nonConstant(callee.Name)
+	}
+	if len(x.Args) != len(fn.Params) || x.Ellipsis != (token.Token{}) {
+		return Value{}, &Error{
+			Err:   fmt.Errorf("%w: %s expects %d arguments, have %d", ErrInvalidOperation, fn.Name.Value, len(fn.Params), len(x.Args)),
+			Token: callee.Name,
+		}
+	}
+
+	params := make(map[string]*local, len(fn.Params))
+	for i, arg := range x.Args {
+		v, err := e.Eval(arg)
+		if err == nil {
+			v, err = Convert(v, fn.Params[i].Type, arg.Start())
+		}
+		if err != nil {
+			return Value{}, err
+		}
+		params[fn.Params[i].Name.Value] = &local{value: v}
+	}
+	return e.exec(fn, params, callee.Name)
+}
+
+// shadowed returns true when name is a local of
+// the caller hiding a function of the file
+func (e *Evaluator) shadowed(name string) bool {
+	if len(e.frames) > 0 {
+		return e.lookupLocal(name) != nil
+	}
+	if e.Resolve != nil {
+		_, found := e.Resolve(name)
+		return found
+	}
+	return false
+}
+
+// exec runs the body of fn with params and returns its result.
+// The step budget is shared by all the calls made from the outermost one
+func (e *Evaluator) exec(fn *ast.FuncDecl, params map[string]*local, tok token.Token) (Value, error) {
+	if len(fn.Results.List) > 1 {
+		return Value{}, &Error{Err: fmt.Errorf("%w: multiple results", ErrUnsupported), Token: tok}
+	}
+	if len(e.frames) == 0 {
+		e.steps = 0
+	}
+	if err := e.step(tok); err != nil {
+		return Value{}, err
+	}
+
+	f := &frame{fn: fn, scopes: []map[string]*local{params}}
+	e.frames = append(e.frames, f)
+	defer func() {
+		e.frames = e.frames[:len(e.frames)-1]
+	}()
+
+	result, err := e.block(fn.Body.Stmts)
+	if err != nil {
+		return Value{}, err
+	}
+	if result != flowReturn && len(fn.Results.List) > 0 {
+		return Value{}, &Error{Err: ErrMissingReturn, Token: fn.Body.RBrace}
+	}
+	return f.result, nil
+}
+
+// step counts an executed statement and fails
+// when the budget of the outermost call is spent
+func (e *Evaluator) step(tok token.Token) error {
+	budget := e.Steps
+	if budget < 1 {
+		budget = DefaultSteps
+	}
+
+	e.steps++
+	if e.steps > budget {
+		return &Error{Err: fmt.Errorf("%w: %d steps", ErrStepBudget, budget), Token: tok}
+	}
+	return nil
+}
+
+// frame returns the comptime call being executed
+func (e *Evaluator) frame() *frame {
+	return e.frames[len(e.frames)-1]
+}
+
+// lookupLocal returns the local of the comptime call
+// being executed called name or nil when there is none
+func (e *Evaluator) lookupLocal(name string) *local {
+	f := e.frame()
+	for i := len(f.scopes) - 1; i >= 0; i-- {
+		if l, ok := f.scopes[i][name]; ok {
+			return l
+		}
+	}
+	return nil
+}
+
+// declareLocal adds a local to the innermost block.
+// An untyped value takes the default type of its kind like int
+func (e *Evaluator) declareLocal(name token.Token, v Value, konst bool, tok token.Token) error {
+	if v.Type == "" && v.Kind != Unknown {
+		var err error
+		if v, err = Represent(v, v.Kind.String(), tok); err != nil {
+			return err
+		}
+	}
+
+	f := e.frame()
+	if name.Value != "_" {
+		f.scopes[len(f.scopes)-1][name.Value] = &local{value: v, konst: konst}
+	}
+	return nil
+}
+
+// block executes stmts in a new scope
+func (e *Evaluator) block(stmts []ast.Stmt) (flow, error) {
+	f := e.frame()
+	f.scopes = append(f.scopes, make(map[string]*local))
+	defer func() {
+		f.scopes = f.scopes[:len(f.scopes)-1]
+	}()
+
+	for _, stmt := range stmts {
+		result, err := e.stmt(stmt)
+		if err != nil || result != flowNext {
+			return result, err
+		}
+	}
+	return flowNext, nil
+}
+
+// stmt executes a statement of a comptime function
+func (e *Evaluator) stmt(stmt ast.Stmt) (flow, error) {
+	if err := e.step(stmt.Start()); err != nil {
+		return flowNext, err
+	}
+
+	switch v := stmt.(type) {
+	case *ast.BlockStmt:
+		return e.block(v.Stmts)
+
+	case *ast.ExprStmt:
+		_, err := e.Eval(v.Expr)
+		return flowNext, err
+
+	case *ast.DeclStmt:
+		return flowNext, e.decl(v.Decl)
+
+	case *ast.AssignStmt:
+		return flowNext, e.assign(v)
+
+	case *ast.IncDecStmt:
+		op := token.Plus
+		if v.Operator.Kind == token.MMinus {
+			op = token.Minus
+		}
+		one := &ast.IntLitExpr{Name: token.Token{Kind: token.IntLit, Value: "1", Line: v.Operator.Line, Column: v.Operator.Column}}
+		return flowNext, e.update(v.X, op, v.Operator, one)
+
+	case *ast.ReturnStmt:
+		return e.ret(v)
+
+	case *ast.IfStmt:
+		ok, err := e.cond(v.Condition)
+		switch {
+		case err != nil:
+			return flowNext, err
+		case ok:
+			return e.block(v.Then.Stmts)
+		case v.Else != nil:
+			return e.stmt(v.Else)
+		}
+		return flowNext, nil
+
+	case *ast.ForStmt:
+		return e.loop(v)
+
+	case *ast.SwitchStmt:
+		return e.switchStmt(v)
+
+	case *ast.BreakStmt:
+		if v.Label == (token.Token{}) {
+			return flowBreak, nil
+		}
+
+	case *ast.ContinueStmt:
+		if v.Label == (token.Token{}) {
+			return flowContinue, nil
+		}
+	}
+	return flowNext, &Error{Err: ErrUnsupported, Token: stmt.Start()}
+}
+
+// decl declares the variables and the constants of a comptime function
+func (e *Evaluator) decl(decl ast.Decl) error {
+	switch v := decl.(type) {
+	case *ast.VarDecl:
+		if len(v.Values) != len(v.Names) {
+			return &Error{Err: fmt.Errorf("%w: multiple values", ErrUnsupported), Token: v.Eq}
+		}
+		for i, name := range v.Names {
+			value, err := e.Eval(v.Values[i])
+			if err == nil {
+				value, err = Convert(value, v.Type, v.Values[i].Start())
+			}
+			if err == nil {
+				err = e.declareLocal(name, value, false, v.Values[i].Start())
+			}
+			if err != nil {
+				return err
+			}
+		}
+		return nil
+
+	case *ast.ConstDecl:
+		if v.Init == nil {
+			return &Error{Err: ErrNotConstant, Token: v.Name}
+		}
+		value, err := e.Eval(v.Init)
+		if err == nil {
+			value, err = Convert(value, v.Type, v.Init.Start())
+		}
+		if err != nil {
+			return err
+		}
+		return e.declareLocal(v.Name, value, true, v.Init.Start())
+
+	case *ast.GenDecl:
+		for _, spec := range v.Specs {
+			if err := e.decl(spec); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+	return &Error{Err: ErrUnsupported, Token: decl.Start()}
+}
+
+// assign executes assignments like a = 1, a, b := b, a or a += 2
+func (e *Evaluator) assign(x *ast.AssignStmt) error {
+	if op, ok := assignOperators[x.Operator.Kind]; ok {
+		if len(x.Left) != 1 || len(x.Right) != 1 {
+			return &Error{Err: fmt.Errorf("%w: multiple values", ErrUnsupported), Token: x.Operator}
+		}
+		return e.update(x.Left[0], op, x.Operator, x.Right[0])
+	}
+
+	if len(x.Left) != len(x.Right) {
+		return &Error{Err: fmt.Errorf("%w: multiple values", ErrUnsupported), Token: x.Operator}
+	}
+	values := make([]Value, len(x.Right))
+	for i, right := range x.Right {
+		v, err := e.Eval(right)
+		if err != nil {
+			return err
+		}
+		values[i] = v
+	}
+
+	for i, left := range x.Left {
+		var err error
+		if ident, ok := left.(*ast.IdentExpr); ok && x.Operator.Kind == token.Define {
+			err = e.declareLocal(ident.Name, values[i], false, x.Right[i].Start())
+		} else {
+			err = e.set(left, values[i], x.Right[i].Start())
+		}
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// update stores in left its value combined with right by op like in a += 2
+func (e *Evaluator) update(left ast.Expr, op token.Kind, tok token.Token, right ast.Expr) error {
+	v, err := e.binary(&ast.BinaryExpr{
+		Left:     left,
+		Operator: token.Token{Kind: op, Value: tok.Value, Line: tok.Line, Column: tok.Column, Offset: tok.Offset},
+		Right:    right,
+	})
+	if err != nil {
+		return err
+	}
+	return e.set(left, v, tok)
+}
+
+// set stores v in the local variable x
+func (e *Evaluator) set(x ast.Expr, v Value, tok token.Token) error {
+	ident, ok := x.(*ast.IdentExpr)
+	if !ok {
+		return &Error{Err: ErrUnsupported, Token: x.Start()}
+	}
+	if ident.Name.Value == "_" {
+		return nil
+	}
+
+	l := e.lookupLocal(ident.Name.Value)
+	if l == nil {
+		return e.nonConstant(ident.Name)
+	}
+	if l.konst {
+		return &Error{
+			Err:   fmt.Errorf("%w: cannot assign to constant %s", ErrInvalidOperation, ident.Name.Value),
+			Token: ident.Name,
+		}
+	}
+
+	v, err := Represent(v, l.value.Type, tok)
+	if err != nil {
+		return err
+	}
+	l.value = v
+	return nil
+}
+
+// ret records the value returned by the comptime call
+func (e *Evaluator) ret(x *ast.ReturnStmt) (flow, error) {
+	f := e.frame()
+	results := f.fn.Results.List
+	if len(x.Values) != len(results) {
+		return flowNext, &Error{
+			Err:   fmt.Errorf("%w: wrong number of return values, want %d, have %d", ErrInvalidOperation, len(results), len(x.Values)),
+			Token: x.Return,
+		}
+	}
+	if len(x.Values) == 0 {
+		return flowReturn, nil
+	}
+
+	v, err := e.Eval(x.Values[0])
+	if err == nil {
+		v, err = Convert(v, results[0].Type, x.Values[0].Start())
+	}
+	if err != nil {
+		return flowNext, err
+	}
+	f.result = v
+	return flowReturn, nil
+}
+
+// cond returns the value of the boolean condition x
+func (e *Evaluator) cond(x ast.Expr) (bool, error) {
+	v, err := e.Eval(x)
+	if err != nil {
+		return false, err
+	}
+	if v.Kind != Bool {
+		return false, &Error{
+			Err:   fmt.Errorf("%w: non-boolean condition of type %s", ErrInvalidOperation, v.typeName()),
+			Token: x.Start(),
+		}
+	}
+	return v.Bool, nil
+}
+
+// loop executes a for statement.
+// Each iteration counts as a step so empty loops end too
+func (e *Evaluator) loop(x *ast.ForStmt) (flow, error) {
+	f := e.frame()
+	f.scopes = append(f.scopes, make(map[string]*local))
+	defer func() {
+		f.scopes = f.scopes[:len(f.scopes)-1]
+	}()
+
+	if x.Init != nil {
+		if _, err := e.stmt(x.Init); err != nil {
+			return flowNext, err
+		}
+	}
+
+	for {
+		if err := e.step(x.ForKW); err != nil {
+			return flowNext, err
+		}
+		if x.Condition != nil {
+			ok, err := e.cond(x.Condition)
+			if err != nil || !ok {
+				return flowNext, err
+			}
+		}
+
+		result, err := e.block(x.Body.Stmts)
+		if err != nil || result == flowReturn {
+			return result, err
+		}
+		if result == flowBreak {
+			return flowNext, nil
+		}
+
+		if x.Post != nil {
+			if _, err := e.stmt(x.Post); err != nil {
+				return flowNext, err
+			}
+		}
+	}
+}
+
+// switchStmt executes the first case matching the tag
+// or the default one, following fallthrough statements
+func (e *Evaluator) switchStmt(x *ast.SwitchStmt) (flow, error) {
+	f := e.frame()
+	f.scopes = append(f.scopes, make(map[string]*local))
+	defer func() {
+		f.scopes = f.scopes[:len(f.scopes)-1]
+	}()
+
+	if x.Init != nil {
+		if _, err := e.stmt(x.Init); err != nil {
+			return flowNext, err
+		}
+	}
+
+	matched, fallback := -1, -1
+	for i, cc := range x.Cases {
+		if cc.Case.Kind == token.KWDefault {
+			fallback = i
+			continue
+		}
+
+		ok, err := e.matchCase(x.Tag, cc.Values)
+		if err != nil {
+			return flowNext, err
+		}
+		if ok {
+			matched = i
+			break
+		}
+	}
+	if matched < 0 {
+		matched = fallback
+	}
+	if matched < 0 {
+		return flowNext, nil
+	}
+
+	for i := matched; i < len(x.Cases); i++ {
+		body := x.Cases[i].Body
+		_, next := lastStmt(body).(*ast.FallThroughStmt)
+		if next {
+			body = body[:len(body)-1]
+		}
+
+		result, err := e.block(body)
+		if err != nil {
+			return result, err
+		}
+		if result == flowBreak {
+			return flowNext, nil
+		}
+		if result != flowNext || !next {
+			return result, nil
+		}
+	}
+	return flowNext, nil
+}
+
+// matchCase returns true when one of values equals tag
+// or is true when there is no tag
+func (e *Evaluator) matchCase(tag ast.Expr, values []ast.Expr) (bool, error) {
+	for _, value := range values {
+		x := value
+		if tag != nil {
+			tok := value.Start()
+			x = &ast.BinaryExpr{
+				Left:     tag,
+				Operator: token.Token{Kind: token.Eq, Value: "==", Line: tok.Line, Column: tok.Column, Offset: tok.Offset},
+				Right:    value,
+			}
+		}
+
+		ok, err := e.cond(x)
+		if err != nil || ok {
+			return ok, err
+		}
+	}
+	return false, nil
+}
+
+// lastStmt returns the last statement of stmts or nil when empty
+func lastStmt(stmts []ast.Stmt) ast.Stmt {
+	if len(stmts) == 0 {
+		return nil
+	}
+	return stmts[len(stmts)-1]
+}
+
+// Literal returns v as a literal expression located
+// at tok like 42, -1.5, "a" or true.
+// It returns nil when v has no literal form
+func Literal(v Value, tok token.Token) ast.Expr {
+	lit := token.Token{Line: tok.Line, Column: tok.Column, Offset: tok.Offset}
+	negative := false
+
+	var x ast.Expr
+	switch v.Kind {
+	case Bool:
+		lit.Kind, lit.Value = token.BoolLit, strconv.FormatBool(v.Bool)
+		return &ast.BoolLitExpr{Name: lit}
+
+	case String:
+		lit.Kind, lit.Value = token.StringLit, strconv.Quote(v.Str)
+		return &ast.StringLitExpr{Name: lit}
+
+	case Int:
+		negative = v.Int.Sign() < 0
+		lit.Kind, lit.Value = token.IntLit, new(big.Int).Abs(v.Int).String()
+		x = &ast.IntLitExpr{Name: lit}
+
+	case Float:
+		negative = v.Float.Sign() < 0
+		text := new(big.Float).SetRat(new(big.Rat).Abs(v.Float)).Text('g', -1)
+		if !strings.ContainsAny(text, ".e") {
+			text += ".0"
+		}
+		lit.Kind, lit.Value = token.FloatLit, text
+		x = &ast.FloatLitExpr{Name: lit}
+
+	default:
+		return nil
+	}
+
+	if negative {
+		minus := token.Token{Kind: token.Minus, Value: "-", Line: tok.Line, Column: tok.Column, Offset: tok.Offset}
+		return &ast.UnaryExpr{Operator: minus, Right: x}
+	}
+	return x
+}
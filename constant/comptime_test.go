@@ -0,0 +1,231 @@
+package constant
+
+import (
+	"context"
+	"math/big"
+	"testing"
+
+	"github.com/orilang/gori/ast"
+	"github.com/orilang/gori/lexer"
+	"github.com/orilang/gori/parser"
+	"github.com/orilang/gori/token"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestConstant_comptime(t *testing.T) {
+	assert := assert.New(t)
+
+	tests := []struct {
+		name     string
+		input    string
+		steps    int
+		expected string
+	}{
+		{
+			name: "recursion",
+			input: `comptime func fib(n int) int {
+  if n < 2 {
+    return n
+  }
+  return fib(n-1) + fib(n-2)
+}
+
+const x int = fib(20)`,
+			expected: "6765",
+		},
+		{
+			name: "loop",
+			input: `comptime {
+  func odds(n int) int {
+    total := 0
+    for i := 1; i <= n; i++ {
+      if i % 2 == 0 {
+        continue
+      }
+      total += i
+    }
+    return total
+  }
+
+  const x int = odds(9) * factor
+}
+
+const factor int = 2`,
+			expected: "50",
+		},
+		{
+			name: "switch",
+			input: `comptime func name(n int) string {
+  var s string = "many"
+  switch n {
+  case 0:
+    s = "none"
+  case 1:
+    s = "one"
+    fallthrough
+  case 2:
+    s = s + " or two"
+  }
+  return s
+}
+
+const x string = name(1)`,
+			expected: `"one or two"`,
+		},
+		{
+			name: "len",
+			input: `comptime func size(s string) int {
+  return len(s) * 2
+}
+
+const x int = size("abc")`,
+			expected: "6",
+		},
+		{
+			name: "overflow",
+			input: `comptime func double(n int8) int8 {
+  return n * 2
+}
+
+const x int8 = double(100)`,
+			expected: `4:12: constant overflow: 200 does not fit in int8, got "*"`,
+		},
+		{
+			name: "step_budget",
+			input: `comptime func spin() int {
+  for {
+  }
+  return 0
+}
+
+const x int = spin()`,
+			steps:    50,
+			expected: `4:3: comptime step budget exceeded: 50 steps, got "for"`,
+		},
+		{
+			name: "global_variable",
+			input: `var counter int = 0
+
+comptime func next() int {
+  counter++
+  return counter
+}
+
+const x int = next()`,
+			expected: `6:3: comptime code uses non-constant state, got "counter"`,
+		},
+		{
+			name: "runtime_call",
+			input: `func now() int {
+  return 1
+}
+
+comptime func at() int {
+  return now()
+}
+
+const x int = at()`,
+			expected: `8:10: comptime code uses non-constant state, got "now"`,
+		},
+		{
+			name: "runtime_function",
+			input: `func twice(n int) int {
+  return n * 2
+}
+
+const x int = twice(2)`,
+			expected: `7:15: value is not constant, got "twice"`,
+		},
+		{
+			name: "missing_return",
+			input: `comptime func f(n int) int {
+  if n > 0 {
+    return n
+  }
+}
+
+const x int = f(0)`,
+			expected: `7:1: missing return in comptime function, got "}"`,
+		},
+		{
+			name: "assign_constant",
+			input: `comptime func f() int {
+  const a int = 1
+  a = 2
+  return a
+}
+
+const x int = f()`,
+			expected: `5:3: invalid operation: cannot assign to constant a, got "a"`,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			lex, err := lexer.NewLexer(lexer.Config{StringOnly: true})
+			assert.Nil(err)
+			tree, err := parser.New(lex.FetchTokensFromString("package main\n\n" + tc.input + "\n")).ParseFile(context.Background())
+			if !assert.Nil(err) {
+				return
+			}
+
+			e := New(tree.Decls)
+			e.Steps = tc.steps
+			d := findConst(tree.Decls, "x")
+			if !assert.NotNil(d) {
+				return
+			}
+			v, err := e.Const(d)
+			if err != nil {
+				assert.Equal(tc.expected, err.Error())
+				return
+			}
+			assert.Equal(tc.expected, v.String())
+		})
+	}
+
+	t.Run("result", func(t *testing.T) {
+		lex, err := lexer.NewLexer(lexer.Config{StringOnly: true})
+		assert.Nil(err)
+		tree, err := parser.New(lex.FetchTokensFromString("package main\n\ncomptime func sq(n int) int {\n  return n * n\n}\n\nconst (\n  x int = comptime sq(iota + 5)\n  y\n)\n")).ParseFile(context.Background())
+		assert.Nil(err)
+
+		e := New(tree.Decls)
+		_, err = e.Const(findConst(tree.Decls, "y"))
+		assert.Nil(err)
+		x := findConst(tree.Decls, "x").Init.(*ast.ComptimeExpr)
+		assert.Nil(x.Result)
+
+		_, err = e.Const(findConst(tree.Decls, "x"))
+		assert.Nil(err)
+		if assert.NotNil(x.Result) {
+			assert.Equal("25", x.Result.Start().Value)
+			assert.Equal(x.ComptimeKW.Line, x.Result.Start().Line)
+			assert.Equal(x.ComptimeKW.Column, x.Result.Start().Column)
+		}
+	})
+
+	t.Run("literal", func(t *testing.T) {
+		tok := token.Token{Line: 1, Column: 2}
+		tests := []struct {
+			value    Value
+			expected string
+		}{
+			{value: Value{Kind: Bool, Bool: true}, expected: "true"},
+			{value: Value{Kind: String, Str: "a\n"}, expected: `"a\n"`},
+			{value: Value{Kind: Int, Int: big.NewInt(-3)}, expected: "-3"},
+			{value: Value{Kind: Float, Float: big.NewRat(5, 2)}, expected: "2.5"},
+			{value: Value{Kind: Float, Float: big.NewRat(3, 1)}, expected: "3.0"},
+		}
+
+		for _, tc := range tests {
+			x := Literal(tc.value, tok)
+			if u, ok := x.(*ast.UnaryExpr); ok {
+				assert.Equal(tc.expected, u.Operator.Value+u.Right.Start().Value)
+				continue
+			}
+			assert.Equal(tc.expected, x.Start().Value)
+			assert.Equal(tok.Line, x.Start().Line)
+		}
+	})
+}
@@ -0,0 +1,478 @@
+package constant
+
+import (
+	"fmt"
+	"math"
+	"math/big"
+	"strconv"
+	"strings"
+
+	"github.com/orilang/gori/ast"
+	"github.com/orilang/gori/token"
+)
+
+// basics holds the basic types by name.
+// int and uint are 64 bits wide
+var basics = map[string]basic{
+	"int":     intType(64, true),
+	"int8":    intType(8, true),
+	"int32":   intType(32, true),
+	"int64":   intType(64, true),
+	"uint":    intType(64, false),
+	"uint8":   intType(8, false),
+	"uint32":  intType(32, false),
+	"uint64":  intType(64, false),
+	"float":   {kind: Float, bits: 64},
+	"float32": {kind: Float, bits: 32},
+	"float64": {kind: Float, bits: 64},
+	"string":  {kind: String},
+	"bool":    {kind: Bool},
+}
+
+// intType returns the bounds of an integer type of size bits
+func intType(bits uint, signed bool) basic {
+	max := new(big.Int).Lsh(big.NewInt(1), bits)
+	if !signed {
+		return basic{kind: Int, min: new(big.Int), max: max.Sub(max, big.NewInt(1))}
+	}
+	max.Rsh(max, 1)
+	min := new(big.Int).Neg(max)
+	return basic{kind: Int, min: min, max: max.Sub(max, big.NewInt(1))}
+}
+
+// New returns an evaluator resolving the constants of decls
+// including the ones inside groups and comptime blocks
+func New(decls []ast.Decl) *Evaluator {
+	e := &Evaluator{
+		consts:     make(map[string]*ast.ConstDecl),
+		values:     make(map[*ast.ConstDecl]result),
+		evaluating: make(map[*ast.ConstDecl]bool),
+		funcs:      make(map[string]*ast.FuncDecl),
+	}
+	e.declare(decls, false)
+	return e
+}
+
+// declare records the constants and the comptime functions of decls
+func (e *Evaluator) declare(decls []ast.Decl, comptime bool) {
+	for _, decl := range decls {
+		switch v := decl.(type) {
+		case *ast.ConstDecl:
+			e.consts[v.Name.Value] = v
+		case *ast.FuncDecl:
+			if comptime && v.Recv == nil {
+				e.funcs[v.Name.Value] = v
+			}
+		case *ast.GenDecl:
+			e.declare(v.Specs, comptime)
+		case *ast.ComptimeBlockDecl:
+			e.declare(v.Decls, true)
+		}
+	}
+}
+
+// Const returns the value of the constant converted to its type if any.
+// Each constant is only evaluated once
+func (e *Evaluator) Const(d *ast.ConstDecl) (Value, error) {
+	if r, ok := e.values[d]; ok {
+		return r.value, r.err
+	}
+	if e.evaluating[d] {
+		return Value{}, &Error{Err: ErrCycle, Token: d.Name}
+	}
+	if d.Init == nil {
+		return Value{}, &Error{Err: ErrNotConstant, Token: d.Name}
+	}
+
+	e.evaluating[d] = true
+	prev := e.konst
+	e.konst = d
+	v, err := e.Eval(d.Init)
+	if err == nil && d.Type != nil {
+		v, err = Convert(v, d.Type, d.Init.Start())
+	}
+	e.konst = prev
+	delete(e.evaluating, d)
+
+	e.values[d] = result{value: v, err: err}
+	return v, err
+}
+
+// Eval returns the value of the constant expression x.
+// The result of a comptime call is substituted in its ComptimeExpr
+// unless the call is made by a comptime function or shared by
+// the implicit constants of a group
+func (e *Evaluator) Eval(x ast.Expr) (Value, error) {
+	switch v := x.(type) {
+	case *ast.IntLitExpr:
+		i, ok := new(big.Int).SetString(strings.ReplaceAll(v.Name.Value, "_", ""), 10)
+		if !ok {
+			return Value{}, &Error{Err: ErrNotConstant, Token: v.Name}
+		}
+		return Value{Kind: Int, Int: i}, nil
+
+	case *ast.FloatLitExpr:
+		f, ok := new(big.Rat).SetString(strings.ReplaceAll(v.Name.Value, "_", ""))
+		if !ok {
+			return Value{}, &Error{Err: ErrNotConstant, Token: v.Name}
+		}
+		return Value{Kind: Float, Float: f}, nil
+
+	case *ast.StringLitExpr:
+		s, err := strconv.Unquote(v.Name.Value)
+		if err != nil {
+			return Value{}, &Error{Err: ErrNotConstant, Token: v.Name}
+		}
+		return Value{Kind: String, Str: s}, nil
+
+	case *ast.BoolLitExpr:
+		return Value{Kind: Bool, Bool: v.Name.Value == "true"}, nil
+
+	case *ast.ParenExpr:
+		return e.Eval(v.Inner)
+
+	case *ast.IdentExpr:
+		return e.ident(v)
+
+	case *ast.UnaryExpr:
+		return e.unary(v)
+
+	case *ast.BinaryExpr:
+		return e.binary(v)
+
+	case *ast.ConversionExpr:
+		value, err := e.Eval(v.X)
+		if err != nil {
+			return Value{}, err
+		}
+		return Convert(value, v.Type, v.X.Start())
+
+	case *ast.CallExpr:
+		return e.call(v)
+
+	case *ast.ComptimeExpr:
+		value, err := e.Eval(v.X)
+		if err == nil && len(e.frames) == 0 && (e.konst == nil || !e.konst.Implicit) {
+			v.Result = Literal(value, v.ComptimeKW)
+		}
+		return value, err
+	}
+	return Value{}, e.nonConstant(x.Start())
+}
+
+// nonConstant returns the failure of a value which is not constant.
+// Inside comptime functions, it is a use of non-constant state
+func (e *Evaluator) nonConstant(tok token.Token) error {
+	if len(e.frames) > 0 {
+		return &Error{Err: ErrComptimeState, Token: tok}
+	}
+	return &Error{Err: ErrNotConstant, Token: tok}
+}
+
+// ident returns the value of the local or the constant named by x.
+// iota is the index of the constant being evaluated in its group.
+// Inside comptime functions, the locals of the caller are not visible
+func (e *Evaluator) ident(x *ast.IdentExpr) (Value, error) {
+	var (
+		d     *ast.ConstDecl
+		found bool
+	)
+	switch {
+	case len(e.frames) > 0:
+		if l := e.lookupLocal(x.Name.Value); l != nil {
+			return l.value, nil
+		}
+	case e.Resolve != nil:
+		d, found = e.Resolve(x.Name.Value)
+	}
+	if !found {
+		d = e.consts[x.Name.Value]
+	}
+	if d == nil && x.Name.Value == "iota" && e.konst != nil && len(e.frames) == 0 {
+		return Value{Kind: Int, Int: big.NewInt(int64(e.konst.Iota))}, nil
+	}
+	if d == nil {
+		return Value{}, e.nonConstant(x.Name)
+	}
+	return e.Const(d)
+}
+
+// unary returns the value of x like -1 or !true
+func (e *Evaluator) unary(x *ast.UnaryExpr) (Value, error) {
+	v, err := e.Eval(x.Right)
+	if err != nil {
+		return Value{}, err
+	}
+
+	switch {
+	case x.Operator.Kind == token.Not && v.Kind == Bool:
+		v.Bool = !v.Bool
+		return v, nil
+	case x.Operator.Kind == token.Plus && v.numeric():
+		return v, nil
+	case x.Operator.Kind == token.Minus && v.Kind == Int:
+		v.Int = new(big.Int).Neg(v.Int)
+	case x.Operator.Kind == token.Minus && v.Kind == Float:
+		v.Float = new(big.Rat).Neg(v.Float)
+	default:
+		return Value{}, &Error{
+			Err:   fmt.Errorf("%w: operator %s not defined on %s", ErrInvalidOperation, x.Operator.Value, v.typeName()),
+			Token: x.Operator,
+		}
+	}
+	return Represent(v, v.Type, x.Operator)
+}
+
+// binary returns the value of x like 1 + 2 or "a" < "b"
+func (e *Evaluator) binary(x *ast.BinaryExpr) (Value, error) {
+	l, err := e.Eval(x.Left)
+	if err != nil {
+		return Value{}, err
+	}
+	r, err := e.Eval(x.Right)
+	if err != nil {
+		return Value{}, err
+	}
+	if l, r, err = match(l, r, x); err != nil {
+		return Value{}, err
+	}
+
+	op := x.Operator.Kind
+	invalid := &Error{
+		Err:   fmt.Errorf("%w: operator %s not defined on %s", ErrInvalidOperation, x.Operator.Value, l.typeName()),
+		Token: x.Operator,
+	}
+
+	switch {
+	case op == token.And || op == token.Or:
+		if l.Kind != Bool {
+			return Value{}, invalid
+		}
+		if op == token.And {
+			return Value{Kind: Bool, Type: l.Type, Bool: l.Bool && r.Bool}, nil
+		}
+		return Value{Kind: Bool, Type: l.Type, Bool: l.Bool || r.Bool}, nil
+
+	case token.IsComparison(op):
+		cmp, ok := compare(l, r)
+		if !ok || l.Kind == Bool && op != token.Eq && op != token.Neq {
+			return Value{}, invalid
+		}
+		return Value{Kind: Bool, Bool: compared(cmp, op)}, nil
+	}
+
+	v := Value{Kind: l.Kind, Type: l.Type}
+	switch {
+	case l.Kind == String && op == token.Plus:
+		v.Str = l.Str + r.Str
+		return v, nil
+
+	case l.Kind == Int:
+		if (op == token.Slash || op == token.Modulo) && r.Int.Sign() == 0 {
+			return Value{}, &Error{Err: ErrDivisionByZero, Token: x.Right.Start()}
+		}
+		v.Int = new(big.Int)
+		switch op {
+		case token.Plus:
+			v.Int.Add(l.Int, r.Int)
+		case token.Minus:
+			v.Int.Sub(l.Int, r.Int)
+		case token.Star:
+			v.Int.Mul(l.Int, r.Int)
+		case token.Slash:
+			v.Int.Quo(l.Int, r.Int)
+		case token.Modulo:
+			v.Int.Rem(l.Int, r.Int)
+		default:
+			return Value{}, invalid
+		}
+
+	case l.Kind == Float:
+		if op == token.Slash && r.Float.Sign() == 0 {
+			return Value{}, &Error{Err: ErrDivisionByZero, Token: x.Right.Start()}
+		}
+		v.Float = new(big.Rat)
+		switch op {
+		case token.Plus:
+			v.Float.Add(l.Float, r.Float)
+		case token.Minus:
+			v.Float.Sub(l.Float, r.Float)
+		case token.Star:
+			v.Float.Mul(l.Float, r.Float)
+		case token.Slash:
+			v.Float.Quo(l.Float, r.Float)
+		default:
+			return Value{}, invalid
+		}
+
+	default:
+		return Value{}, invalid
+	}
+	return Represent(v, v.Type, x.Operator)
+}
+
+// match returns the operands of x converted to the same type.
+// An untyped operand takes the type of the other one and
+// an untyped int is promoted when the other operand is a float
+func match(l, r Value, x *ast.BinaryExpr) (Value, Value, error) {
+	var err error
+	switch {
+	case l.Type != "" && r.Type != "":
+		if l.Type != r.Type {
+			return l, r, &Error{
+				Err:   fmt.Errorf("%w: %s and %s", ErrMismatchedTypes, l.Type, r.Type),
+				Token: x.Operator,
+			}
+		}
+	case l.Type != "":
+		r, err = Represent(r, l.Type, x.Right.Start())
+	case r.Type != "":
+		l, err = Represent(l, r.Type, x.Left.Start())
+	case l.Kind == Int && r.Kind == Float:
+		l = Value{Kind: Float, Float: new(big.Rat).SetInt(l.Int)}
+	case l.Kind == Float && r.Kind == Int:
+		r = Value{Kind: Float, Float: new(big.Rat).SetInt(r.Int)}
+	}
+	if err != nil {
+		return l, r, err
+	}
+
+	if l.Kind != r.Kind {
+		return l, r, &Error{
+			Err:   fmt.Errorf("%w: %s and %s", ErrMismatchedTypes, l.typeName(), r.typeName()),
+			Token: x.Operator,
+		}
+	}
+	return l, r, nil
+}
+
+// compare returns -1, 0 or 1 when l is lower, equal or greater than r.
+// Booleans are only equal or not
+func compare(l, r Value) (int, bool) {
+	switch l.Kind {
+	case Int:
+		return l.Int.Cmp(r.Int), true
+	case Float:
+		return l.Float.Cmp(r.Float), true
+	case String:
+		return strings.Compare(l.Str, r.Str), true
+	case Bool:
+		if l.Bool == r.Bool {
+			return 0, true
+		}
+		return 1, true
+	}
+	return 0, false
+}
+
+// compared returns the result of the comparison op from cmp
+func compared(cmp int, op token.Kind) bool {
+	switch op {
+	case token.Eq:
+		return cmp == 0
+	case token.Neq:
+		return cmp != 0
+	case token.Lt:
+		return cmp < 0
+	case token.Lte:
+		return cmp <= 0
+	case token.Gt:
+		return cmp > 0
+	}
+	return cmp >= 0
+}
+
+// Convert returns v converted to typ.
+// v is returned untouched when typ is not a basic type
+func Convert(v Value, typ ast.Type, tok token.Token) (Value, error) {
+	nt, ok := typ.(*ast.NamedType)
+	if !ok || len(nt.Parts) != 1 || nt.TypeArgs != nil {
+		return v, nil
+	}
+	return Represent(v, nt.Parts[0].Value, tok)
+}
+
+// Represent returns v converted to the basic type name like int8.
+// It fails when v has not the kind of the type or does not fit in it.
+// v is returned untouched when name is not a basic type
+func Represent(v Value, name string, tok token.Token) (Value, error) {
+	b, ok := basics[name]
+	if !ok {
+		return v, nil
+	}
+
+	switch {
+	case b.kind == Int && v.Kind == Float:
+		if !v.Float.IsInt() {
+			return Value{}, &Error{Err: fmt.Errorf("%w: %s", ErrTruncated, v), Token: tok}
+		}
+		v = Value{Kind: Int, Int: new(big.Int).Set(v.Float.Num())}
+	case b.kind == Float && v.Kind == Int:
+		v = Value{Kind: Float, Float: new(big.Rat).SetInt(v.Int)}
+	case b.kind != v.Kind:
+		return Value{}, &Error{
+			Err:   fmt.Errorf("%w: cannot use %s as %s", ErrMismatchedTypes, v.typeName(), name),
+			Token: tok,
+		}
+	}
+	v.Type = name
+
+	overflow := false
+	switch {
+	case b.kind == Int:
+		overflow = v.Int.Cmp(b.min) < 0 || v.Int.Cmp(b.max) > 0
+	case b.kind == Float && b.bits == 32:
+		f, _ := v.Float.Float32()
+		overflow = math.IsInf(float64(f), 0)
+	case b.kind == Float:
+		f, _ := v.Float.Float64()
+		overflow = math.IsInf(f, 0)
+	}
+	if overflow {
+		return Value{}, &Error{Err: fmt.Errorf("%w: %s does not fit in %s", ErrOverflow, v, name), Token: tok}
+	}
+	return v, nil
+}
+
+// numeric returns true when v is an integer or a float
+func (v Value) numeric() bool {
+	return v.Kind == Int || v.Kind == Float
+}
+
+// typeName returns the type of v like int8 or untyped float
+func (v Value) typeName() string {
+	if v.Type != "" {
+		return v.Type
+	}
+	return "untyped " + v.Kind.String()
+}
+
+// String returns the value like 300, 1.5, "a" or true
+func (v Value) String() string {
+	switch v.Kind {
+	case Bool:
+		return strconv.FormatBool(v.Bool)
+	case String:
+		return strconv.Quote(v.Str)
+	case Int:
+		return v.Int.String()
+	case Float:
+		return new(big.Float).SetRat(v.Float).Text('g', 10)
+	}
+	return "unknown"
+}
+
+// String returns the name of the kind like int
+func (k Kind) String() string {
+	switch k {
+	case Bool:
+		return "bool"
+	case String:
+		return "string"
+	case Int:
+		return "int"
+	case Float:
+		return "float"
+	}
+	return "unknown"
+}
@@ -0,0 +1,124 @@
+package constant
+
+import (
+	"context"
+	"testing"
+
+	"github.com/orilang/gori/ast"
+	"github.com/orilang/gori/lexer"
+	"github.com/orilang/gori/parser"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestConstant(t *testing.T) {
+	assert := assert.New(t)
+
+	tests := []struct {
+		name     string
+		input    string
+		expected string
+	}{
+		{name: "int", input: `const x int = 1_000 + 2 * 3`, expected: "1006"},
+		{name: "int_division", input: `const x int = 7 / 2`, expected: "3"},
+		{name: "modulo", input: `const x int = -7 % 3`, expected: "-1"},
+		{name: "float", input: `const x float = 7 / 2.0`, expected: "3.5"},
+		{name: "big", input: `const x uint64 = 1000000000000000000000 * 1000000000000000000000 / 10000000000000000000000000000000`, expected: "100000000000"},
+		{name: "string", input: `const x string = "a" + "b"`, expected: `"ab"`},
+		{name: "bool", input: `const x bool = !(1 < 2) || "a" == "a" && true`, expected: "true"},
+		{name: "paren", input: `const x int = (1 + 2) * 3`, expected: "9"},
+		{name: "typed", input: `const x int8 = 127`, expected: "127"},
+		{name: "typed_float", input: `const x float32 = 1`, expected: "1"},
+		{name: "integral_float", input: `const x int = 2.0`, expected: "2"},
+		{name: "conversion", input: `const x float64 = float64(3) / 2`, expected: "1.5"},
+		{name: "reference", input: "const x int = y * 2\nconst y int = 21", expected: "42"},
+		{name: "group", input: "const (\n  y int8 = 3\n  x int8 = y + 1\n)", expected: "4"},
+		{name: "iota", input: "const (\n  a int = iota * 10\n  b\n  x\n)", expected: "20"},
+		{name: "iota_reference", input: "const (\n  a int = iota\n  b\n)\nconst x int = b", expected: "1"},
+		{name: "iota_outside_group", input: `const x int = iota`, expected: "0"},
+		{name: "overflow", input: `const x int8 = 300`, expected: `3:16: constant overflow: 300 does not fit in int8, got "300"`},
+		{name: "overflow_uint", input: `const x uint8 = -1`, expected: `3:17: constant overflow: -1 does not fit in uint8, got "-"`},
+		{name: "overflow_typed_operation", input: "const x int8 = y * 2\nconst y int8 = 100", expected: `3:18: constant overflow: 200 does not fit in int8, got "*"`},
+		{name: "overflow_float32", input: `const x float32 = 1000000000000000000000000000000000000000.0`, expected: `3:19: constant overflow: 1e+39 does not fit in float32, got "1000000000000000000000000000000000000000.0"`},
+		{name: "truncated", input: `const x int = 2.5`, expected: `3:15: constant truncated to integer: 2.5, got "2.5"`},
+		{name: "division_by_zero", input: `const x int = 1 / (2 - 2)`, expected: `3:19: division by zero, got "("`},
+		{name: "float_division_by_zero", input: `const x float = 1.5 / 0`, expected: `3:23: division by zero, got "0"`},
+		{name: "not_constant", input: `const x int = f() + 1`, expected: `3:15: value is not constant, got "f"`},
+		{name: "unknown_ident", input: `const x int = y + 1`, expected: `3:15: value is not constant, got "y"`},
+		{name: "mismatched", input: `const x string = "a" + 1`, expected: `3:22: mismatched types: untyped string and untyped int, got "+"`},
+		{name: "mismatched_typed", input: "const x int8 = y + z\nconst y int8 = 1\nconst z int32 = 1", expected: `3:18: mismatched types: int8 and int32, got "+"`},
+		{name: "invalid_operator", input: `const x string = "a" - "b"`, expected: `3:22: invalid operation: operator - not defined on untyped string, got "-"`},
+		{name: "invalid_unary", input: `const x bool = !1`, expected: `3:16: invalid operation: operator ! not defined on untyped int, got "!"`},
+		{name: "cycle", input: "const x int = y\nconst y int = x", expected: `3:7: constant definition cycle, got "x"`},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			lex, err := lexer.NewLexer(lexer.Config{StringOnly: true})
+			assert.Nil(err)
+			tree, err := parser.New(lex.FetchTokensFromString("package main\n\n" + tc.input + "\n")).ParseFile(context.Background())
+			if !assert.Nil(err) {
+				return
+			}
+
+			e := New(tree.Decls)
+			v, err := e.Const(findConst(tree.Decls, "x"))
+			if err != nil {
+				assert.Equal(tc.expected, err.Error())
+				return
+			}
+			assert.Equal(tc.expected, v.String())
+		})
+	}
+
+	t.Run("resolve", func(t *testing.T) {
+		lex, err := lexer.NewLexer(lexer.Config{StringOnly: true})
+		assert.Nil(err)
+		tree, err := parser.New(lex.FetchTokensFromString("package main\n\nconst x int = y + 1\nconst y int = 1\n")).ParseFile(context.Background())
+		assert.Nil(err)
+
+		e := New(tree.Decls)
+		e.Resolve = func(name string) (*ast.ConstDecl, bool) {
+			return nil, name == "y"
+		}
+		_, err = e.Const(findConst(tree.Decls, "x"))
+		assert.ErrorIs(err, ErrNotConstant)
+	})
+
+	t.Run("iota_group", func(t *testing.T) {
+		lex, err := lexer.NewLexer(lexer.Config{StringOnly: true})
+		assert.Nil(err)
+		tree, err := parser.New(lex.FetchTokensFromString("package main\n\nconst (\n  A int = iota\n  B\n  C\n)\n")).ParseFile(context.Background())
+		assert.Nil(err)
+
+		e := New(tree.Decls)
+		var result []string
+		for _, name := range []string{"A", "B", "C"} {
+			v, err := e.Const(findConst(tree.Decls, name))
+			assert.Nil(err)
+			result = append(result, v.String())
+		}
+		assert.Equal([]string{"0", "1", "2"}, result)
+	})
+}
+
+// findConst returns the constant of decls called name
+// including the ones inside groups and comptime blocks
+func findConst(decls []ast.Decl, name string) *ast.ConstDecl {
+	for _, decl := range decls {
+		switch v := decl.(type) {
+		case *ast.ConstDecl:
+			if v.Name.Value == name {
+				return v
+			}
+		case *ast.GenDecl:
+			if d := findConst(v.Specs, name); d != nil {
+				return d
+			}
+		case *ast.ComptimeBlockDecl:
+			if d := findConst(v.Decls, name); d != nil {
+				return d
+			}
+		}
+	}
+	return nil
+}
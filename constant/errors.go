@@ -0,0 +1,30 @@
+package constant
+
+import (
+	"errors"
+	"fmt"
+)
+
+var (
+	ErrNotConstant      = errors.New("value is not constant")
+	ErrOverflow         = errors.New("constant overflow")
+	ErrTruncated        = errors.New("constant truncated to integer")
+	ErrDivisionByZero   = errors.New("division by zero")
+	ErrInvalidOperation = errors.New("invalid operation")
+	ErrMismatchedTypes  = errors.New("mismatched types")
+	ErrCycle            = errors.New("constant definition cycle")
+	ErrComptimeState    = errors.New("comptime code uses non-constant state")
+	ErrStepBudget       = errors.New("comptime step budget exceeded")
+	ErrUnsupported      = errors.New("not supported in comptime")
+	ErrMissingReturn    = errors.New("missing return in comptime function")
+)
+
+// Error returns the reason with the position of the failure
+func (e *Error) Error() string {
+	return fmt.Sprintf("%d:%d: %v, got %q", e.Token.Line, e.Token.Column, e.Err, e.Token.Value)
+}
+
+// Unwrap returns the reason of the failure
+func (e *Error) Unwrap() error {
+	return e.Err
+}
@@ -0,0 +1,131 @@
+package constant
+
+import (
+	"math/big"
+
+	"github.com/orilang/gori/ast"
+	"github.com/orilang/gori/token"
+)
+
+// Kind is the kind of a constant value
+type Kind uint8
+
+const (
+	Unknown Kind = iota
+	Bool
+	String
+	Int
+	Float
+)
+
+// Value holds the result of a constant expression
+type Value struct {
+	// Kind of the value
+	Kind Kind
+
+	// Type is the basic type of the value like int8
+	// or empty when the value is untyped
+	Type string
+
+	// Bool holds the value when Kind is Bool
+	Bool bool
+
+	// Str holds the unquoted value when Kind is String
+	Str string
+
+	// Int holds the value when Kind is Int
+	Int *big.Int
+
+	// Float holds the exact value when Kind is Float
+	Float *big.Rat
+}
+
+// Evaluator holds requirements to evaluate constant expressions
+type Evaluator struct {
+	// Resolve returns the constant declared locally as name.
+	// found is false when name is not declared locally so the
+	// constants of the file are looked up instead.
+	// A nil decl with found set means name is not a constant
+	Resolve func(name string) (decl *ast.ConstDecl, found bool)
+
+	// consts holds the constants of the file by name
+	consts map[string]*ast.ConstDecl
+
+	// values holds the constants already evaluated
+	values map[*ast.ConstDecl]result
+
+	// evaluating holds the constants being evaluated
+	// to detect definition cycles
+	evaluating map[*ast.ConstDecl]bool
+
+	// konst is the constant being evaluated,
+	// its index in its group is the value of iota
+	konst *ast.ConstDecl
+
+	// Steps is the number of statements a comptime call can execute
+	// before being stopped. When lower than 1, DefaultSteps is used
+	Steps int
+
+	// steps is the number of statements executed
+	// by the outermost comptime call
+	steps int
+
+	// funcs holds the comptime functions of the file by name
+	funcs map[string]*ast.FuncDecl
+
+	// frames holds the comptime calls being executed
+	frames []*frame
+}
+
+// frame holds the state of a comptime function being executed
+type frame struct {
+	fn *ast.FuncDecl
+
+	// scopes holds the locals of each nested block
+	scopes []map[string]*local
+
+	// result is the returned value
+	result Value
+}
+
+// local holds a variable or a constant of a comptime function
+type local struct {
+	value Value
+	konst bool
+}
+
+// flow tells how the execution continues after a statement
+type flow uint8
+
+const (
+	flowNext flow = iota
+	flowBreak
+	flowContinue
+	flowReturn
+)
+
+// result holds the outcome of a constant evaluation
+type result struct {
+	value Value
+	err   error
+}
+
+// Error holds a failure of constant evaluation with its position
+type Error struct {
+	// Err is the reason of the failure like ErrOverflow
+	Err error
+
+	// Token is where the failure happens
+	Token token.Token
+}
+
+// basic holds the properties of a basic type
+type basic struct {
+	kind Kind
+
+	// min and max are the bounds of integer types
+	min, max *big.Int
+
+	// bits is the size of float types
+	bits int
+}
@@ -0,0 +1,29 @@
+package lexer
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+var (
+	ErrUnterminatedString       = errors.New("unterminated string")
+	ErrUnterminatedBlockComment = errors.New("unterminated block comment")
+	ErrMisplacedDigitSeparator  = errors.New("misplaced digit separator")
+	ErrMultipleDots             = errors.New("multiple dots in number")
+	ErrMalformedNumber          = errors.New("malformed number")
+	ErrInvalidIdent             = errors.New("invalid identifier")
+	ErrUnexpectedCharacter      = errors.New("unexpected character")
+)
+
+// Error returns the reason with the position of the failure.
+// Only the first line of the value is kept
+func (e *Error) Error() string {
+	value, _, _ := strings.Cut(e.Value, "\n")
+	return fmt.Sprintf("%d:%d: %v, got %q", e.Line, e.Column, e.Err, value)
+}
+
+// Unwrap returns the reason of the failure
+func (e *Error) Unwrap() error {
+	return e.Err
+}
@@ -1,9 +1,14 @@
 package lexer
 
 import (
+	"bytes"
+	"context"
+	"errors"
 	"fmt"
+	"io"
 	"os"
 
+	"github.com/orilang/gori/pool"
 	"github.com/orilang/gori/token"
 	"github.com/orilang/gori/walk"
 )
@@ -12,45 +17,91 @@ import (
 func NewLexer(config Config) (*Files, error) {
 	if config.StringOnly {
 		return &Files{
-			output: config.Output,
+			output:   config.Output,
+			jobs:     config.Jobs,
+			tabWidth: config.TabWidth,
 		}, nil
 	}
 
-	w, err := walk.Walk(walk.Config{File: config.File, Directory: config.Directory})
+	w, err := walk.Walk(walk.Config{File: config.File, Directory: config.Directory, Tags: config.Tags})
 	if err != nil {
 		return nil, err
 	}
 
 	return &Files{
-		Files:  w.Files,
-		output: config.Output,
+		Files:    w.Files,
+		output:   config.Output,
+		jobs:     config.Jobs,
+		tabWidth: config.TabWidth,
 	}, nil
 }
 
-// StartLexing ranges over files for tokenization
-func (f *Files) StartLexing() error {
-	for _, file := range f.Files {
-		data, err := os.ReadFile(file)
-		if err != nil {
+// StartLexing tokenizes files in parallel with at most jobs workers.
+// Output and lexing errors are emitted in files order and
+// lexing errors of all files are returned joined together
+func (f *Files) StartLexing(ctx context.Context) error {
+	var errs []error
+	err := pool.Run(ctx, f.jobs, len(f.Files),
+		func(ctx context.Context, i int) (result, error) {
+			return f.lexFile(ctx, f.Files[i])
+		},
+		func(i int, r result) error {
+			errs = append(errs, FileErrors(f.Files[i], r.errors)...)
+			_, err := os.Stdout.Write(r.output)
 			return err
-		}
+		},
+	)
+	if err != nil {
+		return err
+	}
+	return errors.Join(errs...)
+}
 
-		l := New(data)
-		l.Tokenize()
+// FileErrors prefixes each error with the file it comes from
+func FileErrors(file string, errs []error) []error {
+	var result []error
+	for _, err := range errs {
+		result = append(result, fmt.Errorf("%s:%w", file, err))
+	}
+	return result
+}
 
-		if f.output {
-			for _, v := range l.Tokens {
-				fmt.Printf("Kind %d value %s line %d column %d\n", v.Kind, v.Value, v.Line, v.Column)
+// lexFile streams the tokens of the provided file
+// and returns its output with the lexing errors found
+func (f *Files) lexFile(ctx context.Context, file string) (result, error) {
+	fd, err := os.Open(file)
+	if err != nil {
+		return result{}, err
+	}
+	defer func() {
+		_ = fd.Close()
+	}()
+
+	var b bytes.Buffer
+	l := NewReader(fd)
+	l.TabWidth = f.tabWidth
+	for i := 0; ; i++ {
+		if i%checkInterval == 0 {
+			if err := ctx.Err(); err != nil {
+				return result{}, err
 			}
 		}
+
+		v := l.Next()
+		if f.output {
+			fmt.Fprintf(&b, "Kind %d value %s line %d column %d\n", v.Kind, v.Value, v.Line, v.Column)
+		}
+		if v.Kind == token.EOF {
+			return result{output: b.Bytes(), errors: l.Errors}, nil
+		}
 	}
-	return nil
 }
 
 // StartLexingFromString transforms data passed for tokenization
 func (f *Files) StartLexingFromString(s string) {
 	l := New([]byte(s))
-	l.Tokenize()
+	l.TabWidth = f.tabWidth
+	_ = l.Tokenize(context.Background())
 
 	if f.output {
 		for _, v := range l.Tokens {
@@ -62,7 +113,8 @@ func (f *Files) StartLexingFromString(s string) {
 // FetchTokensFromString returns tokenization results from string
 func (f *Files) FetchTokensFromString(s string) []token.Token {
 	l := New([]byte(s))
-	l.Tokenize()
+	l.TabWidth = f.tabWidth
+	_ = l.Tokenize(context.Background())
 
 	return l.Tokens
 }
@@ -77,293 +129,439 @@ func New(input []byte) *Lexer {
 	}
 }
 
-// next appends the new data to the current token list
+// NewReader returns a lexer reading its input from r on demand.
+// It is meant to be used with Next to keep memory usage
+// proportional to the token being scanned instead of the file size
+func NewReader(r io.Reader) *Lexer {
+	return &Lexer{
+		reader: r,
+		line:   1,
+		column: 1,
+	}
+}
+
+// newToken queues the new data to be returned by Next
 func (l *Lexer) newToken(kind token.Kind, data []byte, line, column int) {
-	l.Tokens = append(l.Tokens, token.Token{
+	l.pending = append(l.pending, token.Token{
 		Kind:   kind,
 		Value:  string(data),
 		Line:   line,
 		Column: column,
+		Offset: l.start,
 	})
 }
 
-func (l *Lexer) advance(pos int, newLine bool) {
-	l.position += pos
-	if newLine {
-		l.column = 1
-		l.line++
-		return
+// at returns the byte located at offset i from the current position.
+// More data is read from the reader when needed.
+// bool is set to false when the input is exhausted
+func (l *Lexer) at(i int) (byte, bool) {
+	for l.position+i >= l.size {
+		if !l.fill() {
+			return 0, false
+		}
 	}
-	l.column += pos
+	return l.input[l.position+i], true
 }
 
-func (l *Lexer) Tokenize() {
-	for l.position < l.size {
-		var tok []byte
-		v := l.input[l.position]
-		// fmt.Printf("INPUT '%s' line %d position %d column %d\n", string(v), l.line, l.position, l.column)
-
-		switch {
-		case isWhitespace(v):
-			l.skipWhitespace()
+// fill reads the next chunk of data from the reader
+// and returns false when nothing more can be read
+func (l *Lexer) fill() bool {
+	if l.reader == nil {
+		return false
+	}
 
-		case v == '=':
-			line, column := l.line, l.column
-			if l.compareNextToken('=') {
-				tok = append(tok, v, v)
-				l.newToken(token.Eq, tok, line, column)
-				l.advance(2, false)
-			} else {
-				tok = append(tok, v)
-				l.newToken(token.Assign, tok, line, column)
-				l.advance(1, false)
-			}
+	buf := make([]byte, readSize)
+	for {
+		n, err := l.reader.Read(buf)
+		if n > 0 {
+			l.input = append(l.input, buf[:n]...)
+			l.size = len(l.input)
+			return true
+		}
+		if err != nil {
+			l.reader = nil
+			return false
+		}
+	}
+}
 
-		case v == ':':
-			line, column := l.line, l.column
-			if l.compareNextToken('=') {
-				tok = append(tok, v, '=')
-				l.newToken(token.Define, tok, line, column)
-				l.advance(2, false)
-			} else {
-				tok = append(tok, v)
-				l.newToken(token.Colon, tok, line, column)
-				l.advance(1, false)
-			}
+// compact drops already scanned data when reading from a reader
+// so the buffer never grows with the file size
+func (l *Lexer) compact() {
+	if l.reader == nil || l.position < readSize {
+		return
+	}
 
-		case v == '/':
-			line, column := l.line, l.column
-			if l.compareNextToken('/') {
-				l.singleLineComment()
-			} else if l.compareNextToken('*') {
-				l.multiLineComment()
-			} else if l.compareNextToken('=') {
-				tok = append(tok, v, '=')
-				l.newToken(token.SlashEq, tok, line, column)
-				l.advance(2, false)
-			} else {
-				tok = append(tok, v)
-				l.newToken(token.Slash, tok, line, column)
-				l.advance(1, false)
-			}
+	n := copy(l.input, l.input[l.position:])
+	l.input = l.input[:n]
+	l.size = n
+	l.position = 0
+}
 
-		case v == '+':
-			line, column := l.line, l.column
-			if l.compareNextToken('=') {
-				tok = append(tok, v, '=')
-				l.newToken(token.PlusEq, tok, line, column)
-				l.advance(2, false)
-			} else if l.compareNextToken('+') {
-				tok = append(tok, v, '+')
-				l.newToken(token.PPlus, tok, line, column)
-				l.advance(2, false)
-			} else {
-				tok = append(tok, v)
-				l.newToken(token.Plus, tok, line, column)
-				l.advance(1, false)
-			}
+// illegal appends an illegal token and records the reason
+// of the failure in the errors list
+func (l *Lexer) illegal(reason error, data []byte, line, column int) {
+	l.newToken(token.Illegal, data, line, column)
+	l.Errors = append(l.Errors, &Error{
+		Err:    reason,
+		Line:   line,
+		Column: column,
+		Value:  string(data),
+	})
+}
 
-		case v == '-':
-			line, column := l.line, l.column
-			if l.compareNextToken('=') {
-				tok = append(tok, v, '=')
-				l.newToken(token.MinusEq, tok, line, column)
-				l.advance(2, false)
-			} else if l.compareNextToken('-') {
-				tok = append(tok, v, '-')
-				l.newToken(token.MMinus, tok, line, column)
-				l.advance(2, false)
-			} else {
-				tok = append(tok, v)
-				l.newToken(token.Minus, tok, line, column)
-				l.advance(1, false)
-			}
+// advance moves forward by n bytes and updates the line and column.
+// "\n", "\r\n" and a lone "\r" are line breaks, a tab moves to the
+// next tab stop and UTF-8 continuation bytes do not count as columns
+func (l *Lexer) advance(n int) {
+	for range n {
+		v := l.input[l.position]
+		l.position++
+		l.offset++
 
-		case v == '*':
-			line, column := l.line, l.column
-			if l.compareNextToken('=') {
-				tok = append(tok, v, '=')
-				l.newToken(token.StarEq, tok, line, column)
-				l.advance(2, false)
-			} else {
-				tok = append(tok, v)
-				l.newToken(token.Star, tok, line, column)
-				l.advance(1, false)
+		switch {
+		case v == '\n':
+			l.line++
+			l.column = 1
+		case v == '\r':
+			if next, ok := l.at(0); !ok || next != '\n' {
+				l.line++
+				l.column = 1
 			}
+		case v == '\t' && l.TabWidth > 1:
+			l.column += l.TabWidth - (l.column-1)%l.TabWidth
+		case v&0xc0 == 0x80:
+		default:
+			l.column++
+		}
+	}
+}
 
-		case v == '%':
-			line, column := l.line, l.column
-			tok = append(tok, v)
-			l.newToken(token.Modulo, tok, line, column)
-			l.advance(1, false)
+// skipBOM skips the UTF-8 byte order mark at the start of the input.
+// Byte offsets still account for it
+func (l *Lexer) skipBOM() {
+	for i, b := range bom {
+		if v, ok := l.at(i); !ok || v != b {
+			return
+		}
+	}
+	l.position += len(bom)
+	l.offset += len(bom)
+}
 
-		case v == '!':
-			line, column := l.line, l.column
-			if l.compareNextToken('=') {
-				tok = append(tok, v, '=')
-				l.newToken(token.Neq, tok, line, column)
-				l.advance(2, false)
-			} else {
-				tok = append(tok, v)
-				l.newToken(token.Not, tok, line, column)
-				l.advance(1, false)
+// Tokenize runs Next until EOF and stores all tokens in Tokens.
+// Cancellation is checked periodically and ctx.Err() is returned
+// when ctx is done
+func (l *Lexer) Tokenize(ctx context.Context) error {
+	for {
+		if len(l.Tokens)%checkInterval == 0 {
+			if err := ctx.Err(); err != nil {
+				return err
 			}
+		}
 
-		case v == '|':
-			line, column := l.line, l.column
-			if l.compareNextToken('|') {
-				tok = append(tok, v, '|')
-				l.newToken(token.Or, tok, line, column)
-				l.advance(2, false)
-			} else {
-				tok = append(tok, v)
-				l.newToken(token.Illegal, tok, line, column)
-				l.advance(1, false)
-			}
+		tok := l.Next()
+		l.Tokens = append(l.Tokens, tok)
+		if tok.Kind == token.EOF {
+			return nil
+		}
+	}
+}
 
-		case v == '<':
-			line, column := l.line, l.column
-			if l.compareNextToken('=') {
-				tok = append(tok, v, '=')
-				l.newToken(token.Lte, tok, line, column)
-				l.advance(2, false)
-			} else {
-				tok = append(tok, v)
-				l.newToken(token.Lt, tok, line, column)
-				l.advance(1, false)
-			}
+// Next returns the next token of the input.
+// When the lexer is built with NewReader, data is read on demand
+// so only the token being scanned is kept in memory.
+// Once the input is exhausted, EOF is returned on every call
+func (l *Lexer) Next() token.Token {
+	for len(l.pending) == 0 {
+		if !l.scan() {
+			return token.Token{Kind: token.EOF, Line: l.line, Column: l.column, Offset: l.offset}
+		}
+	}
 
-		case v == '>':
-			line, column := l.line, l.column
-			if l.compareNextToken('=') {
-				tok = append(tok, v, '=')
-				l.newToken(token.Gte, tok, line, column)
-				l.advance(2, false)
-			} else {
-				tok = append(tok, v)
-				l.newToken(token.Gt, tok, line, column)
-				l.advance(1, false)
-			}
+	tok := l.pending[0]
+	l.pending = l.pending[1:]
+	return tok
+}
 
-		case v == '&':
-			line, column := l.line, l.column
-			if l.compareNextToken('&') {
-				tok = append(tok, v, '&')
-				l.newToken(token.And, tok, line, column)
-				l.advance(2, false)
-			} else {
-				tok = append(tok, v)
-				l.newToken(token.Illegal, tok, line, column)
-				l.advance(1, false)
-			}
+// scan reads the next lexeme of the input and returns false
+// when there is nothing left to read
+func (l *Lexer) scan() bool {
+	l.compact()
+	if l.offset == 0 {
+		l.skipBOM()
+	}
 
-		// must be check, handled twice
-		case v == '.':
-			if ch, ok := l.fetchNextToken(); ok && isDigit(ch) {
-				l.number()
-			} else {
-				line, column := l.line, l.column
-				tok = append(tok, v)
-				l.newToken(token.Dot, tok, line, column)
-				l.advance(1, false)
-			}
+	v, ok := l.at(0)
+	if !ok {
+		return false
+	}
+	l.start = l.offset
 
-		case v == '_':
-			if ch, ok := l.fetchNextToken(); ok && (ch == '.' || isDigit(ch)) {
-				l.number()
-			} else if ch, ok := l.fetchNextToken(); ok && isLetter(ch) {
-				l.identOrKeyword()
-			} else {
-				line, column := l.line, l.column
-				tok = append(tok, v)
-				l.newToken(token.Ident, tok, line, column)
-				l.advance(1, false)
-			}
+	var tok []byte
+	switch {
+	case isWhitespace(v):
+		l.skipWhitespace()
+
+	case v == '=':
+		line, column := l.line, l.column
+		if l.compareNextToken('=') {
+			tok = append(tok, v, v)
+			l.newToken(token.Eq, tok, line, column)
+			l.advance(2)
+		} else {
+			tok = append(tok, v)
+			l.newToken(token.Assign, tok, line, column)
+			l.advance(1)
+		}
 
-		case v == ',':
-			line, column := l.line, l.column
+	case v == ':':
+		line, column := l.line, l.column
+		if l.compareNextToken('=') {
+			tok = append(tok, v, '=')
+			l.newToken(token.Define, tok, line, column)
+			l.advance(2)
+		} else {
 			tok = append(tok, v)
-			l.newToken(token.Comma, tok, line, column)
-			l.advance(1, false)
+			l.newToken(token.Colon, tok, line, column)
+			l.advance(1)
+		}
 
-		case v == ';':
-			line, column := l.line, l.column
+	case v == '/':
+		line, column := l.line, l.column
+		if l.compareNextToken('/') {
+			l.singleLineComment()
+		} else if l.compareNextToken('*') {
+			l.multiLineComment()
+		} else if l.compareNextToken('=') {
+			tok = append(tok, v, '=')
+			l.newToken(token.SlashEq, tok, line, column)
+			l.advance(2)
+		} else {
 			tok = append(tok, v)
-			l.newToken(token.SemiComma, tok, line, column)
-			l.advance(1, false)
+			l.newToken(token.Slash, tok, line, column)
+			l.advance(1)
+		}
 
-		case v == '(':
-			line, column := l.line, l.column
+	case v == '+':
+		line, column := l.line, l.column
+		if l.compareNextToken('=') {
+			tok = append(tok, v, '=')
+			l.newToken(token.PlusEq, tok, line, column)
+			l.advance(2)
+		} else if l.compareNextToken('+') {
+			tok = append(tok, v, '+')
+			l.newToken(token.PPlus, tok, line, column)
+			l.advance(2)
+		} else {
 			tok = append(tok, v)
-			l.newToken(token.LParen, tok, line, column)
-			l.advance(1, false)
+			l.newToken(token.Plus, tok, line, column)
+			l.advance(1)
+		}
 
-		case v == ')':
-			line, column := l.line, l.column
+	case v == '-':
+		line, column := l.line, l.column
+		if l.compareNextToken('=') {
+			tok = append(tok, v, '=')
+			l.newToken(token.MinusEq, tok, line, column)
+			l.advance(2)
+		} else if l.compareNextToken('-') {
+			tok = append(tok, v, '-')
+			l.newToken(token.MMinus, tok, line, column)
+			l.advance(2)
+		} else {
 			tok = append(tok, v)
-			l.newToken(token.RParen, tok, line, column)
-			l.advance(1, false)
+			l.newToken(token.Minus, tok, line, column)
+			l.advance(1)
+		}
 
-		case v == '[':
-			line, column := l.line, l.column
+	case v == '*':
+		line, column := l.line, l.column
+		if l.compareNextToken('=') {
+			tok = append(tok, v, '=')
+			l.newToken(token.StarEq, tok, line, column)
+			l.advance(2)
+		} else {
 			tok = append(tok, v)
-			l.newToken(token.LBracket, tok, line, column)
-			l.advance(1, false)
+			l.newToken(token.Star, tok, line, column)
+			l.advance(1)
+		}
 
-		case v == ']':
-			line, column := l.line, l.column
+	case v == '%':
+		line, column := l.line, l.column
+		tok = append(tok, v)
+		l.newToken(token.Modulo, tok, line, column)
+		l.advance(1)
+
+	case v == '!':
+		line, column := l.line, l.column
+		if l.compareNextToken('=') {
+			tok = append(tok, v, '=')
+			l.newToken(token.Neq, tok, line, column)
+			l.advance(2)
+		} else {
 			tok = append(tok, v)
-			l.newToken(token.RBracket, tok, line, column)
-			l.advance(1, false)
+			l.newToken(token.Not, tok, line, column)
+			l.advance(1)
+		}
 
-		case v == '{':
-			line, column := l.line, l.column
+	case v == '|':
+		line, column := l.line, l.column
+		if l.compareNextToken('|') {
+			tok = append(tok, v, '|')
+			l.newToken(token.Or, tok, line, column)
+			l.advance(2)
+		} else {
 			tok = append(tok, v)
-			l.newToken(token.LBrace, tok, line, column)
-			l.advance(1, false)
+			l.newToken(token.Pipe, tok, line, column)
+			l.advance(1)
+		}
 
-		case v == '}':
-			line, column := l.line, l.column
+	case v == '<':
+		line, column := l.line, l.column
+		if l.compareNextToken('=') {
+			tok = append(tok, v, '=')
+			l.newToken(token.Lte, tok, line, column)
+			l.advance(2)
+		} else {
 			tok = append(tok, v)
-			l.newToken(token.RBrace, tok, line, column)
-			l.advance(1, false)
+			l.newToken(token.Lt, tok, line, column)
+			l.advance(1)
+		}
 
-		case v == '"':
-			l.stringLit()
+	case v == '>':
+		line, column := l.line, l.column
+		if l.compareNextToken('=') {
+			tok = append(tok, v, '=')
+			l.newToken(token.Gte, tok, line, column)
+			l.advance(2)
+		} else {
+			tok = append(tok, v)
+			l.newToken(token.Gt, tok, line, column)
+			l.advance(1)
+		}
 
-		case isLetter(v):
-			l.identOrKeyword()
+	case v == '&':
+		line, column := l.line, l.column
+		if l.compareNextToken('&') {
+			tok = append(tok, v, '&')
+			l.newToken(token.And, tok, line, column)
+			l.advance(2)
+		} else {
+			tok = append(tok, v)
+			l.illegal(ErrUnexpectedCharacter, tok, line, column)
+			l.advance(1)
+		}
 
-		case isDigit(v):
+	// must be check, handled twice
+	case v == '.':
+		if ch, ok := l.fetchNextToken(); ok && isDigit(ch) {
 			l.number()
+		} else if ch, ok := l.at(2); ok && ch == '.' && l.compareNextToken('.') {
+			line, column := l.line, l.column
+			tok = append(tok, v, '.', '.')
+			l.newToken(token.Ellipsis, tok, line, column)
+			l.advance(3)
+		} else {
+			line, column := l.line, l.column
+			tok = append(tok, v)
+			l.newToken(token.Dot, tok, line, column)
+			l.advance(1)
+		}
 
-		default:
+	case v == '_':
+		if ch, ok := l.fetchNextToken(); ok && (ch == '.' || isDigit(ch)) {
+			l.number()
+		} else if ch, ok := l.fetchNextToken(); ok && isLetter(ch) {
+			l.identOrKeyword()
+		} else {
 			line, column := l.line, l.column
 			tok = append(tok, v)
-			l.newToken(token.Illegal, tok, line, column)
-			l.advance(1, false)
+			l.newToken(token.Ident, tok, line, column)
+			l.advance(1)
 		}
+
+	case v == ',':
+		line, column := l.line, l.column
+		tok = append(tok, v)
+		l.newToken(token.Comma, tok, line, column)
+		l.advance(1)
+
+	case v == ';':
+		line, column := l.line, l.column
+		tok = append(tok, v)
+		l.newToken(token.SemiComma, tok, line, column)
+		l.advance(1)
+
+	case v == '(':
+		line, column := l.line, l.column
+		tok = append(tok, v)
+		l.newToken(token.LParen, tok, line, column)
+		l.advance(1)
+
+	case v == ')':
+		line, column := l.line, l.column
+		tok = append(tok, v)
+		l.newToken(token.RParen, tok, line, column)
+		l.advance(1)
+
+	case v == '[':
+		line, column := l.line, l.column
+		tok = append(tok, v)
+		l.newToken(token.LBracket, tok, line, column)
+		l.advance(1)
+
+	case v == ']':
+		line, column := l.line, l.column
+		tok = append(tok, v)
+		l.newToken(token.RBracket, tok, line, column)
+		l.advance(1)
+
+	case v == '{':
+		line, column := l.line, l.column
+		tok = append(tok, v)
+		l.newToken(token.LBrace, tok, line, column)
+		l.advance(1)
+
+	case v == '}':
+		line, column := l.line, l.column
+		tok = append(tok, v)
+		l.newToken(token.RBrace, tok, line, column)
+		l.advance(1)
+
+	case v == '"':
+		l.stringLit()
+
+	case isLetter(v):
+		l.identOrKeyword()
+
+	case isDigit(v):
+		l.number()
+
+	default:
+		line, column := l.line, l.column
+		tok = append(tok, v)
+		l.illegal(ErrUnexpectedCharacter, tok, line, column)
+		l.advance(1)
 	}
-	l.newToken(token.EOF, nil, l.line, l.column)
+
+	return true
 }
 
 // skipWhitespace skips any white space characters
 func (l *Lexer) skipWhitespace() {
-	for _, v := range l.input[l.position:] {
-		if !isWhitespace(v) {
+	for {
+		v, ok := l.at(0)
+		if !ok || !isWhitespace(v) {
 			break
 		}
-		if v == '\n' {
-			l.advance(1, true)
-		} else {
-			l.advance(1, false)
-		}
+		l.advance(1)
 	}
 }
 
 // compareNextToken compares if next token match the provided one
 func (l *Lexer) compareNextToken(ch byte) bool {
-	if l.position+1 < l.size && l.input[l.position+1] == ch {
+	if v, ok := l.at(1); ok && v == ch {
 		return true
 	}
 	return false
@@ -372,25 +570,23 @@ func (l *Lexer) compareNextToken(ch byte) bool {
 // fetchNextToken returns the next token.
 // bool is the to true when a token is find
 func (l *Lexer) fetchNextToken() (byte, bool) {
-	if l.position+1 < l.size {
-		return l.input[l.position+1], true
-	}
-	return 0, false
+	return l.at(1)
 }
 
 // identOrKeyword parses the token and appends token list
 func (l *Lexer) identOrKeyword() {
 	var tok []byte
 	line, column := l.line, l.column
-	for _, v := range l.input[l.position:] {
-		if !isLetter(v) && !isDigit(v) && v != '_' {
+	for i := 0; ; i++ {
+		v, ok := l.at(i)
+		if !ok || (!isLetter(v) && !isDigit(v) && v != '_') {
 			break
 		}
 		tok = append(tok, v)
 	}
-	l.advance(len(tok), false)
+	l.advance(len(tok))
 	if tok[0] == '_' {
-		l.newToken(token.Illegal, tok, line, column)
+		l.illegal(ErrInvalidIdent, tok, line, column)
 		return
 	}
 	l.newToken(token.LookupKeyword(string(tok)), tok, line, column)
@@ -405,7 +601,11 @@ func (l *Lexer) number() {
 		illegal, isIdent bool
 	)
 	line, column := l.line, l.column
-	for i, v := range l.input[l.position:] {
+	for i := 0; ; i++ {
+		v, ok := l.at(i)
+		if !ok {
+			break
+		}
 		if isLetter(v) {
 			isIdent = true
 		}
@@ -426,28 +626,32 @@ func (l *Lexer) number() {
 		tok = append(tok, v)
 	}
 
-	l.advance(len(tok), false)
+	l.advance(len(tok))
 	if len(tok) > 1 {
 		if illegal {
-			l.newToken(token.Illegal, tok, line, column)
+			l.illegal(ErrMisplacedDigitSeparator, tok, line, column)
 			return
 		}
 
 		last := tok[len(tok)-1]
 		switch {
 		case dot == 1:
-			if last == '.' || last == '_' {
-				l.newToken(token.Illegal, tok, line, column)
+			if last == '_' {
+				l.illegal(ErrMisplacedDigitSeparator, tok, line, column)
+				return
+			}
+			if last == '.' {
+				l.illegal(ErrMalformedNumber, tok, line, column)
 				return
 			}
 			l.newToken(token.FloatLit, tok, line, column)
 
 		case dot > 1:
-			l.newToken(token.Illegal, tok, line, column)
+			l.illegal(ErrMultipleDots, tok, line, column)
 
 		case undescore > 0:
 			if tok[0] == '_' || last == '_' {
-				l.newToken(token.Illegal, tok, line, column)
+				l.illegal(ErrMisplacedDigitSeparator, tok, line, column)
 				return
 			}
 			l.newToken(token.IntLit, tok, line, column)
@@ -470,7 +674,11 @@ func (l *Lexer) stringLit() {
 		prev  byte
 	)
 	line, column := l.line, l.column
-	for _, v := range l.input[l.position:] {
+	for i := 0; ; i++ {
+		v, ok := l.at(i)
+		if !ok {
+			break
+		}
 		tok = append(tok, v)
 		if prev != '\\' && v == '"' {
 			count++
@@ -481,27 +689,37 @@ func (l *Lexer) stringLit() {
 		prev = v
 	}
 
-	l.advance(len(tok), false)
+	l.advance(len(tok))
 	if count == 2 {
 		l.newToken(token.StringLit, tok, line, column)
 		return
 	}
-	l.newToken(token.Illegal, tok, line, column)
+	l.illegal(ErrUnterminatedString, tok, line, column)
 }
 
-// singleLineComment parses single line comment and appends token list
+// singleLineComment parses single line comment and appends token list.
+// Comments starting with /// are doc comments and the ones
+// starting with //ori: are directives
 func (l *Lexer) singleLineComment() {
 	var tok []byte
 	line, column := l.line, l.column
-	for _, v := range l.input[l.position:] {
-		if v == '\n' {
+	for i := 0; ; i++ {
+		v, ok := l.at(i)
+		if !ok || v == '\n' || v == '\r' {
 			break
 		}
 		tok = append(tok, v)
 	}
 
-	l.advance(len(tok), false)
-	l.newToken(token.Comment, tok, line, column)
+	l.advance(len(tok))
+	switch {
+	case bytes.HasPrefix(tok, []byte(token.DirectivePrefix)):
+		l.newToken(token.Directive, tok, line, column)
+	case bytes.HasPrefix(tok, []byte("///")) && !bytes.HasPrefix(tok, []byte("////")):
+		l.newToken(token.DocComment, tok, line, column)
+	default:
+		l.newToken(token.Comment, tok, line, column)
+	}
 }
 
 // multiLineComment parses multi line comments like /* */ and appends token list
@@ -511,34 +729,25 @@ func (l *Lexer) multiLineComment() {
 		ok  bool
 	)
 	line, column := l.line, l.column
-	compareNextToken := func(ch byte, pos int) bool {
-		if pos+1 < l.size && l.input[pos+1] == ch {
-			return true
+	for {
+		v, found := l.at(0)
+		if !found {
+			break
 		}
-		return false
-	}
-
-	pos := l.position
-	for _, v := range l.input[l.position:] {
-		if v == '*' && compareNextToken('/', pos) {
+		if v == '*' && l.compareNextToken('/') {
 			tok = append(tok, v, '/')
 			ok = true
-			l.advance(2, false)
+			l.advance(2)
 			break
 		}
-		if v == '\n' {
-			l.advance(1, true)
-		} else {
-			l.advance(1, false)
-		}
+		l.advance(1)
 		tok = append(tok, v)
-		pos++
 	}
 	if ok {
 		l.newToken(token.Comment, tok, line, column)
 		return
 	}
-	l.newToken(token.Illegal, tok, line, column)
+	l.illegal(ErrUnterminatedBlockComment, tok, line, column)
 }
 
 // isLetter returns wether we found a letter or not.
@@ -1,10 +1,13 @@
 package lexer
 
 import (
+	"context"
 	"os"
 	"path/filepath"
+	"strings"
 	"syscall"
 	"testing"
+	"testing/iotest"
 
 	"github.com/orilang/gori/token"
 	"github.com/stretchr/testify/assert"
@@ -20,7 +23,7 @@ func TestLexer_lexer(t *testing.T) {
 
 	t.Run("err_start_lexing", func(t *testing.T) {
 		lex := &Files{Files: []string{"xxxx.ori"}}
-		assert.ErrorIs(lex.StartLexing(), syscall.Errno(2))
+		assert.ErrorIs(lex.StartLexing(context.Background()), syscall.Errno(2))
 	})
 
 	t.Run("success_files", func(t *testing.T) {
@@ -29,7 +32,34 @@ func TestLexer_lexer(t *testing.T) {
 
 		lex, err := NewLexer(Config{Directory: filepath.Join(workingDir, "..", "testdata/success")})
 		assert.Nil(err)
-		assert.Nil(lex.StartLexing())
+		assert.Nil(lex.StartLexing(context.Background()))
+	})
+
+	t.Run("jobs_ordered_errors", func(t *testing.T) {
+		workingDir, err := os.Getwd()
+		assert.Nil(err)
+
+		lex, err := NewLexer(Config{Directory: filepath.Join(workingDir, "..", "testdata/illegal"), Jobs: 3})
+		assert.Nil(err)
+		for range 5 {
+			msg := lex.StartLexing(context.Background()).Error()
+			comment := strings.Index(msg, "multiline_comment.ori:4:3")
+			numbers := strings.Index(msg, "numbers.ori:4:21")
+			str := strings.Index(msg, "string.ori:4:18")
+			assert.Less(comment, numbers)
+			assert.Less(numbers, str)
+		}
+	})
+
+	t.Run("err_cancelled", func(t *testing.T) {
+		workingDir, err := os.Getwd()
+		assert.Nil(err)
+
+		lex, err := NewLexer(Config{Directory: filepath.Join(workingDir, "..", "testdata/success")})
+		assert.Nil(err)
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+		assert.ErrorIs(lex.StartLexing(ctx), context.Canceled)
 	})
 
 	t.Run("success_start_lexing_from_string", func(t *testing.T) {
@@ -43,8 +73,8 @@ func TestLexer_lexer(t *testing.T) {
 		assert.Nil(err)
 		result := []token.Token{
 			{Kind: token.KWPackage, Value: "package", Line: 1, Column: 1},
-			{Kind: token.Ident, Value: "main", Line: 1, Column: 9},
-			{Kind: token.EOF, Value: "", Line: 2, Column: 1},
+			{Kind: token.Ident, Value: "main", Line: 1, Column: 9, Offset: 8},
+			{Kind: token.EOF, Value: "", Line: 2, Column: 1, Offset: 13},
 		}
 		assert.Equal(result, lex.FetchTokensFromString("package main\n"))
 	})
@@ -55,7 +85,12 @@ func TestLexer_lexer(t *testing.T) {
 
 		lex, err := NewLexer(Config{Directory: filepath.Join(workingDir, "..", "testdata/illegal")})
 		assert.Nil(err)
-		assert.Nil(lex.StartLexing())
+		err = lex.StartLexing(context.Background())
+		assert.ErrorIs(err, ErrUnterminatedBlockComment)
+		assert.ErrorIs(err, ErrMultipleDots)
+		assert.ErrorIs(err, ErrMisplacedDigitSeparator)
+		assert.ErrorIs(err, ErrUnterminatedString)
+		assert.Contains(err.Error(), "string.ori:4:18: unterminated string")
 	})
 
 	t.Run("basic", func(t *testing.T) {
@@ -75,7 +110,7 @@ func main() {}
 			{Kind: token.EOF, Value: ""},
 		}
 		lex := New([]byte(input))
-		lex.Tokenize()
+		assert.Nil(lex.Tokenize(context.Background()))
 		for i, r := range result {
 			assert.Equal(r.Kind, lex.Tokens[i].Kind)
 			assert.Equal(r.Value, lex.Tokens[i].Value)
@@ -95,24 +130,109 @@ multi line
 `
 		result := []token.Token{
 			{Kind: token.KWPackage, Value: "package", Line: 1, Column: 1},
-			{Kind: token.Ident, Value: "main", Line: 1, Column: 9},
-			{Kind: token.KWFunc, Value: "func", Line: 3, Column: 1},
-			{Kind: token.Ident, Value: "main", Line: 3, Column: 6},
-			{Kind: token.LParen, Value: "(", Line: 3, Column: 10},
-			{Kind: token.RParen, Value: ")", Line: 3, Column: 11},
-			{Kind: token.LBrace, Value: "{", Line: 3, Column: 13},
-			{Kind: token.Comment, Value: "// comment", Line: 4, Column: 1},
+			{Kind: token.Ident, Value: "main", Line: 1, Column: 9, Offset: 8},
+			{Kind: token.KWFunc, Value: "func", Line: 3, Column: 1, Offset: 14},
+			{Kind: token.Ident, Value: "main", Line: 3, Column: 6, Offset: 19},
+			{Kind: token.LParen, Value: "(", Line: 3, Column: 10, Offset: 23},
+			{Kind: token.RParen, Value: ")", Line: 3, Column: 11, Offset: 24},
+			{Kind: token.LBrace, Value: "{", Line: 3, Column: 13, Offset: 26},
+			{Kind: token.Comment, Value: "// comment", Line: 4, Column: 1, Offset: 28},
 			{Kind: token.Comment, Value: `/*
 multi line
-*/`, Line: 5, Column: 1},
-			{Kind: token.RBrace, Value: "}", Line: 8, Column: 1},
-			{Kind: token.EOF, Value: "", Line: 9, Column: 1},
+*/`, Line: 5, Column: 1, Offset: 39},
+			{Kind: token.RBrace, Value: "}", Line: 8, Column: 1, Offset: 56},
+			{Kind: token.EOF, Value: "", Line: 9, Column: 1, Offset: 58},
 		}
 		lex := New([]byte(input))
-		lex.Tokenize()
+		assert.Nil(lex.Tokenize(context.Background()))
 		assert.Equal(result, lex.Tokens)
 	})
 
+	t.Run("crlf", func(t *testing.T) {
+		input := "package main\r\n\r\nvar a int\rvar b int\r\n"
+		result := []token.Token{
+			{Kind: token.KWPackage, Value: "package", Line: 1, Column: 1, Offset: 0},
+			{Kind: token.Ident, Value: "main", Line: 1, Column: 9, Offset: 8},
+			{Kind: token.KWVar, Value: "var", Line: 3, Column: 1, Offset: 16},
+			{Kind: token.Ident, Value: "a", Line: 3, Column: 5, Offset: 20},
+			{Kind: token.KWInt, Value: "int", Line: 3, Column: 7, Offset: 22},
+			{Kind: token.KWVar, Value: "var", Line: 4, Column: 1, Offset: 26},
+			{Kind: token.Ident, Value: "b", Line: 4, Column: 5, Offset: 30},
+			{Kind: token.KWInt, Value: "int", Line: 4, Column: 7, Offset: 32},
+			{Kind: token.EOF, Value: "", Line: 5, Column: 1, Offset: 37},
+		}
+		lex := New([]byte(input))
+		assert.Nil(lex.Tokenize(context.Background()))
+		assert.Equal(result, lex.Tokens)
+	})
+
+	t.Run("crlf_comments", func(t *testing.T) {
+		input := "// comment\r\n/*\r\nblock\r\n*/ a\r\n"
+		lex := New([]byte(input))
+		assert.Nil(lex.Tokenize(context.Background()))
+		assert.Equal("// comment", lex.Tokens[0].Value)
+		assert.Equal(2, lex.Tokens[1].Line)
+		assert.Equal(token.Ident, lex.Tokens[2].Kind)
+		assert.Equal(4, lex.Tokens[2].Line)
+		assert.Equal(4, lex.Tokens[2].Column)
+		assert.Equal(5, lex.Tokens[3].Line)
+	})
+
+	t.Run("bom", func(t *testing.T) {
+		input := "\xef\xbb\xbfpackage main\n"
+		result := []token.Token{
+			{Kind: token.KWPackage, Value: "package", Line: 1, Column: 1, Offset: 3},
+			{Kind: token.Ident, Value: "main", Line: 1, Column: 9, Offset: 11},
+			{Kind: token.EOF, Value: "", Line: 2, Column: 1, Offset: 16},
+		}
+		lex := New([]byte(input))
+		assert.Nil(lex.Tokenize(context.Background()))
+		assert.Equal(result, lex.Tokens)
+		assert.Equal(0, len(lex.Errors))
+
+		stream := NewReader(iotest.OneByteReader(strings.NewReader(input)))
+		assert.Equal(result[0], stream.Next())
+	})
+
+	t.Run("bom_not_leading", func(t *testing.T) {
+		lex := New([]byte("a \xef\xbb\xbf"))
+		assert.Nil(lex.Tokenize(context.Background()))
+		assert.Equal(token.Illegal, lex.Tokens[1].Kind)
+		assert.Equal(3, len(lex.Errors))
+	})
+
+	t.Run("tab_width", func(t *testing.T) {
+		input := "\tvar a\n  \tb\n"
+		lex := New([]byte(input))
+		lex.TabWidth = 4
+		assert.Nil(lex.Tokenize(context.Background()))
+		assert.Equal(5, lex.Tokens[0].Column)
+		assert.Equal(1, lex.Tokens[0].Offset)
+		assert.Equal(9, lex.Tokens[1].Column)
+		assert.Equal(5, lex.Tokens[1].Offset)
+		assert.Equal(5, lex.Tokens[2].Column)
+		assert.Equal(10, lex.Tokens[2].Offset)
+
+		lex = New([]byte(input))
+		assert.Nil(lex.Tokenize(context.Background()))
+		assert.Equal(2, lex.Tokens[0].Column)
+		assert.Equal(6, lex.Tokens[1].Column)
+		assert.Equal(4, lex.Tokens[2].Column)
+	})
+
+	t.Run("utf8_columns", func(t *testing.T) {
+		lex := New([]byte(`"héllo" a`))
+		assert.Nil(lex.Tokenize(context.Background()))
+		assert.Equal(9, lex.Tokens[1].Column)
+		assert.Equal(9, lex.Tokens[1].Offset)
+	})
+
+	t.Run("tab_width_config", func(t *testing.T) {
+		lex, err := NewLexer(Config{StringOnly: true, TabWidth: 8})
+		assert.Nil(err)
+		assert.Equal(9, lex.FetchTokensFromString("\ta")[0].Column)
+	})
+
 	t.Run("vars", func(t *testing.T) {
 		input := `package main
 
@@ -312,7 +432,7 @@ func main() {
 			{Kind: token.EOF, Value: ""},
 		}
 		lex := New([]byte(input))
-		lex.Tokenize()
+		assert.Nil(lex.Tokenize(context.Background()))
 		for i, r := range result {
 			assert.Equal(r.Kind, lex.Tokens[i].Kind, r.Value)
 			assert.Equal(r.Value, lex.Tokens[i].Value)
@@ -412,7 +532,7 @@ func main() {
 			{Kind: token.EOF, Value: ""},
 		}
 		lex := New([]byte(input))
-		lex.Tokenize()
+		assert.Nil(lex.Tokenize(context.Background()))
 		for i, r := range result {
 			assert.Equal(r.Kind, lex.Tokens[i].Kind)
 			assert.Equal(r.Value, lex.Tokens[i].Value)
@@ -537,7 +657,7 @@ func main() {
 			{Kind: token.EOF, Value: ""},
 		}
 		lex := New([]byte(input))
-		lex.Tokenize()
+		assert.Nil(lex.Tokenize(context.Background()))
 		for i, r := range result {
 			assert.Equal(r.Kind, lex.Tokens[i].Kind)
 			assert.Equal(r.Value, lex.Tokens[i].Value)
@@ -620,13 +740,32 @@ func main() {
 			{Kind: token.RBrace, Value: "}"},
 			{Kind: token.EOF, Value: ""},
 		}
+		errs := []error{
+			&Error{Err: ErrMultipleDots, Line: 4, Column: 21, Value: "3.141.592_653_59"},
+			&Error{Err: ErrMultipleDots, Line: 5, Column: 21, Value: "3.141."},
+			&Error{Err: ErrMisplacedDigitSeparator, Line: 6, Column: 21, Value: "3.141_"},
+			&Error{Err: ErrMisplacedDigitSeparator, Line: 7, Column: 21, Value: "_.14"},
+			&Error{Err: ErrMisplacedDigitSeparator, Line: 8, Column: 19, Value: "3._14"},
+			&Error{Err: ErrMisplacedDigitSeparator, Line: 9, Column: 21, Value: "3_.14"},
+			&Error{Err: ErrMisplacedDigitSeparator, Line: 10, Column: 21, Value: "3__141"},
+			&Error{Err: ErrMisplacedDigitSeparator, Line: 11, Column: 21, Value: "_3_141"},
+		}
 		lex := New([]byte(input))
-		lex.Tokenize()
+		assert.Nil(lex.Tokenize(context.Background()))
 		for i, r := range result {
 			assert.Equal(r.Kind, lex.Tokens[i].Kind, i)
 			assert.Equal(r.Value, lex.Tokens[i].Value, i)
 		}
 		assert.Equal(len(result), len(lex.Tokens))
+		assert.Equal(errs, lex.Errors)
+	})
+
+	t.Run("illegal_number_trailing_dot", func(t *testing.T) {
+		lex := New([]byte("3."))
+		assert.Nil(lex.Tokenize(context.Background()))
+		assert.Equal(1, len(lex.Errors))
+		assert.ErrorIs(lex.Errors[0], ErrMalformedNumber)
+		assert.Equal(`1:1: malformed number, got "3."`, lex.Errors[0].Error())
 	})
 
 	t.Run("illegal_string", func(t *testing.T) {
@@ -655,12 +794,15 @@ func main() {
 			{Kind: token.EOF, Value: ""},
 		}
 		lex := New([]byte(input))
-		lex.Tokenize()
+		assert.Nil(lex.Tokenize(context.Background()))
 		for i, r := range result {
 			assert.Equal(r.Kind, lex.Tokens[i].Kind, i)
 			assert.Equal(r.Value, lex.Tokens[i].Value)
 		}
 		assert.Equal(len(result), len(lex.Tokens))
+		assert.Equal(1, len(lex.Errors))
+		assert.ErrorIs(lex.Errors[0], ErrUnterminatedString)
+		assert.Equal(`4:18: unterminated string, got "\"test"`, lex.Errors[0].Error())
 	})
 
 	t.Run("illegal_characters", func(t *testing.T) {
@@ -697,7 +839,7 @@ func main() {
 			{Kind: token.KWInt, Value: "int"},
 			{Kind: token.Assign, Value: "="},
 			{Kind: token.IntLit, Value: "1"},
-			{Kind: token.Illegal, Value: "|"},
+			{Kind: token.Pipe, Value: "|"},
 			{Kind: token.IntLit, Value: "1"},
 
 			{Kind: token.Ident, Value: "_"},
@@ -713,13 +855,19 @@ func main() {
 			{Kind: token.RBrace, Value: "}"},
 			{Kind: token.EOF, Value: ""},
 		}
+		errs := []error{
+			&Error{Err: ErrUnexpectedCharacter, Line: 4, Column: 17, Value: "&"},
+			&Error{Err: ErrInvalidIdent, Line: 7, Column: 2, Value: "_c"},
+			&Error{Err: ErrUnexpectedCharacter, Line: 8, Column: 2, Value: "#"},
+		}
 		lex := New([]byte(input))
-		lex.Tokenize()
+		assert.Nil(lex.Tokenize(context.Background()))
 		for i, r := range result {
 			assert.Equal(r.Kind, lex.Tokens[i].Kind, i)
 			assert.Equal(r.Value, lex.Tokens[i].Value)
 		}
 		assert.Equal(len(result), len(lex.Tokens))
+		assert.Equal(errs, lex.Errors)
 	})
 
 	t.Run("illegal_multiline_comment", func(t *testing.T) {
@@ -749,12 +897,15 @@ func main() {
 			{Kind: token.EOF, Value: ""},
 		}
 		lex := New([]byte(input))
-		lex.Tokenize()
+		assert.Nil(lex.Tokenize(context.Background()))
 		for i, r := range result {
 			assert.Equal(r.Kind, lex.Tokens[i].Kind, i)
 			assert.Equal(r.Value, lex.Tokens[i].Value)
 		}
 		assert.Equal(len(result), len(lex.Tokens))
+		assert.Equal(1, len(lex.Errors))
+		assert.ErrorIs(lex.Errors[0], ErrUnterminatedBlockComment)
+		assert.Equal(`4:3: unterminated block comment, got "/*"`, lex.Errors[0].Error())
 	})
 
 	t.Run("comment", func(t *testing.T) {
@@ -812,7 +963,39 @@ func main() {
 			{Kind: token.EOF, Value: ""},
 		}
 		lex := New([]byte(input))
-		lex.Tokenize()
+		assert.Nil(lex.Tokenize(context.Background()))
+		for i, r := range result {
+			assert.Equal(r.Kind, lex.Tokens[i].Kind, i)
+			assert.Equal(r.Value, lex.Tokens[i].Value, i)
+		}
+		assert.Equal(len(result), len(lex.Tokens))
+	})
+
+	t.Run("doc_comment_directive", func(t *testing.T) {
+		input := `//ori:build linux && amd64
+package main
+
+/// A is documented
+//// not a doc comment
+//ori:generate gori lex
+const a int = 1
+`
+		result := []token.Token{
+			{Kind: token.Directive, Value: "//ori:build linux && amd64"},
+			{Kind: token.KWPackage, Value: "package"},
+			{Kind: token.Ident, Value: "main"},
+			{Kind: token.DocComment, Value: "/// A is documented"},
+			{Kind: token.Comment, Value: "//// not a doc comment"},
+			{Kind: token.Directive, Value: "//ori:generate gori lex"},
+			{Kind: token.KWConst, Value: "const"},
+			{Kind: token.Ident, Value: "a"},
+			{Kind: token.KWInt, Value: "int"},
+			{Kind: token.Assign, Value: "="},
+			{Kind: token.IntLit, Value: "1"},
+			{Kind: token.EOF, Value: ""},
+		}
+		lex := New([]byte(input))
+		assert.Nil(lex.Tokenize(context.Background()))
 		for i, r := range result {
 			assert.Equal(r.Kind, lex.Tokens[i].Kind, i)
 			assert.Equal(r.Value, lex.Tokens[i].Value, i)
@@ -869,7 +1052,130 @@ func main() {
 			{Kind: token.EOF, Value: ""},
 		}
 		lex := New([]byte(input))
-		lex.Tokenize()
+		assert.Nil(lex.Tokenize(context.Background()))
+		for i, r := range result {
+			assert.Equal(r.Kind, lex.Tokens[i].Kind)
+			assert.Equal(r.Value, lex.Tokens[i].Value)
+		}
+		assert.Equal(len(result), len(lex.Tokens))
+	})
+
+	t.Run("next_from_reader", func(t *testing.T) {
+		input := `package main
+
+func main() {
+  var s string = "a b c"
+  /* multi
+  line */
+  var f float64 = 1_000.5 // comment
+}
+`
+		lex := New([]byte(input))
+		assert.Nil(lex.Tokenize(context.Background()))
+
+		stream := NewReader(iotest.OneByteReader(strings.NewReader(input)))
+		var result []token.Token
+		for {
+			tok := stream.Next()
+			result = append(result, tok)
+			if tok.Kind == token.EOF {
+				break
+			}
+		}
+		assert.Equal(lex.Tokens, result)
+		assert.Equal(token.EOF, stream.Next().Kind)
+	})
+
+	t.Run("next_from_reader_bounded_buffer", func(t *testing.T) {
+		var b strings.Builder
+		b.WriteString("package main\n")
+		for range 2000 {
+			b.WriteString("const a int = 1\n")
+		}
+
+		stream := NewReader(strings.NewReader(b.String()))
+		for stream.Next().Kind != token.EOF {
+			assert.LessOrEqual(len(stream.input), 2*readSize)
+		}
+	})
+
+	t.Run("tokenize_cancelled", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+		lex := New([]byte("package main"))
+		assert.ErrorIs(lex.Tokenize(ctx), context.Canceled)
+		assert.Equal(0, len(lex.Tokens))
+	})
+
+	t.Run("match", func(t *testing.T) {
+		input := `match s {
+case Circle(r) if r > 0:
+case _:
+}`
+		result := []token.Token{
+			{Kind: token.KWMatch, Value: "match"},
+			{Kind: token.Ident, Value: "s"},
+			{Kind: token.LBrace, Value: "{"},
+			{Kind: token.KWCase, Value: "case"},
+			{Kind: token.Ident, Value: "Circle"},
+			{Kind: token.LParen, Value: "("},
+			{Kind: token.Ident, Value: "r"},
+			{Kind: token.RParen, Value: ")"},
+			{Kind: token.KWIf, Value: "if"},
+			{Kind: token.Ident, Value: "r"},
+			{Kind: token.Gt, Value: ">"},
+			{Kind: token.IntLit, Value: "0"},
+			{Kind: token.Colon, Value: ":"},
+			{Kind: token.KWCase, Value: "case"},
+			{Kind: token.Ident, Value: "_"},
+			{Kind: token.Colon, Value: ":"},
+			{Kind: token.RBrace, Value: "}"},
+			{Kind: token.EOF, Value: ""},
+		}
+		lex := New([]byte(input))
+		assert.Nil(lex.Tokenize(context.Background()))
+		for i, r := range result {
+			assert.Equal(r.Kind, lex.Tokens[i].Kind)
+			assert.Equal(r.Value, lex.Tokens[i].Value)
+		}
+		assert.Equal(len(result), len(lex.Tokens))
+	})
+
+	t.Run("defer", func(t *testing.T) {
+		input := `defer f.Close()`
+		result := []token.Token{
+			{Kind: token.KWDefer, Value: "defer"},
+			{Kind: token.Ident, Value: "f"},
+			{Kind: token.Dot, Value: "."},
+			{Kind: token.Ident, Value: "Close"},
+			{Kind: token.LParen, Value: "("},
+			{Kind: token.RParen, Value: ")"},
+			{Kind: token.EOF, Value: ""},
+		}
+		lex := New([]byte(input))
+		assert.Nil(lex.Tokenize(context.Background()))
+		for i, r := range result {
+			assert.Equal(r.Kind, lex.Tokens[i].Kind)
+			assert.Equal(r.Value, lex.Tokens[i].Value)
+		}
+		assert.Equal(len(result), len(lex.Tokens))
+	})
+
+	t.Run("ellipsis", func(t *testing.T) {
+		input := `f(xs...) .. .`
+		result := []token.Token{
+			{Kind: token.Ident, Value: "f"},
+			{Kind: token.LParen, Value: "("},
+			{Kind: token.Ident, Value: "xs"},
+			{Kind: token.Ellipsis, Value: "..."},
+			{Kind: token.RParen, Value: ")"},
+			{Kind: token.Dot, Value: "."},
+			{Kind: token.Dot, Value: "."},
+			{Kind: token.Dot, Value: "."},
+			{Kind: token.EOF, Value: ""},
+		}
+		lex := New([]byte(input))
+		assert.Nil(lex.Tokenize(context.Background()))
 		for i, r := range result {
 			assert.Equal(r.Kind, lex.Tokens[i].Kind)
 			assert.Equal(r.Value, lex.Tokens[i].Value)
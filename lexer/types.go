@@ -1,6 +1,8 @@
 package lexer
 
 import (
+	"io"
+
 	"github.com/orilang/gori/token"
 )
 
@@ -17,6 +19,17 @@ type Config struct {
 
 	// Output when set to true outputs the result
 	Output bool
+
+	// Jobs is the number of files processed in parallel.
+	// When lower than 1, the number of CPUs is used
+	Jobs int
+
+	// TabWidth is the number of columns a tab advances to.
+	// When lower than 2, a tab counts as a single column
+	TabWidth int
+
+	// Tags are the build tags used to evaluate //ori:build constraints
+	Tags []string
 }
 
 // LexerFiles holds all files to use for tokenization
@@ -26,14 +39,66 @@ type Files struct {
 
 	// output when set to true outputs the result
 	output bool
+
+	// jobs is the number of files processed in parallel
+	jobs int
+
+	// tabWidth is the number of columns a tab advances to
+	tabWidth int
+}
+
+// result holds the outcome of a lexed file
+type result struct {
+	// output holds the printed tokens
+	output []byte
+
+	// errors holds the lexing errors
+	errors []error
 }
 
 // Lexer holds requirements to parse tokens
 type Lexer struct {
-	Tokens   []token.Token
+	Tokens []token.Token
+	Errors []error
+
+	// TabWidth is the number of columns a tab advances to.
+	// When lower than 2, a tab counts as a single column
+	TabWidth int
+
 	input    []byte
+	reader   io.Reader
+	pending  []token.Token
 	position int
+	offset   int
+	start    int
 	line     int
 	column   int
 	size     int
 }
+
+const (
+	// readSize is the chunk size used when reading from an io.Reader
+	readSize = 4096
+
+	// checkInterval is the number of tokens produced between
+	// two context cancellation checks
+	checkInterval = 1024
+)
+
+// bom is the UTF-8 byte order mark skipped at the start of the input
+var bom = []byte{0xef, 0xbb, 0xbf}
+
+// Error holds a lexing failure with its position
+type Error struct {
+	// Err is the reason of the failure like ErrUnterminatedString
+	Err error
+
+	// Line of the illegal token
+	Line int
+
+	// Column of the illegal token
+	Column int
+
+	// Value is the raw text of the illegal token
+	Value string
+}
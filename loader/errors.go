@@ -0,0 +1,25 @@
+package loader
+
+import (
+	"errors"
+	"fmt"
+)
+
+var (
+	ErrNoManifest      = errors.New("no .mod manifest found")
+	ErrManyManifests   = errors.New("multiple .mod manifests found")
+	ErrPackageMismatch = errors.New("package name mismatch")
+	ErrInvalidImport   = errors.New("invalid import path")
+	ErrImportNotFound  = errors.New("imported package not found")
+	ErrImportCycle     = errors.New("import cycle not allowed")
+)
+
+// Error returns the reason with the position of the failure
+func (e *Error) Error() string {
+	return fmt.Sprintf("%d:%d: %v, got %q", e.Token.Line, e.Token.Column, e.Err, e.Token.Value)
+}
+
+// Unwrap returns the reason of the failure
+func (e *Error) Unwrap() error {
+	return e.Err
+}
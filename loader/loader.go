@@ -0,0 +1,257 @@
+package loader
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"maps"
+	"os"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/orilang/gori/lexer"
+	"github.com/orilang/gori/modfile"
+	"github.com/orilang/gori/parser"
+	"github.com/orilang/gori/pool"
+	"github.com/orilang/gori/walk"
+)
+
+// Load parses the files of the module located in config.Directory,
+// groups them by directory into packages, resolves their imports
+// relative to the module root and returns the packages in dependency order.
+// Directories holding their own .mod manifest are other modules and are skipped.
+// Imports of required modules are not loaded.
+// Lexing, parsing and loading errors of all files are returned joined together
+func Load(ctx context.Context, config Config) (*Program, error) {
+	if config.Directory == "" {
+		return nil, walk.ErrNoFileOrDirectoryPassed
+	}
+
+	w, err := walk.Walk(walk.Config{Directory: config.Directory, Tags: config.Tags})
+	if err != nil {
+		return nil, err
+	}
+
+	l := &loader{
+		config:   config,
+		root:     filepath.Clean(config.Directory),
+		packages: make(map[string]*Package),
+		edges:    make(map[*Package][]edge),
+		visited:  make(map[*Package]state),
+	}
+	files, err := l.readManifest(w.Files)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := l.group(ctx, files); err != nil {
+		return nil, err
+	}
+	if len(l.errors) > 0 {
+		return nil, errors.Join(l.errors...)
+	}
+
+	l.resolve()
+	for _, path := range slices.Sorted(maps.Keys(l.packages)) {
+		l.sort(l.packages[path])
+	}
+	if len(l.errors) > 0 {
+		return nil, errors.Join(l.errors...)
+	}
+
+	return &Program{Manifest: l.manifest, Packages: l.order}, nil
+}
+
+// readManifest parses the .mod manifest of the module root
+// and returns the .ori files belonging to the module
+func (l *loader) readManifest(files []string) ([]string, error) {
+	var manifests, nested []string
+	for _, file := range files {
+		if filepath.Ext(file) != ".mod" {
+			continue
+		}
+		if filepath.Dir(file) == l.root {
+			manifests = append(manifests, file)
+		} else {
+			nested = append(nested, filepath.Dir(file))
+		}
+	}
+
+	switch len(manifests) {
+	case 0:
+		return nil, fmt.Errorf("%w in %s", ErrNoManifest, l.root)
+	case 1:
+	default:
+		return nil, fmt.Errorf("%w: %s", ErrManyManifests, strings.Join(manifests, ", "))
+	}
+
+	src, err := os.ReadFile(manifests[0])
+	if err != nil {
+		return nil, err
+	}
+	manifest, errs := modfile.Parse(src)
+	if len(errs) > 0 {
+		return nil, errors.Join(lexer.FileErrors(manifests[0], errs)...)
+	}
+	l.manifest = manifest
+
+	var result []string
+	for _, file := range files {
+		if filepath.Ext(file) == ".ori" && !slices.ContainsFunc(nested, func(dir string) bool { return within(file, dir) }) {
+			result = append(result, file)
+		}
+	}
+	return result, nil
+}
+
+// group parses the files in parallel and adds them
+// to the package of their directory
+func (l *loader) group(ctx context.Context, files []string) error {
+	return pool.Run(ctx, l.config.Jobs, len(files),
+		func(ctx context.Context, i int) (parsed, error) {
+			return l.parseFile(ctx, files[i])
+		},
+		func(i int, r parsed) error {
+			file := files[i]
+			l.errors = append(l.errors, lexer.FileErrors(file, r.errors)...)
+
+			dir := filepath.Dir(file)
+			path := l.importPath(dir)
+			pkg, ok := l.packages[path]
+			if !ok {
+				pkg = &Package{Path: path, Name: r.tree.Name.Value, Dir: dir}
+				l.packages[path] = pkg
+			}
+
+			if r.tree.Name.Value != pkg.Name {
+				l.errorf(file, &Error{Err: fmt.Errorf("%w: expected %s", ErrPackageMismatch, pkg.Name), Token: r.tree.Name})
+			}
+			pkg.Files = append(pkg.Files, file)
+			pkg.Syntax = append(pkg.Syntax, r.tree)
+			return nil
+		},
+	)
+}
+
+// parseFile streams the tokens of the provided file to the parser
+// and returns the tree with the lexing and parsing errors found
+func (l *loader) parseFile(ctx context.Context, file string) (parsed, error) {
+	src, err := os.ReadFile(file)
+	if err != nil {
+		return parsed{}, err
+	}
+
+	lex := lexer.NewReader(bytes.NewReader(src))
+	lex.TabWidth = l.config.TabWidth
+	p := parser.NewStream(lex)
+	tree, err := p.ParseFile(ctx)
+	if err != nil {
+		return parsed{}, err
+	}
+
+	return parsed{tree: tree, errors: append(slices.Clip(lex.Errors), p.Errors()...)}, nil
+}
+
+// importPath returns the import path of the package located in dir
+func (l *loader) importPath(dir string) string {
+	rel, err := filepath.Rel(l.root, dir)
+	if err != nil || rel == "." {
+		return l.manifest.Module.Path.Value
+	}
+	return l.manifest.Module.Path.Value + "/" + filepath.ToSlash(rel)
+}
+
+// resolve links each package to the packages of the module it imports
+func (l *loader) resolve() {
+	module := l.manifest.Module.Path.Value
+	required := make(map[string]bool)
+	for _, r := range l.manifest.Require {
+		required[r.Path.Value] = true
+	}
+
+	for _, path := range slices.Sorted(maps.Keys(l.packages)) {
+		pkg := l.packages[path]
+		for i, tree := range pkg.Syntax {
+			for _, decl := range tree.Imports {
+				for _, spec := range decl.Specs {
+					value, err := strconv.Unquote(spec.Path.Value)
+					if err != nil || value == "" {
+						l.errorf(pkg.Files[i], &Error{Err: ErrInvalidImport, Token: spec.Path})
+						continue
+					}
+
+					if to, ok := l.packages[value]; ok {
+						if !slices.Contains(pkg.Imports, to) {
+							pkg.Imports = append(pkg.Imports, to)
+						}
+						l.edges[pkg] = append(l.edges[pkg], edge{to: to, file: pkg.Files[i], path: spec.Path})
+						continue
+					}
+
+					if value != module && !strings.HasPrefix(value, module+"/") && requiredBy(required, value) {
+						continue
+					}
+					l.errorf(pkg.Files[i], &Error{Err: ErrImportNotFound, Token: spec.Path})
+				}
+			}
+		}
+	}
+}
+
+// sort appends pkg to the order after the packages it imports
+// and reports the import cycles found
+func (l *loader) sort(pkg *Package) {
+	switch l.visited[pkg] {
+	case visiting, sorted:
+		return
+	}
+
+	l.visited[pkg] = visiting
+	l.stack = append(l.stack, pkg)
+	for _, e := range l.edges[pkg] {
+		if l.visited[e.to] == visiting {
+			l.errorf(e.file, &Error{Err: fmt.Errorf("%w: %s", ErrImportCycle, l.cycle(e.to)), Token: e.path})
+			continue
+		}
+		l.sort(e.to)
+	}
+	l.stack = l.stack[:len(l.stack)-1]
+
+	l.visited[pkg] = sorted
+	l.order = append(l.order, pkg)
+}
+
+// cycle returns the import paths of the stack from pkg
+// back to pkg like a -> b -> a
+func (l *loader) cycle(pkg *Package) string {
+	start := slices.Index(l.stack, pkg)
+	var paths []string
+	for _, p := range l.stack[start:] {
+		paths = append(paths, p.Path)
+	}
+	return strings.Join(append(paths, pkg.Path), " -> ")
+}
+
+// errorf records err prefixed with the file it comes from
+func (l *loader) errorf(file string, err error) {
+	l.errors = append(l.errors, lexer.FileErrors(file, []error{err})...)
+}
+
+// requiredBy returns true when path belongs to a required module
+func requiredBy(required map[string]bool, path string) bool {
+	for module := range required {
+		if path == module || strings.HasPrefix(path, module+"/") {
+			return true
+		}
+	}
+	return false
+}
+
+// within returns true when file is located in dir or its subdirectories
+func within(file, dir string) bool {
+	rel, err := filepath.Rel(dir, file)
+	return err == nil && rel != ".." && !strings.HasPrefix(rel, ".."+string(filepath.Separator))
+}
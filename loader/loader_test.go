@@ -0,0 +1,108 @@
+package loader
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/orilang/gori/walk"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLoader(t *testing.T) {
+	assert := assert.New(t)
+
+	t.Run("success", func(t *testing.T) {
+		dir := "../testdata/loader/app"
+		program, err := Load(context.Background(), Config{Directory: dir, Jobs: 2})
+		if !assert.Nil(err) {
+			return
+		}
+
+		assert.Equal("github.com/orilang/app", program.Manifest.Module.Path.Value)
+		var paths []string
+		for _, pkg := range program.Packages {
+			paths = append(paths, pkg.Path)
+		}
+		assert.Equal([]string{"github.com/orilang/app/util", "github.com/orilang/app/lib", "github.com/orilang/app"}, paths)
+
+		util, lib, main := program.Packages[0], program.Packages[1], program.Packages[2]
+		assert.Equal("main", main.Name)
+		assert.Equal(dir, main.Dir)
+		assert.Equal([]*Package{util, lib}, main.Imports)
+		assert.Equal("lib", lib.Name)
+		assert.Equal([]string{filepath.Join(dir, "lib/extra.ori"), filepath.Join(dir, "lib/lib.ori")}, lib.Files)
+		assert.Len(lib.Syntax, 2)
+		assert.Equal([]*Package{util}, lib.Imports)
+		assert.Empty(util.Imports)
+	})
+
+	t.Run("cycle", func(t *testing.T) {
+		dir := "../testdata/loader/cycle"
+		_, err := Load(context.Background(), Config{Directory: dir})
+		assert.ErrorIs(err, ErrImportCycle)
+		assert.Equal(filepath.Join(dir, "b/b.ori")+`:3:8: import cycle not allowed: github.com/orilang/cycle/a -> github.com/orilang/cycle/b -> github.com/orilang/cycle/a, got "\"github.com/orilang/cycle/a\""`, err.Error())
+	})
+
+	t.Run("invalid", func(t *testing.T) {
+		dir := "../testdata/loader/invalid"
+		_, err := Load(context.Background(), Config{Directory: dir})
+		assert.ErrorIs(err, ErrPackageMismatch)
+		assert.Equal(filepath.Join(dir, "lib/other.ori")+`:1:9: package name mismatch: expected lib, got "other"`, err.Error())
+	})
+
+	t.Run("import_not_found", func(t *testing.T) {
+		dir := t.TempDir()
+		assert.Nil(os.WriteFile(filepath.Join(dir, "ori.mod"), []byte("module github.com/orilang/app\n"), 0o600))
+		assert.Nil(os.WriteFile(filepath.Join(dir, "main.ori"), []byte("package main\n\nimport (\n  \"github.com/orilang/app/util\"\n  \"github.com/orilang/json\"\n  \"\"\n)\n"), 0o600))
+
+		_, err := Load(context.Background(), Config{Directory: dir})
+		assert.ErrorIs(err, ErrImportNotFound)
+		assert.ErrorIs(err, ErrInvalidImport)
+		file := filepath.Join(dir, "main.ori")
+		assert.Equal(file+`:4:3: imported package not found, got "\"github.com/orilang/app/util\""`+"\n"+
+			file+`:5:3: imported package not found, got "\"github.com/orilang/json\""`+"\n"+
+			file+`:6:3: invalid import path, got "\"\""`, err.Error())
+	})
+
+	t.Run("parsing_errors", func(t *testing.T) {
+		dir := t.TempDir()
+		assert.Nil(os.WriteFile(filepath.Join(dir, "ori.mod"), []byte("module app\n"), 0o600))
+		assert.Nil(os.WriteFile(filepath.Join(dir, "main.ori"), []byte("package main\n\nimport 12\n"), 0o600))
+
+		_, err := Load(context.Background(), Config{Directory: dir})
+		assert.Equal(filepath.Join(dir, "main.ori")+`:3:8 expected import path (got 4 "12")`, err.Error())
+	})
+
+	t.Run("invalid_manifest", func(t *testing.T) {
+		_, err := Load(context.Background(), Config{Directory: "../testdata/module_invalid"})
+		assert.Error(err)
+	})
+
+	t.Run("no_manifest", func(t *testing.T) {
+		_, err := Load(context.Background(), Config{Directory: "../testdata/loader/none"})
+		assert.ErrorIs(err, ErrNoManifest)
+	})
+
+	t.Run("many_manifests", func(t *testing.T) {
+		dir := t.TempDir()
+		assert.Nil(os.WriteFile(filepath.Join(dir, "a.mod"), []byte("module a\n"), 0o600))
+		assert.Nil(os.WriteFile(filepath.Join(dir, "b.mod"), []byte("module b\n"), 0o600))
+
+		_, err := Load(context.Background(), Config{Directory: dir})
+		assert.ErrorIs(err, ErrManyManifests)
+	})
+
+	t.Run("no_directory", func(t *testing.T) {
+		_, err := Load(context.Background(), Config{})
+		assert.ErrorIs(err, walk.ErrNoFileOrDirectoryPassed)
+	})
+
+	t.Run("cancelled", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+		_, err := Load(ctx, Config{Directory: "../testdata/loader/app"})
+		assert.ErrorIs(err, context.Canceled)
+	})
+}
@@ -0,0 +1,130 @@
+package loader
+
+import (
+	"github.com/orilang/gori/ast"
+	"github.com/orilang/gori/modfile"
+	"github.com/orilang/gori/token"
+)
+
+// Config holds the module to load
+type Config struct {
+	// Directory is the module root holding the .mod manifest
+	Directory string
+
+	// Jobs is the number of files parsed in parallel.
+	// When lower than 1, the number of CPUs is used
+	Jobs int
+
+	// TabWidth is the number of columns a tab advances to.
+	// When lower than 2, a tab counts as a single column
+	TabWidth int
+
+	// Tags are the build tags used to evaluate //ori:build constraints
+	Tags []string
+}
+
+// Program holds the packages of a module
+type Program struct {
+	// Manifest is the module manifest
+	Manifest *modfile.File
+
+	// Packages holds the packages in dependency order,
+	// a package always comes after the packages it imports
+	Packages []*Package
+}
+
+// Package holds the files of a directory sharing the same package name
+type Package struct {
+	// Path is the import path of the package
+	Path string
+
+	// Name is the package name of its files
+	Name string
+
+	// Dir is the directory of the package
+	Dir string
+
+	// Files holds the file names of the package
+	Files []string
+
+	// Syntax holds the parsed files in Files order
+	Syntax []*ast.File
+
+	// Imports holds the packages of the module imported by the package
+	Imports []*Package
+}
+
+// Error holds a loading failure with its position
+type Error struct {
+	// Err is the reason of the failure like ErrImportCycle
+	Err error
+
+	// Token is where the failure happened
+	Token token.Token
+}
+
+// parsed holds the outcome of a parsed file
+type parsed struct {
+	// tree is the parsed file
+	tree *ast.File
+
+	// errors holds the lexing and parsing errors
+	errors []error
+}
+
+// edge holds an import of a package of the module
+type edge struct {
+	// to is the imported package
+	to *Package
+
+	// file is where the import is declared
+	file string
+
+	// path is the import path token
+	path token.Token
+}
+
+// loader holds requirements to group files into packages
+// and sort them in dependency order
+type loader struct {
+	// config used to load the module
+	config Config
+
+	// root is the module root directory
+	root string
+
+	// manifest is the module manifest
+	manifest *modfile.File
+
+	// packages holds the packages by import path
+	packages map[string]*Package
+
+	// edges holds the imports of each package
+	edges map[*Package][]edge
+
+	// visited holds the sorting state of each package
+	visited map[*Package]state
+
+	// stack holds the packages being sorted
+	stack []*Package
+
+	// order holds the packages in dependency order
+	order []*Package
+
+	// errors found while loading
+	errors []error
+}
+
+// state is the sorting state of a package
+type state int
+
+const (
+	// unvisited package
+	unvisited state = iota
+
+	// visiting package whose imports are being sorted
+	visiting
+
+	// sorted package
+	sorted
+)
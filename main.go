@@ -11,7 +11,7 @@ import (
 
 func main() {
 	usage := "A new cli for Ori purposes"
-	description := "Gori is Ori lexer and parser"
+	description := "Gori is Ori lexer, parser and checker"
 
 	cmd := cli.Command{
 		Name:                  "gori",
@@ -21,6 +21,7 @@ func main() {
 		Commands: []*cli.Command{
 			commands.Lexer(),
 			commands.Parse(),
+			commands.Check(),
 		},
 	}
 
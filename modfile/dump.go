@@ -0,0 +1,39 @@
+package modfile
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Dump returns a readable representation of the module manifest
+func Dump(f *File) string {
+	var b strings.Builder
+	b.WriteString("ModFile\n")
+	if f.Module != nil {
+		word(&b, 1, "Module", f.Module.Path)
+	}
+	if f.Ori != nil {
+		word(&b, 1, "Ori", f.Ori.Version)
+	}
+
+	if len(f.Require) > 0 {
+		b.WriteString(" Require\n")
+		for _, r := range f.Require {
+			word(&b, 2, "Path", r.Path)
+		}
+	}
+
+	if len(f.Replace) > 0 {
+		b.WriteString(" Replace\n")
+		for _, r := range f.Replace {
+			word(&b, 2, "Old", r.Old)
+			word(&b, 2, "New", r.New)
+		}
+	}
+	return b.String()
+}
+
+// word writes the key with the value and position of w
+func word(b *strings.Builder, indent int, key string, w Word) {
+	fmt.Fprintf(b, "%s%s: %q @%d:%d\n", strings.Repeat(" ", indent), key, w.Value, w.Line, w.Column)
+}
@@ -0,0 +1,30 @@
+package modfile
+
+import (
+	"errors"
+	"fmt"
+)
+
+var (
+	ErrUnknownDirective   = errors.New("unknown directive")
+	ErrMalformedDirective = errors.New("malformed directive")
+	ErrDuplicateDirective = errors.New("duplicate directive")
+	ErrMissingModule      = errors.New("missing module directive")
+	ErrInvalidModulePath  = errors.New("invalid module path")
+	ErrInvalidVersion     = errors.New("invalid ori version")
+	ErrDuplicateRequire   = errors.New("module already required")
+	ErrDuplicateReplace   = errors.New("module already replaced")
+	ErrNotLocal           = errors.New("replacement is not a local path")
+	ErrNotRequired        = errors.New("replaced module is not required")
+	ErrUnterminatedBlock  = errors.New("unterminated directive block")
+)
+
+// Error returns the reason with the position of the failure
+func (e *Error) Error() string {
+	return fmt.Sprintf("%d:%d: %v, got %q", e.Word.Line, e.Word.Column, e.Err, e.Word.Value)
+}
+
+// Unwrap returns the reason of the failure
+func (e *Error) Unwrap() error {
+	return e.Err
+}
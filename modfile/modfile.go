@@ -0,0 +1,210 @@
+package modfile
+
+import (
+	"bytes"
+	"path/filepath"
+	"strings"
+)
+
+// bom is the UTF-8 byte order mark skipped at the start of the input
+var bom = []byte{0xef, 0xbb, 0xbf}
+
+// Parse returns the module manifest held by data
+// with the syntax and validation errors found
+func Parse(data []byte) (*File, []error) {
+	p := &parser{file: &File{}}
+	data = bytes.TrimPrefix(data, bom)
+	for i, line := range strings.Split(string(data), "\n") {
+		if words := split(line, i+1); len(words) > 0 {
+			p.line(words)
+		}
+	}
+	if p.block.Value != "" {
+		p.errorf(ErrUnterminatedBlock, p.block)
+	}
+
+	p.validate()
+	return p.file, p.errors
+}
+
+// split returns the words of the line without its comment.
+// A comment starts with // at the beginning of a word
+func split(line string, number int) []Word {
+	line = strings.TrimSuffix(line, "\r")
+
+	var result []Word
+	for i := 0; i < len(line); {
+		if line[i] == ' ' || line[i] == '\t' {
+			i++
+			continue
+		}
+		if strings.HasPrefix(line[i:], "//") {
+			break
+		}
+
+		start := i
+		for i < len(line) && line[i] != ' ' && line[i] != '\t' {
+			i++
+		}
+		result = append(result, Word{Value: line[start:i], Line: number, Column: start + 1})
+	}
+	return result
+}
+
+// errorf records err at the position of w
+func (p *parser) errorf(err error, w Word) {
+	p.errors = append(p.errors, &Error{Err: err, Word: w})
+}
+
+// line parses the words of a line, opening or closing
+// require ( ... ) and replace ( ... ) blocks
+func (p *parser) line(words []Word) {
+	if p.block.Value != "" {
+		if len(words) == 1 && words[0].Value == ")" {
+			p.block = Word{}
+			return
+		}
+		p.directive(p.block, words)
+		return
+	}
+
+	kw, args := words[0], words[1:]
+	if (kw.Value == "require" || kw.Value == "replace") && len(args) == 1 && args[0].Value == "(" {
+		p.block = kw
+		return
+	}
+	p.directive(kw, args)
+}
+
+// directive adds the directive kw with its arguments to the file
+func (p *parser) directive(kw Word, args []Word) {
+	switch kw.Value {
+	case "module":
+		if len(args) != 1 {
+			p.errorf(ErrMalformedDirective, kw)
+			return
+		}
+		if p.file.Module != nil {
+			p.errorf(ErrDuplicateDirective, kw)
+			return
+		}
+		p.file.Module = &Module{Directive: kw, Path: args[0]}
+
+	case "ori":
+		if len(args) != 1 {
+			p.errorf(ErrMalformedDirective, kw)
+			return
+		}
+		if p.file.Ori != nil {
+			p.errorf(ErrDuplicateDirective, kw)
+			return
+		}
+		p.file.Ori = &Ori{Directive: kw, Version: args[0]}
+
+	case "require":
+		if len(args) != 1 {
+			p.errorf(ErrMalformedDirective, kw)
+			return
+		}
+		p.file.Require = append(p.file.Require, &Require{Directive: kw, Path: args[0]})
+
+	case "replace":
+		if len(args) != 3 || args[1].Value != "=>" {
+			p.errorf(ErrMalformedDirective, kw)
+			return
+		}
+		p.file.Replace = append(p.file.Replace, &Replace{Directive: kw, Old: args[0], Arrow: args[1], New: args[2]})
+
+	default:
+		p.errorf(ErrUnknownDirective, kw)
+	}
+}
+
+// validate reports the directives with invalid values
+func (p *parser) validate() {
+	f := p.file
+	if f.Module == nil {
+		p.errorf(ErrMissingModule, Word{Line: 1, Column: 1})
+	} else if !validPath(f.Module.Path.Value) {
+		p.errorf(ErrInvalidModulePath, f.Module.Path)
+	}
+
+	if f.Ori != nil && !validVersion(f.Ori.Version.Value) {
+		p.errorf(ErrInvalidVersion, f.Ori.Version)
+	}
+
+	required := make(map[string]bool)
+	for _, r := range f.Require {
+		switch {
+		case !validPath(r.Path.Value):
+			p.errorf(ErrInvalidModulePath, r.Path)
+		case required[r.Path.Value]:
+			p.errorf(ErrDuplicateRequire, r.Path)
+		}
+		required[r.Path.Value] = true
+	}
+
+	replaced := make(map[string]bool)
+	for _, r := range f.Replace {
+		switch {
+		case replaced[r.Old.Value]:
+			p.errorf(ErrDuplicateReplace, r.Old)
+		case !required[r.Old.Value]:
+			p.errorf(ErrNotRequired, r.Old)
+		case !isLocal(r.New.Value):
+			p.errorf(ErrNotLocal, r.New)
+		}
+		replaced[r.Old.Value] = true
+	}
+}
+
+// validPath returns true when path is made of non empty
+// slash separated elements like github.com/orilang/app
+func validPath(path string) bool {
+	if path == "" {
+		return false
+	}
+
+	for _, elem := range strings.Split(path, "/") {
+		if elem == "" || elem == "." || elem == ".." {
+			return false
+		}
+		for _, r := range elem {
+			switch {
+			case r >= 'a' && r <= 'z', r >= 'A' && r <= 'Z', r >= '0' && r <= '9':
+			case strings.ContainsRune("-._~", r):
+			default:
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// validVersion returns true when version is like 1.2 or 1.2.3
+func validVersion(version string) bool {
+	parts := strings.Split(version, ".")
+	if len(parts) < 2 || len(parts) > 3 {
+		return false
+	}
+
+	for _, part := range parts {
+		if part == "" || (len(part) > 1 && part[0] == '0') {
+			return false
+		}
+		for _, r := range part {
+			if r < '0' || r > '9' {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// isLocal returns true when path is a directory path
+// like ./lib, ../lib or /src/lib
+func isLocal(path string) bool {
+	return path == "." || path == ".." ||
+		strings.HasPrefix(path, "./") || strings.HasPrefix(path, "../") ||
+		filepath.IsAbs(path)
+}
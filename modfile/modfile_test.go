@@ -0,0 +1,134 @@
+package modfile
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestModfile(t *testing.T) {
+	assert := assert.New(t)
+
+	tests := []struct {
+		name     string
+		input    string
+		dump     string
+		expected []string
+	}{
+		{
+			name: "valid",
+			input: `// app manifest
+module github.com/orilang/app
+
+ori 0.1.2
+
+require github.com/orilang/lib
+require (
+  github.com/orilang/log // logging
+)
+
+replace (
+  github.com/orilang/lib => ../lib
+  github.com/orilang/log => /src/log
+)
+`,
+			dump: `ModFile
+ Module: "github.com/orilang/app" @2:8
+ Ori: "0.1.2" @4:5
+ Require
+  Path: "github.com/orilang/lib" @6:9
+  Path: "github.com/orilang/log" @8:3
+ Replace
+  Old: "github.com/orilang/lib" @12:3
+  New: "../lib" @12:29
+  Old: "github.com/orilang/log" @13:3
+  New: "/src/log" @13:29
+`,
+		},
+		{
+			name: "syntax",
+			input: `module github.com/orilang/app
+module github.com/orilang/other
+ori
+import github.com/orilang/lib
+replace github.com/orilang/lib ./lib
+require (
+  github.com/orilang/lib
+`,
+			dump: `ModFile
+ Module: "github.com/orilang/app" @1:8
+ Require
+  Path: "github.com/orilang/lib" @7:3
+`,
+			expected: []string{
+				`2:1: duplicate directive, got "module"`,
+				`3:1: malformed directive, got "ori"`,
+				`4:1: unknown directive, got "import"`,
+				`5:1: malformed directive, got "replace"`,
+				`6:1: unterminated directive block, got "require"`,
+			},
+		},
+		{
+			name: "validation",
+			input: `ori 01.2
+
+require github.com//lib
+require github.com/orilang/log
+require github.com/orilang/log
+
+replace github.com/orilang/log => github.com/orilang/fork
+replace github.com/orilang/log => ./log
+replace github.com/orilang/json => ./json
+`,
+			dump: `ModFile
+ Ori: "01.2" @1:5
+ Require
+  Path: "github.com//lib" @3:9
+  Path: "github.com/orilang/log" @4:9
+  Path: "github.com/orilang/log" @5:9
+ Replace
+  Old: "github.com/orilang/log" @7:9
+  New: "github.com/orilang/fork" @7:35
+  Old: "github.com/orilang/log" @8:9
+  New: "./log" @8:35
+  Old: "github.com/orilang/json" @9:9
+  New: "./json" @9:36
+`,
+			expected: []string{
+				`1:1: missing module directive, got ""`,
+				`1:5: invalid ori version, got "01.2"`,
+				`3:9: invalid module path, got "github.com//lib"`,
+				`5:9: module already required, got "github.com/orilang/log"`,
+				`7:35: replacement is not a local path, got "github.com/orilang/fork"`,
+				`8:9: module already replaced, got "github.com/orilang/log"`,
+				`9:9: replaced module is not required, got "github.com/orilang/json"`,
+			},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			f, errs := Parse([]byte(tc.input))
+
+			var result []string
+			for _, err := range errs {
+				result = append(result, err.Error())
+			}
+			assert.Equal(tc.expected, result)
+			assert.Equal(tc.dump, Dump(f))
+		})
+	}
+
+	t.Run("bom", func(t *testing.T) {
+		f, errs := Parse([]byte("\xef\xbb\xbfmodule app\r\n"))
+		assert.Nil(errs)
+		assert.Equal("app", f.Module.Path.Value)
+	})
+
+	t.Run("error", func(t *testing.T) {
+		_, errs := Parse([]byte("module a/../b\n"))
+		if assert.Len(errs, 1) {
+			assert.ErrorIs(errs[0], ErrInvalidModulePath)
+		}
+	})
+}
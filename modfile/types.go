@@ -0,0 +1,93 @@
+package modfile
+
+// Word holds a value of a directive with its position
+type Word struct {
+	// Value is the raw text of the word
+	Value string
+
+	// Line of the word
+	Line int
+
+	// Column of the word
+	Column int
+}
+
+// File holds the directives of a .mod module manifest
+type File struct {
+	// Module is the module directive, nil when missing
+	Module *Module
+
+	// Ori is the language version directive, nil when missing
+	Ori *Ori
+
+	// Require holds the modules the module depends on
+	Require []*Require
+
+	// Replace holds the modules replaced by local directories
+	Replace []*Replace
+}
+
+// Module holds the module directive like module github.com/orilang/app
+type Module struct {
+	// Directive is the module keyword
+	Directive Word
+
+	// Path is the module path
+	Path Word
+}
+
+// Ori holds the language version directive like ori 0.1
+type Ori struct {
+	// Directive is the ori keyword
+	Directive Word
+
+	// Version is the Ori language version
+	Version Word
+}
+
+// Require holds a required module like require github.com/orilang/lib
+type Require struct {
+	// Directive is the require keyword
+	Directive Word
+
+	// Path is the required module path
+	Path Word
+}
+
+// Replace holds a module replaced by a local directory
+// like replace github.com/orilang/lib => ../lib
+type Replace struct {
+	// Directive is the replace keyword
+	Directive Word
+
+	// Old is the replaced module path
+	Old Word
+
+	// Arrow is the => separator
+	Arrow Word
+
+	// New is the local directory replacing the module
+	New Word
+}
+
+// Error holds a module manifest failure with its position
+type Error struct {
+	// Err is the reason of the failure like ErrUnknownDirective
+	Err error
+
+	// Word is where the failure happened
+	Word Word
+}
+
+// parser holds requirements to parse a module manifest
+type parser struct {
+	// file being built
+	file *File
+
+	// errors found while parsing
+	errors []error
+
+	// block is the directive of the current ( ... ) block,
+	// empty when outside a block
+	block Word
+}
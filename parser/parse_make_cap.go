@@ -19,16 +19,17 @@ func (p *Parser) parseMakeExpr() *ast.MakeExpr {
 
 	if !token.IsMakeTypes(p.kind()) {
 		p.errors = append(p.errors, fmt.Errorf("%d:%d: unexpected map/hashmap or slice, got %v %q", p.peek().Line, p.peek().Column, p.peek().Kind, p.peek().Value))
-		p.consumeTo(token.EOF)
+		p.consumeTo(token.RParen)
+		if p.kind() == token.RParen {
+			x.RParen = p.next()
+		}
 		return x
 	}
 
 	var count int
 	for p.kind() != token.RParen && p.kind() != token.EOF {
-		if token.IsMapType(p.kind()) {
-			x.Type = p.parseMapsHashMapsDecl()
-		} else if p.lookForInSliceHeader(token.LBracket) {
-			x.Type = p.parseSliceOrArrayType()
+		if x.Type == nil {
+			x.Type = p.parseType()
 		}
 
 		if p.kind() == token.Comma {
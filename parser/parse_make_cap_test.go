@@ -1,6 +1,7 @@
 package parser
 
 import (
+	"context"
 	"testing"
 
 	"github.com/orilang/gori/ast"
@@ -22,7 +23,8 @@ func main() {
 }
 `
 		parser := New(lex.FetchTokensFromString(data))
-		pr := parser.ParseFile()
+		pr, err := parser.ParseFile(context.Background())
+		assert.Nil(err)
 		result := `File
  Package: "package" @1:1 (kind=8)
  Name: "main" @1:9 (kind=3)
@@ -112,7 +114,8 @@ func main(){
 }
 `
 		parser := New(lex.FetchTokensFromString(data))
-		pr := parser.ParseFile()
+		pr, err := parser.ParseFile(context.Background())
+		assert.Nil(err)
 		result := `File
  Package: "package" @1:1 (kind=8)
  Name: "main" @1:9 (kind=3)
@@ -175,7 +178,8 @@ func main() {
 }
 `
 		parser := New(lex.FetchTokensFromString(data))
-		pr := parser.ParseFile()
+		pr, err := parser.ParseFile(context.Background())
+		assert.Nil(err)
 		result := `File
  Package: "package" @1:1 (kind=8)
  Name: "main" @1:9 (kind=3)
@@ -231,7 +235,8 @@ func main() {
 }
 `
 		parser := New(lex.FetchTokensFromString(data))
-		pr := parser.ParseFile()
+		pr, err := parser.ParseFile(context.Background())
+		assert.Nil(err)
 		assert.NotNil(pr)
 		assert.Greater(len(parser.errors), 0)
 	})
@@ -246,7 +251,8 @@ func main() {
 }
 `
 		parser := New(lex.FetchTokensFromString(data))
-		pr := parser.ParseFile()
+		pr, err := parser.ParseFile(context.Background())
+		assert.Nil(err)
 		assert.NotNil(pr)
 		assert.Greater(len(parser.errors), 0)
 	})
@@ -261,7 +267,8 @@ func main() {
 }
 `
 		parser := New(lex.FetchTokensFromString(data))
-		pr := parser.ParseFile()
+		pr, err := parser.ParseFile(context.Background())
+		assert.Nil(err)
 		assert.NotNil(pr)
 		assert.Greater(len(parser.errors), 0)
 	})
@@ -276,7 +283,8 @@ func main() {
 }
 `
 		parser := New(lex.FetchTokensFromString(data))
-		pr := parser.ParseFile()
+		pr, err := parser.ParseFile(context.Background())
+		assert.Nil(err)
 		assert.NotNil(pr)
 		assert.Greater(len(parser.errors), 0)
 	})
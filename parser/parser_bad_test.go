@@ -1,8 +1,11 @@
 package parser
 
 import (
+	"context"
+	"fmt"
 	"testing"
 
+	"github.com/orilang/gori/ast"
 	"github.com/orilang/gori/lexer"
 	"github.com/stretchr/testify/assert"
 )
@@ -42,4 +45,126 @@ func TestParser_bad(t *testing.T) {
 		assert.NotNil(pr)
 		assert.Greater(len(parser.errors), 0)
 	})
+
+	t.Run("illegal_token", func(t *testing.T) {
+		lex, err := lexer.NewLexer(lexer.Config{StringOnly: true})
+		assert.Nil(err)
+		data := `3.14.15
+`
+		parser := New(lex.FetchTokensFromString(data))
+		pr := parser.parseExpr(LOWEST)
+		assert.Contains(ast.Dump(pr), "reason=illegal token")
+		assert.Equal(1, len(parser.errors))
+		assert.Equal(`1:1: illegal token "3.14.15"`, parser.errors[0].Error())
+	})
+
+	t.Run("recovery", func(t *testing.T) {
+		tests := []struct {
+			name     string
+			input    string
+			decls    []string
+			expected []string
+		}{
+			{
+				name: "file_level",
+				input: `package main
+
+comptime type X int
+
+type S struct {
+  a 1
+  b int
+}
+
+func f() {
+  return x )
+  z := 3
+}
+
+@ @ @
+
+func g() int {
+  return 1
+}
+`,
+				decls: []string{"*ast.BadDecl", "*ast.StructDecl", "*ast.FuncDecl", "*ast.FuncDecl"},
+				expected: []string{
+					`3:10: expected 'const' or 'func', got 26 "type"`,
+					`6:5: unsupported type with 4 "1"`,
+					`11:12: unexpected expression, got 40 ")"`,
+					`15:1: unsupported file statement starting with 0 "@"`,
+				},
+			},
+			{
+				name: "block_level",
+				input: `package main
+
+func f() {
+  var s []int = make(1)
+  var c []int = make([]int, 1, 2, 3)
+  if x {
+    return y )
+  }
+  t := 2
+}
+
+func g() {
+  return 1 1
+}
+`,
+				decls: []string{"*ast.FuncDecl", "*ast.FuncDecl"},
+				expected: []string{
+					`4:22: unexpected map/hashmap or slice, got 4 "1"`,
+					`5:35: unexpected map/hashmap or slice, got 4 "3"`,
+					`7:14: unexpected expression, got 40 ")"`,
+					`13:12: unexpected expression, got 4 "1"`,
+				},
+			},
+			{
+				name: "missing_operand_before_decl",
+				input: `package main
+
+const C int = (
+var V int = 2
+`,
+				decls:    []string{"*ast.ConstDecl", "*ast.VarDecl"},
+				expected: []string{`4:1: expected prefix expression, got 11 "var"`},
+			},
+			{
+				name: "missing_operand_before_stmt",
+				input: `package main
+
+func f() {
+  x := 1 +
+  var y int = )
+}
+`,
+				decls: []string{"*ast.FuncDecl"},
+				expected: []string{
+					`5:3: expected prefix expression, got 11 "var"`,
+					`5:15: expected prefix expression, got 40 ")"`,
+				},
+			},
+		}
+
+		for _, tc := range tests {
+			lex, err := lexer.NewLexer(lexer.Config{StringOnly: true})
+			assert.Nil(err)
+			parser := New(lex.FetchTokensFromString(tc.input))
+			pr, err := parser.ParseFile(context.Background())
+			assert.Nil(err)
+
+			var decls []string
+			for _, decl := range pr.Decls {
+				decls = append(decls, fmt.Sprintf("%T", decl))
+			}
+			assert.Equal(tc.decls, decls, tc.name)
+
+			var errs []string
+			for _, e := range parser.errors {
+				errs = append(errs, e.Error())
+			}
+			assert.Equal(tc.expected, errs, tc.name)
+		}
+	})
 }
@@ -0,0 +1,145 @@
+package parser
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/orilang/gori/ast"
+	"github.com/orilang/gori/lexer"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParser_comments(t *testing.T) {
+	assert := assert.New(t)
+
+	data := `//ori:build linux && amd64
+package main
+
+/// A is documented
+/// on two lines
+const A int = 1 // trailing comment
+func f() {}
+
+// B is documented too
+//ori:inline
+type B struct {
+  x int // field comment
+}
+
+// detached comment
+
+type C enum {
+  Red
+}
+
+// V is documented
+var V int = 1
+`
+
+	t.Run("docs", func(t *testing.T) {
+		lex, err := lexer.NewLexer(lexer.Config{StringOnly: true})
+		assert.Nil(err)
+		parser := New(lex.FetchTokensFromString(data))
+		pr, err := parser.ParseFile(context.Background())
+		assert.Nil(err)
+		assert.Equal(0, len(parser.errors))
+		assert.Equal(5, len(pr.Decls))
+
+		assert.Equal("A is documented\non two lines", pr.Decls[0].(*ast.ConstDecl).Doc.Text())
+		assert.Nil(pr.Decls[1].(*ast.FuncDecl).Doc)
+		assert.Equal("B is documented too", pr.Decls[2].(*ast.StructDecl).Doc.Text())
+		assert.Nil(pr.Decls[3].(*ast.EnumDecl).Doc)
+		assert.Equal("V is documented", pr.Decls[4].(*ast.VarDecl).Doc.Text())
+	})
+
+	t.Run("directives", func(t *testing.T) {
+		lex, err := lexer.NewLexer(lexer.Config{StringOnly: true})
+		assert.Nil(err)
+		parser := New(lex.FetchTokensFromString(data))
+		pr, err := parser.ParseFile(context.Background())
+		assert.Nil(err)
+		assert.Equal(2, len(pr.Directives))
+		assert.Equal("build", pr.Directives[0].Name)
+		assert.Equal([]string{"linux", "&&", "amd64"}, pr.Directives[0].Args)
+		assert.Equal(1, pr.Directives[0].Token.Line)
+		assert.Equal("inline", pr.Directives[1].Name)
+		assert.Equal(0, len(pr.Directives[1].Args))
+	})
+
+	t.Run("dump", func(t *testing.T) {
+		lex, err := lexer.NewLexer(lexer.Config{StringOnly: true})
+		assert.Nil(err)
+		data := `//ori:generate gori lex
+package main
+
+/// main runs
+func main() {}
+
+// v is documented
+var v int = 1
+`
+		result := `File
+ Package: "package" @2:1 (kind=8)
+ Name: "main" @2:9 (kind=3)
+ Directives
+  Directive
+   Token: "//ori:generate gori lex" @1:1 (kind=83)
+   Name: generate
+   Args: ["gori" "lex"]
+ Decls
+  FuncDecl
+   Doc
+    Comment: "/// main runs" @4:1 (kind=82)
+   Function: "func" @5:1 (kind=10)
+   Name: "main" @5:6 (kind=3)
+   Params
+    (none)
+   Body
+  VarDecl
+   Doc
+    Comment: "// v is documented" @7:1 (kind=2)
+   Var: "var" @8:1 (kind=11)
+   Name: "v" @8:5 (kind=3)
+   Type
+    NamedType
+     Ident: "int" @8:7 (kind=12)
+   Eq: "=" @8:11 (kind=49)
+   Init
+    IntLitExpr
+     Value: "1" @8:13 (kind=4)
+`
+		parser := New(lex.FetchTokensFromString(data))
+		pr, err := parser.ParseFile(context.Background())
+		assert.Nil(err)
+		assert.Equal(result, ast.Dump(pr))
+		assert.Equal(0, len(parser.errors))
+	})
+
+	t.Run("stream", func(t *testing.T) {
+		lex, err := lexer.NewLexer(lexer.Config{StringOnly: true})
+		assert.Nil(err)
+		expected, err := New(lex.FetchTokensFromString(data)).ParseFile(context.Background())
+		assert.Nil(err)
+
+		p := NewStream(lexer.NewReader(strings.NewReader(data)))
+		pr, err := p.ParseFile(context.Background())
+		assert.Nil(err)
+		assert.Equal(ast.Dump(expected), ast.Dump(pr))
+		assert.Equal(0, len(p.errors))
+	})
+
+	t.Run("invalid_directive", func(t *testing.T) {
+		lex, err := lexer.NewLexer(lexer.Config{StringOnly: true})
+		assert.Nil(err)
+		data := `//ori: build linux
+package main
+`
+		parser := New(lex.FetchTokensFromString(data))
+		pr, err := parser.ParseFile(context.Background())
+		assert.Nil(err)
+		assert.Equal(0, len(pr.Directives))
+		assert.Equal(1, len(parser.errors))
+		assert.Equal(`1:1: expected directive name, got 83 "//ori: build linux"`, parser.errors[0].Error())
+	})
+}
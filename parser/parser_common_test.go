@@ -1,10 +1,16 @@
 package parser
 
 import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
 	"syscall"
 	"testing"
 
+	"github.com/orilang/gori/ast"
 	"github.com/orilang/gori/lexer"
+	"github.com/orilang/gori/modfile"
 	"github.com/orilang/gori/token"
 	"github.com/stretchr/testify/assert"
 )
@@ -19,7 +25,79 @@ func TestParser_parse_common(t *testing.T) {
 
 	t.Run("err_start_lexing", func(t *testing.T) {
 		parse := &Files{Files: []string{"xxxx.ori"}}
-		assert.ErrorIs(parse.StartParsing(), syscall.Errno(2))
+		assert.ErrorIs(parse.StartParsing(context.Background()), syscall.Errno(2))
+	})
+
+	t.Run("err_lexing", func(t *testing.T) {
+		parse, err := NewParser(Config{File: "../testdata/illegal/numbers.ori"})
+		assert.Nil(err)
+		err = parse.StartParsing(context.Background())
+		assert.ErrorIs(err, lexer.ErrMultipleDots)
+		assert.ErrorIs(err, lexer.ErrMisplacedDigitSeparator)
+		assert.Contains(err.Error(), "numbers.ori:4:21: multiple dots in number")
+	})
+
+	t.Run("err_lexing_tab_width", func(t *testing.T) {
+		file := filepath.Join(t.TempDir(), "tab.ori")
+		assert.Nil(os.WriteFile(file, []byte("package main\r\n\r\nfunc main() {\r\n\tvar x string = \"test\r\n}\r\n"), 0o600))
+
+		parse, err := NewParser(Config{File: file, TabWidth: 4})
+		assert.Nil(err)
+		err = parse.StartParsing(context.Background())
+		assert.ErrorIs(err, lexer.ErrUnterminatedString)
+		assert.Contains(err.Error(), "tab.ori:4:20: unterminated string")
+	})
+
+	t.Run("err_parsing", func(t *testing.T) {
+		parse, err := NewParser(Config{File: "../testdata/syntax/invalid/recovery.ori"})
+		assert.Nil(err)
+		err = parse.StartParsing(context.Background())
+		if assert.Error(err) {
+			assert.Contains(err.Error(), "recovery.ori:4:5: unsupported type with")
+			assert.Contains(err.Error(), "recovery.ori:9:12: unexpected expression")
+		}
+	})
+
+	t.Run("success_syntax", func(t *testing.T) {
+		parse, err := NewParser(Config{Directory: "../testdata/syntax/valid"})
+		assert.Nil(err)
+		assert.Nil(parse.StartParsing(context.Background()))
+	})
+
+	t.Run("success_module", func(t *testing.T) {
+		parse, err := NewParser(Config{Directory: "../testdata/module"})
+		assert.Nil(err)
+		assert.Nil(parse.StartParsing(context.Background()))
+	})
+
+	t.Run("err_module", func(t *testing.T) {
+		parse, err := NewParser(Config{File: "../testdata/module_invalid/ori.mod"})
+		assert.Nil(err)
+		err = parse.StartParsing(context.Background())
+		assert.ErrorIs(err, modfile.ErrInvalidVersion)
+		assert.ErrorIs(err, modfile.ErrNotRequired)
+		assert.Contains(err.Error(), "ori.mod:3:5: invalid ori version")
+	})
+
+	t.Run("jobs_ordered_errors", func(t *testing.T) {
+		parse, err := NewParser(Config{Directory: "../testdata/illegal", Jobs: 3})
+		assert.Nil(err)
+		for range 5 {
+			msg := parse.StartParsing(context.Background()).Error()
+			comment := strings.Index(msg, "multiline_comment.ori:4:3")
+			numbers := strings.Index(msg, "numbers.ori:4:21")
+			str := strings.Index(msg, "string.ori:4:18")
+			assert.Less(comment, numbers)
+			assert.Less(numbers, str)
+		}
+	})
+
+	t.Run("err_cancelled", func(t *testing.T) {
+		parse, err := NewParser(Config{Directory: "../testdata/success"})
+		assert.Nil(err)
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+		assert.ErrorIs(parse.StartParsing(ctx), context.Canceled)
 	})
 
 	t.Run("peek_eof", func(t *testing.T) {
@@ -35,7 +113,7 @@ func TestParser_parse_common(t *testing.T) {
 
 	t.Run("match_true", func(t *testing.T) {
 		lex := lexer.New([]byte("package"))
-		lex.Tokenize()
+		assert.Nil(lex.Tokenize(context.Background()))
 		parse := New(lex.Tokens)
 		_, ok := parse.match(token.KWPackage)
 
@@ -55,7 +133,7 @@ func TestParser_parse_common(t *testing.T) {
 
 	t.Run("expect_ok", func(t *testing.T) {
 		lex := lexer.New([]byte("package"))
-		lex.Tokenize()
+		assert.Nil(lex.Tokenize(context.Background()))
 		parse := New(lex.Tokens)
 		_ = parse.expect(token.KWPackage, "ok")
 
@@ -64,7 +142,7 @@ func TestParser_parse_common(t *testing.T) {
 
 	t.Run("expect_errors", func(t *testing.T) {
 		lex := lexer.New([]byte("package"))
-		lex.Tokenize()
+		assert.Nil(lex.Tokenize(context.Background()))
 		parse := New(lex.Tokens)
 		tok := parse.expect(token.Illegal, "nok")
 		assert.NotNil(parse.errors)
@@ -73,35 +151,35 @@ func TestParser_parse_common(t *testing.T) {
 
 	t.Run("peekPrecedence_lowest", func(t *testing.T) {
 		lex := lexer.New([]byte("package"))
-		lex.Tokenize()
+		assert.Nil(lex.Tokenize(context.Background()))
 		parse := New(lex.Tokens)
 		assert.Equal(LOWEST, parse.peekPrecedence())
 	})
 
 	t.Run("peekPrecedence_multiplicative", func(t *testing.T) {
 		lex := lexer.New([]byte("*"))
-		lex.Tokenize()
+		assert.Nil(lex.Tokenize(context.Background()))
 		parse := New(lex.Tokens)
 		assert.Equal(MULTIPLICATIVE, parse.peekPrecedence())
 	})
 
 	t.Run("look_for_x1", func(t *testing.T) {
 		lex := lexer.New([]byte("*"))
-		lex.Tokenize()
+		assert.Nil(lex.Tokenize(context.Background()))
 		parse := New(lex.Tokens)
 		assert.Equal(false, parse.lookForInForHeader(token.Comma))
 	})
 
 	t.Run("look_for_x2", func(t *testing.T) {
 		lex := lexer.New([]byte("a 1"))
-		lex.Tokenize()
+		assert.Nil(lex.Tokenize(context.Background()))
 		parse := New(lex.Tokens)
 		assert.Equal(true, parse.lookForInForHeader(token.IntLit))
 	})
 
 	t.Run("look_for_x3", func(t *testing.T) {
 		lex := lexer.New([]byte("*"))
-		lex.Tokenize()
+		assert.Nil(lex.Tokenize(context.Background()))
 		parse := New(lex.Tokens)
 		parse.position = len(lex.Tokens)
 		assert.Equal(false, parse.lookForInForHeader(token.Comma))
@@ -109,21 +187,21 @@ func TestParser_parse_common(t *testing.T) {
 
 	t.Run("look_for_in_switch_header_x1", func(t *testing.T) {
 		lex := lexer.New([]byte("*"))
-		lex.Tokenize()
+		assert.Nil(lex.Tokenize(context.Background()))
 		parse := New(lex.Tokens)
 		assert.Equal(false, parse.lookForInSwitchHeader(token.SemiComma))
 	})
 
 	t.Run("look_for_in_switch_header_x2", func(t *testing.T) {
 		lex := lexer.New([]byte("switch x:=f();x"))
-		lex.Tokenize()
+		assert.Nil(lex.Tokenize(context.Background()))
 		parse := New(lex.Tokens)
 		assert.Equal(true, parse.lookForInSwitchHeader(token.SemiComma))
 	})
 
 	t.Run("look_for_in_switch_header_x3", func(t *testing.T) {
 		lex := lexer.New([]byte("*"))
-		lex.Tokenize()
+		assert.Nil(lex.Tokenize(context.Background()))
 		parse := New(lex.Tokens)
 		parse.position = len(lex.Tokens)
 		assert.Equal(false, parse.lookForInSwitchHeader(token.SemiComma))
@@ -131,21 +209,21 @@ func TestParser_parse_common(t *testing.T) {
 
 	t.Run("look_for_in_switch_case_header_x1", func(t *testing.T) {
 		lex := lexer.New([]byte("*"))
-		lex.Tokenize()
+		assert.Nil(lex.Tokenize(context.Background()))
 		parse := New(lex.Tokens)
 		assert.Equal(false, parse.lookForInSwitchCaseHeader(token.Comma))
 	})
 
 	t.Run("look_for_in_switch_case_header_x2", func(t *testing.T) {
 		lex := lexer.New([]byte("case 1,2"))
-		lex.Tokenize()
+		assert.Nil(lex.Tokenize(context.Background()))
 		parse := New(lex.Tokens)
 		assert.Equal(true, parse.lookForInSwitchCaseHeader(token.Comma))
 	})
 
 	t.Run("look_for_in_switch_case_header_x3", func(t *testing.T) {
 		lex := lexer.New([]byte("*"))
-		lex.Tokenize()
+		assert.Nil(lex.Tokenize(context.Background()))
 		parse := New(lex.Tokens)
 		parse.position = len(lex.Tokens)
 		assert.Equal(false, parse.lookForInSwitchCaseHeader(token.Comma))
@@ -192,25 +270,60 @@ type test struct{
 		assert.Equal(false, p.newlineSincePrev())
 	})
 
-	t.Run("look_for_in_slice_view_colon_header_x1", func(t *testing.T) {
-		lex := lexer.New([]byte("*"))
-		lex.Tokenize()
-		parse := New(lex.Tokens)
-		assert.Equal(false, parse.lookForInSliceHeader(token.Comma))
+	t.Run("stream", func(t *testing.T) {
+		data := `package main
+
+type A struct {
+  x int
+  y string
+}
+
+func main() {
+  for i := 0; i < 10; i++ {
+    a[i] = b[i] + f(i)
+  }
+}
+`
+		lex := lexer.New([]byte(data))
+		assert.Nil(lex.Tokenize(context.Background()))
+		expected, err := New(lex.Tokens).ParseFile(context.Background())
+		assert.Nil(err)
+
+		p := NewStream(lexer.NewReader(strings.NewReader(data)))
+		pr, err := p.ParseFile(context.Background())
+		assert.Nil(err)
+		assert.Equal(ast.Dump(expected), ast.Dump(pr))
+		assert.Equal(0, len(p.errors))
 	})
 
-	t.Run("look_for_in_slice_view_colon_header_x2", func(t *testing.T) {
-		lex := lexer.New([]byte("[:]"))
-		lex.Tokenize()
-		parse := New(lex.Tokens)
-		assert.Equal(true, parse.lookForInSliceHeader(token.Colon))
+	t.Run("stream_bounded_window", func(t *testing.T) {
+		var b strings.Builder
+		b.WriteString("package main\n")
+		for range 2000 {
+			b.WriteString("const a int = 1\n")
+		}
+
+		p := NewStream(lexer.NewReader(strings.NewReader(b.String())))
+		pr, err := p.ParseFile(context.Background())
+		assert.Nil(err)
+		assert.Equal(2000, len(pr.Decls))
+		assert.Equal(0, len(p.errors))
+		assert.LessOrEqual(len(p.Tokens), windowSize+1)
 	})
 
-	t.Run("look_for_in_slice_view_colon_header_x3", func(t *testing.T) {
-		lex := lexer.New([]byte("*"))
-		lex.Tokenize()
-		parse := New(lex.Tokens)
-		parse.position = len(lex.Tokens)
-		assert.Equal(false, parse.lookForInSliceHeader(token.Colon))
+	t.Run("parse_file_cancelled", func(t *testing.T) {
+		var b strings.Builder
+		b.WriteString("package main\n")
+		for range 100 {
+			b.WriteString("func main() {\n  a = 1\n}\n")
+		}
+
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+		p := NewStream(lexer.NewReader(strings.NewReader(b.String())))
+		pr, err := p.ParseFile(ctx)
+		assert.ErrorIs(err, context.Canceled)
+		assert.NotNil(pr)
+		assert.Equal(0, len(pr.Decls))
 	})
 }
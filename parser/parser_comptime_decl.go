@@ -10,6 +10,10 @@ import (
 // parseComptimeBlockDecl returns comptime expression
 func (p *Parser) parseComptimeBlockDecl() ast.Decl {
 	x := p.expect(token.KWComptime, "expected 'comptime'")
+	if p.kind() == token.LBrace {
+		return p.parseComptimeBlock(x)
+	}
+
 	if p.kind() == token.KWConst {
 		c := &ast.ComptimeBlockDecl{
 			ComptimeKW: x,
@@ -22,12 +26,59 @@ func (p *Parser) parseComptimeBlockDecl() ast.Decl {
 		c := &ast.ComptimeBlockDecl{
 			ComptimeKW: x,
 		}
+		p.comptime = true
 		c.Decls = append(c.Decls, p.parseFuncDecl())
+		p.comptime = false
 		return c
 	}
 
-	p.errors = append(p.errors, fmt.Errorf("%d:%d: expected 'const' or 'func', got %v %q", p.peek().Line, p.peek().Column, p.peek().Kind, p.peek().Value))
+	tok := p.peek()
+	p.errors = append(p.errors, fmt.Errorf("%d:%d: expected 'const' or 'func', got %v %q", tok.Line, tok.Column, tok.Kind, tok.Value))
+	return &ast.BadDecl{From: x, To: tok, Reason: "expected 'const' or 'func'"}
+}
+
+// parseComptimeBlock returns the const and func declarations
+// grouped in comptime { ... }
+func (p *Parser) parseComptimeBlock(kw token.Token) ast.Decl {
+	c := &ast.ComptimeBlockDecl{
+		ComptimeKW: kw,
+		LBrace:     p.expect(token.LBrace, "expected '{'"),
+	}
+
+	for p.kind() != token.RBrace && p.kind() != token.EOF && !p.cancelled() {
+		switch p.kind() {
+		case token.KWConst:
+			c.Decls = append(c.Decls, p.parseConstDecl())
+
+		case token.KWFunc:
+			p.comptime = true
+			c.Decls = append(c.Decls, p.parseFuncDecl())
+			p.comptime = false
+
+		case token.SemiComma:
+			_ = p.next()
+
+		default:
+			tok := p.next()
+			p.errors = append(p.errors, fmt.Errorf("%d:%d: expected 'const' or 'func', got %v %q", tok.Line, tok.Column, tok.Kind, tok.Value))
+			for p.kind() != token.KWConst && p.kind() != token.KWFunc && p.kind() != token.RBrace && p.kind() != token.EOF {
+				_ = p.next()
+			}
+		}
+	}
+	c.RBrace = p.expect(token.RBrace, "expected '}'")
+
+	return c
+}
 
-	p.consumeTo(token.EOF)
-	return nil
+// parseComptimeExpr returns a call evaluated
+// at compile time like comptime f(3)
+func (p *Parser) parseComptimeExpr() ast.Expr {
+	kw := p.next()
+	x := p.parseExpr(PREFIX + 1)
+	if _, ok := x.(*ast.CallExpr); !ok {
+		p.errors = append(p.errors, fmt.Errorf("%d:%d: expression in comptime must be function call, got %v %q", x.Start().Line, x.Start().Column, x.Start().Kind, x.Start().Value))
+		return &ast.BadExpr{From: kw, To: x.End(), Reason: "expected function call"}
+	}
+	return &ast.ComptimeExpr{ComptimeKW: kw, X: x}
 }
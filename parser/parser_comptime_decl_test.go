@@ -1,6 +1,7 @@
 package parser
 
 import (
+	"context"
 	"testing"
 
 	"github.com/orilang/gori/ast"
@@ -19,7 +20,8 @@ func TestParser_comptime_decl(t *testing.T) {
 comptime const a float = 3.14
 `
 		parser := New(lex.FetchTokensFromString(data))
-		pr := parser.ParseFile()
+		pr, err := parser.ParseFile(context.Background())
+		assert.Nil(err)
 		result := `File
  Package: "package" @1:1 (kind=8)
  Name: "main" @1:9 (kind=3)
@@ -49,7 +51,8 @@ comptime const a float = 3.14
 comptime func x()[]int{}
 `
 		parser := New(lex.FetchTokensFromString(data))
-		pr := parser.ParseFile()
+		pr, err := parser.ParseFile(context.Background())
+		assert.Nil(err)
 		result := `File
  Package: "package" @1:1 (kind=8)
  Name: "main" @1:9 (kind=3)
@@ -75,6 +78,107 @@ comptime func x()[]int{}
 		assert.Equal(0, len(parser.errors))
 	})
 
+	t.Run("block_x1", func(t *testing.T) {
+		lex, err := lexer.NewLexer(lexer.Config{StringOnly: true})
+		assert.Nil(err)
+		data := `package main
+
+comptime {
+  const a int = 1
+  func f(n int) int { return n }
+}
+`
+		parser := New(lex.FetchTokensFromString(data))
+		pr, err := parser.ParseFile(context.Background())
+		assert.Nil(err)
+		result := `File
+ Package: "package" @1:1 (kind=8)
+ Name: "main" @1:9 (kind=3)
+ Decls
+  CompTimeBlockDecl:
+   Comptime: "comptime" @3:1 (kind=78)
+   LBrace: "{" @3:10 (kind=41)
+    ConstDecl
+     Const: "const" @4:3 (kind=23)
+     Name: "a" @4:9 (kind=3)
+     Type
+      NamedType
+       Ident: "int" @4:11 (kind=12)
+     Eq: "=" @4:15 (kind=49)
+     Init
+      IntLitExpr
+       Value: "1" @4:17 (kind=4)
+    FuncDecl
+     Function: "func" @5:3 (kind=10)
+     Name: "f" @5:8 (kind=3)
+     Params
+      Param
+       Ident: "n" @5:10 (kind=3)
+       Type
+        NamedType
+         Ident: "int" @5:12 (kind=12)
+     Results
+       Param
+        Type
+         NamedType
+          Ident: "int" @5:17 (kind=12)
+     Body
+      BlockStmt
+       LBrace: "{" @5:21 (kind=41)
+       Stmts
+        ReturnStmt
+         Values
+          IdentExpr
+           Name: "n" @5:30 (kind=3)
+       RBrace: "}" @5:32 (kind=42)
+   RBrace: "}" @6:1 (kind=42)
+`
+		assert.Equal(result, ast.Dump(pr))
+		assert.Equal(0, len(parser.errors))
+	})
+
+	t.Run("expr_x1", func(t *testing.T) {
+		lex, err := lexer.NewLexer(lexer.Config{StringOnly: true})
+		assert.Nil(err)
+		data := `package main
+
+const a int = comptime f(1) + 2
+`
+		parser := New(lex.FetchTokensFromString(data))
+		pr, err := parser.ParseFile(context.Background())
+		assert.Nil(err)
+		result := `File
+ Package: "package" @1:1 (kind=8)
+ Name: "main" @1:9 (kind=3)
+ Decls
+  ConstDecl
+   Const: "const" @3:1 (kind=23)
+   Name: "a" @3:7 (kind=3)
+   Type
+    NamedType
+     Ident: "int" @3:9 (kind=12)
+   Eq: "=" @3:13 (kind=49)
+   Init
+    BinaryExpr
+     ComptimeExpr
+      Comptime: "comptime" @3:15 (kind=78)
+      CallExpr
+       Callee
+        IdentExpr
+         Name: "f" @3:24 (kind=3)
+       LParent: "(" @3:25 (kind=39)
+       Args:
+        IntLitExpr
+         Value: "1" @3:26 (kind=4)
+       RParent: ")" @3:27 (kind=40)
+     Operator: "+" @3:29 (kind=51)
+     IntLitExpr
+      Value: "2" @3:31 (kind=4)
+`
+		assert.Equal(result, ast.Dump(pr))
+		assert.Equal(0, len(parser.errors))
+	})
+
 	t.Run("bad_x1", func(t *testing.T) {
 		lex, err := lexer.NewLexer(lexer.Config{StringOnly: true})
 		assert.Nil(err)
@@ -83,7 +187,8 @@ comptime func x()[]int{}
 comptime var a float = 3.14
 `
 		parser := New(lex.FetchTokensFromString(data))
-		pr := parser.ParseFile()
+		pr, err := parser.ParseFile(context.Background())
+		assert.Nil(err)
 		assert.NotNil(pr)
 		assert.Greater(len(parser.errors), 0)
 	})
@@ -96,8 +201,25 @@ comptime var a float = 3.14
 const a float = comptime 3.14
 `
 		parser := New(lex.FetchTokensFromString(data))
-		pr := parser.ParseFile()
+		pr, err := parser.ParseFile(context.Background())
+		assert.Nil(err)
 		assert.NotNil(pr)
 		assert.Greater(len(parser.errors), 0)
 	})
+
+	t.Run("bad_x3", func(t *testing.T) {
+		lex, err := lexer.NewLexer(lexer.Config{StringOnly: true})
+		assert.Nil(err)
+		data := `package main
+
+comptime {
+  var a int = 1
+}
+`
+		parser := New(lex.FetchTokensFromString(data))
+		pr, err := parser.ParseFile(context.Background())
+		assert.Nil(err)
+		assert.NotNil(pr)
+		assert.Equal(`4:3: expected 'const' or 'func', got 11 "var"`, parser.errors[0].Error())
+	})
 }
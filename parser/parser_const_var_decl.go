@@ -7,24 +7,27 @@ import (
 	"github.com/orilang/gori/token"
 )
 
-// parseConstDecl returns constant declaration
+// parseConstDecl returns constant declaration or a group of them
 func (p *Parser) parseConstDecl() ast.Decl {
 	kw := p.expect(token.KWConst, "expected 'const'")
+	if p.kind() == token.LParen {
+		return p.parseGenDecl(kw)
+	}
+	return p.parseConstSpec(kw)
+}
+
+// parseConstSpec returns a single constant declaration.
+// kw is empty when the declaration is part of a group
+func (p *Parser) parseConstSpec(kw token.Token) ast.Decl {
 	name := p.expectValidIdent(token.Ident, true, "expected constant name")
 
-	typ, btyp, bad := p.parseVarConstType()
-	if bad {
+	typ := p.parseType()
+	if btyp, ok := typ.(*ast.BadType); ok {
 		p.errors = append(p.errors, fmt.Errorf("%d:%d: unexpected expression, got %v %q", btyp.From.Line, btyp.From.Column, btyp.From.Kind, btyp.From.Value))
 		return btyp
 	}
 	eq := p.expect(token.Assign, "expected '=")
-	var init ast.Expr
-	switch p.peek().Value {
-	case "[":
-		init = p.parseSliceElements()
-	default:
-		init = p.parseExpr(LOWEST)
-	}
+	init := p.parseVarInit()
 
 	return &ast.ConstDecl{
 		ConstKW: kw,
@@ -35,68 +38,130 @@ func (p *Parser) parseConstDecl() ast.Decl {
 	}
 }
 
-// parseVarDecl returns variable declaration
+// parseVarDecl returns variable declaration or a group of them
 func (p *Parser) parseVarDecl() ast.Decl {
 	kw := p.expect(token.KWVar, "expected 'var'")
-	name := p.expectValidIdent(token.Ident, true, "expected variable name")
-	var view token.Token
-	if p.kind() == token.KWView {
-		view = p.expect(token.KWView, "expected 'view'")
+	if p.kind() == token.LParen {
+		return p.parseGenDecl(kw)
 	}
+	return p.parseVarSpec(kw)
+}
 
-	typ, btyp, bad := p.parseVarConstType()
-	if bad {
+// parseVarSpec returns a single variable declaration.
+// kw is empty when the declaration is part of a group
+func (p *Parser) parseVarSpec(kw token.Token) ast.Decl {
+	names := []token.Token{p.expectValidIdent(token.Ident, true, "expected variable name")}
+	for p.kind() == token.Comma {
+		_ = p.next()
+		names = append(names, p.expectValidIdent(token.Ident, true, "expected variable name"))
+	}
+	qualifier := p.parseQualifier()
+
+	typ := p.parseType()
+	if btyp, ok := typ.(*ast.BadType); ok {
 		return btyp
 	}
 	eq := p.expect(token.Assign, "expected '=")
-	var init ast.Expr
-	switch p.peek().Value {
-	case "make":
-		init = p.parseMakeExpr()
-	case "[":
-		// []string{}
-		init = p.parseSliceElements()
-	default:
-		// x[1:]
-		if p.lookForInSliceHeader(token.LBracket) {
-			init = p.parseSliceExpr(p.parsePrefix())
-		} else {
-			init = p.parseExpr(LOWEST)
-		}
+	values := []ast.Expr{p.parseVarInit()}
+	for p.kind() == token.Comma {
+		_ = p.next()
+		values = append(values, p.parseVarInit())
+	}
+
+	if !p.validValueCount(len(names), values) {
+		p.errors = append(p.errors, fmt.Errorf("%d:%d: assignment mismatch: %d variable(s) but %d value(s), got %v %q", eq.Line, eq.Column, len(names), len(values), eq.Kind, eq.Value))
+	}
+	for _, init := range values {
+		p.checkViewInit(qualifier, init)
 	}
 
 	return &ast.VarDecl{
-		VarKW: kw,
-		Name:  name,
-		View:  view,
-		Type:  typ,
-		Eq:    eq,
-		Init:  init,
+		VarKW:     kw,
+		Names:     names,
+		Qualifier: qualifier,
+		Type:      typ,
+		Eq:        eq,
+		Values:    values,
 	}
 }
 
-// parseVarConstType returns const/vars types
-func (p *Parser) parseVarConstType() (ast.Type, *ast.BadType, bool) {
-	typ := &ast.NamedType{}
-	btyp := &ast.BadType{}
-	var bad bool
-
-	switch {
-	case p.kind() == token.LBracket:
-		return p.parseSliceOrArrayType(), nil, false
-
-	case token.IsMapType(p.kind()):
-		return p.parseMapsHashMapsDecl(), nil, false
-
-	case token.IsVarConstTypes(p.kind()):
-		typ.Parts = append(typ.Parts, p.next())
-	default:
-		tok := p.next()
-		p.errors = append(p.errors, fmt.Errorf("%d:%d: unsupported type with %v %q", tok.Line, tok.Column, tok.Kind, tok.Value))
-		btyp.From = tok
-		btyp.Reason = "unexpected type name"
-		bad = true
+// parseGenDecl returns grouped const or var declarations.
+// In const groups, a name alone repeats the type and the value
+// of the previous declaration and each constant records its index
+// in the group as its iota
+func (p *Parser) parseGenDecl(kw token.Token) ast.Decl {
+	g := &ast.GenDecl{
+		Keyword: kw,
+		LParen:  p.expect(token.LParen, "expected '('"),
 	}
 
-	return typ, btyp, bad
+	var prev *ast.ConstDecl
+	for p.kind() != token.RParen && p.kind() != token.EOF && !p.cancelled() {
+		var spec ast.Decl
+		switch {
+		case kw.Kind == token.KWVar:
+			spec = p.parseVarSpec(token.Token{})
+
+		case p.isImplicitConst():
+			name := p.expectValidIdent(token.Ident, true, "expected constant name")
+			if prev == nil {
+				p.errors = append(p.errors, fmt.Errorf("%d:%d: expected type and value for the first constant of the group, got %v %q", name.Line, name.Column, name.Kind, name.Value))
+				spec = &ast.BadDecl{From: name, To: name, Reason: "missing type and value"}
+				break
+			}
+			spec = &ast.ConstDecl{Name: name, Type: prev.Type, Init: prev.Init, Implicit: true}
+
+		default:
+			spec = p.parseConstSpec(token.Token{})
+		}
+
+		if c, ok := spec.(*ast.ConstDecl); ok {
+			c.Iota = len(g.Specs)
+			prev = c
+		}
+		g.Specs = append(g.Specs, spec)
+
+		if p.kind() == token.SemiComma {
+			_ = p.next()
+			continue
+		}
+
+		if p.kind() == token.RParen {
+			break
+		}
+
+		if p.newlineSincePrev() {
+			continue
+		}
+
+		p.errors = append(p.errors, fmt.Errorf("%d:%d: expected ';' or newline after declaration, got %v %q", p.peek().Line, p.peek().Column, p.peek().Kind, p.peek().Value))
+		p.consumeTo(token.RParen)
+	}
+	g.RParen = p.expect(token.RParen, "expected ')'")
+
+	return g
+}
+
+// isImplicitConst returns true when the current constant
+// of the group only has a name
+func (p *Parser) isImplicitConst() bool {
+	if p.kind() != token.Ident {
+		return false
+	}
+
+	next := p.peekNext(p.position + 1)
+	return next.Kind == token.SemiComma || next.Kind == token.RParen || next.Line > p.peek().Line
+}
+
+// parseVarInit returns a variable initial value
+func (p *Parser) parseVarInit() ast.Expr {
+	if p.kind() == token.LBracket {
+		// []string{} or []byte(s)
+		typ := p.parseType()
+		if p.kind() == token.LParen {
+			return p.parseExprFrom(p.parseConversionExpr(typ), LOWEST)
+		}
+		return p.parseSliceElements(typ)
+	}
+	return p.parseExpr(LOWEST)
 }
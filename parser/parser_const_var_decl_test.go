@@ -1,6 +1,7 @@
 package parser
 
 import (
+	"context"
 	"testing"
 
 	"github.com/orilang/gori/ast"
@@ -202,3 +203,319 @@ func TestParser_parse_var_decl(t *testing.T) {
 		assert.Equal(0, len(parser.errors))
 	})
 }
+
+func TestParser_parse_gen_decl(t *testing.T) {
+	assert := assert.New(t)
+
+	t.Run("const_group_iota", func(t *testing.T) {
+		lex, err := lexer.NewLexer(lexer.Config{StringOnly: true})
+		assert.Nil(err)
+		data := `package main
+
+const (
+  A int = iota
+  B
+  C; D
+)
+`
+		result := `File
+ Package: "package" @1:1 (kind=8)
+ Name: "main" @1:9 (kind=3)
+ Decls
+  GenDecl
+   Keyword: "const" @3:1 (kind=23)
+   LParen: "(" @3:7 (kind=39)
+   Specs
+    ConstDecl
+     Name: "A" @4:3 (kind=3)
+     Type
+      NamedType
+       Ident: "int" @4:5 (kind=12)
+     Eq: "=" @4:9 (kind=49)
+     Init
+      IdentExpr
+       Name: "iota" @4:11 (kind=3)
+    ConstDecl
+     Name: "B" @5:3 (kind=3)
+     Implicit: true
+     Type
+      NamedType
+       Ident: "int" @4:5 (kind=12)
+     Init
+      IdentExpr
+       Name: "iota" @4:11 (kind=3)
+    ConstDecl
+     Name: "C" @6:3 (kind=3)
+     Implicit: true
+     Type
+      NamedType
+       Ident: "int" @4:5 (kind=12)
+     Init
+      IdentExpr
+       Name: "iota" @4:11 (kind=3)
+    ConstDecl
+     Name: "D" @6:6 (kind=3)
+     Implicit: true
+     Type
+      NamedType
+       Ident: "int" @4:5 (kind=12)
+     Init
+      IdentExpr
+       Name: "iota" @4:11 (kind=3)
+   RParen: ")" @7:1 (kind=40)
+`
+		parser := New(lex.FetchTokensFromString(data))
+		pr, err := parser.ParseFile(context.Background())
+		assert.Nil(err)
+		assert.Equal(result, ast.Dump(pr))
+		assert.Equal(0, len(parser.errors))
+	})
+
+	t.Run("const_group_iota_index", func(t *testing.T) {
+		lex, err := lexer.NewLexer(lexer.Config{StringOnly: true})
+		assert.Nil(err)
+		data := `package main
+
+const ( A int = iota; B; C )
+`
+		parser := New(lex.FetchTokensFromString(data))
+		pr, err := parser.ParseFile(context.Background())
+		assert.Nil(err)
+		assert.Equal(0, len(parser.errors))
+
+		g := pr.Decls[0].(*ast.GenDecl)
+		if assert.Equal(3, len(g.Specs)) {
+			for i, name := range []string{"A", "B", "C"} {
+				c := g.Specs[i].(*ast.ConstDecl)
+				assert.Equal(name, c.Name.Value)
+				assert.Equal(i, c.Iota)
+			}
+		}
+	})
+
+	t.Run("const_group_explicit", func(t *testing.T) {
+		lex, err := lexer.NewLexer(lexer.Config{StringOnly: true})
+		assert.Nil(err)
+		data := `package main
+
+const (
+  A int = 1 << iota
+  B
+  C string = "c"
+  D
+)
+`
+		result := `File
+ Package: "package" @1:1 (kind=8)
+ Name: "main" @1:9 (kind=3)
+ Decls
+  GenDecl
+   Keyword: "const" @3:1 (kind=23)
+   LParen: "(" @3:7 (kind=39)
+   Specs
+    ConstDecl
+     Name: "A" @4:3 (kind=3)
+     Type
+      NamedType
+       Ident: "int" @4:5 (kind=12)
+     Eq: "=" @4:9 (kind=49)
+     Init
+      BinaryExpr
+       IntLitExpr
+        Value: "1" @4:11 (kind=4)
+       Operator: "<" @4:13 (kind=64)
+         BadExpr at @4:14 reason=unexpected prefix expression value="<"
+   RParen: ")" @8:1 (kind=40)
+`
+		parser := New(lex.FetchTokensFromString(data))
+		pr, err := parser.ParseFile(context.Background())
+		assert.Nil(err)
+		assert.Equal(result, ast.Dump(pr))
+		assert.Equal(2, len(parser.errors))
+	})
+
+	t.Run("var_group_file", func(t *testing.T) {
+		lex, err := lexer.NewLexer(lexer.Config{StringOnly: true})
+		assert.Nil(err)
+		data := `package main
+
+var (
+  a int = 1
+  b, c string = "b", "c"
+)
+`
+		result := `File
+ Package: "package" @1:1 (kind=8)
+ Name: "main" @1:9 (kind=3)
+ Decls
+  GenDecl
+   Keyword: "var" @3:1 (kind=11)
+   LParen: "(" @3:5 (kind=39)
+   Specs
+    VarDecl
+     Name: "a" @4:3 (kind=3)
+     Type
+      NamedType
+       Ident: "int" @4:5 (kind=12)
+     Eq: "=" @4:9 (kind=49)
+     Init
+      IntLitExpr
+       Value: "1" @4:11 (kind=4)
+    VarDecl
+     Name: "b" @5:3 (kind=3)
+     Name: "c" @5:6 (kind=3)
+     Type
+      NamedType
+       Ident: "string" @5:8 (kind=24)
+     Eq: "=" @5:15 (kind=49)
+     Init
+      StringLitExpr
+       Value: "b" @5:17 (kind=6)
+      StringLitExpr
+       Value: "c" @5:22 (kind=6)
+   RParen: ")" @6:1 (kind=40)
+`
+		parser := New(lex.FetchTokensFromString(data))
+		pr, err := parser.ParseFile(context.Background())
+		assert.Nil(err)
+		assert.Equal(result, ast.Dump(pr))
+		assert.Equal(0, len(parser.errors))
+	})
+
+	t.Run("var_group_block", func(t *testing.T) {
+		lex, err := lexer.NewLexer(lexer.Config{StringOnly: true})
+		assert.Nil(err)
+		data := `package main
+
+func main() {
+  var (
+    a int = 1; b int = 2
+  )
+  const (
+    X int = iota
+    Y
+  )
+}
+`
+		result := `File
+ Package: "package" @1:1 (kind=8)
+ Name: "main" @1:9 (kind=3)
+ Decls
+  FuncDecl
+   Function: "func" @3:1 (kind=10)
+   Name: "main" @3:6 (kind=3)
+   Params
+    (none)
+   Body
+    BlockStmt
+     LBrace: "{" @3:13 (kind=41)
+     Stmts
+      GenDecl
+       Keyword: "var" @4:3 (kind=11)
+       LParen: "(" @4:7 (kind=39)
+       Specs
+        VarDecl
+         Name: "a" @5:5 (kind=3)
+         Type
+          NamedType
+           Ident: "int" @5:7 (kind=12)
+         Eq: "=" @5:11 (kind=49)
+         Init
+          IntLitExpr
+           Value: "1" @5:13 (kind=4)
+        VarDecl
+         Name: "b" @5:16 (kind=3)
+         Type
+          NamedType
+           Ident: "int" @5:18 (kind=12)
+         Eq: "=" @5:22 (kind=49)
+         Init
+          IntLitExpr
+           Value: "2" @5:24 (kind=4)
+       RParen: ")" @6:3 (kind=40)
+      GenDecl
+       Keyword: "const" @7:3 (kind=23)
+       LParen: "(" @7:9 (kind=39)
+       Specs
+        ConstDecl
+         Name: "X" @8:5 (kind=3)
+         Type
+          NamedType
+           Ident: "int" @8:7 (kind=12)
+         Eq: "=" @8:11 (kind=49)
+         Init
+          IdentExpr
+           Name: "iota" @8:13 (kind=3)
+        ConstDecl
+         Name: "Y" @9:5 (kind=3)
+         Implicit: true
+         Type
+          NamedType
+           Ident: "int" @8:7 (kind=12)
+         Init
+          IdentExpr
+           Name: "iota" @8:13 (kind=3)
+       RParen: ")" @10:3 (kind=40)
+     RBrace: "}" @11:1 (kind=42)
+`
+		parser := New(lex.FetchTokensFromString(data))
+		pr, err := parser.ParseFile(context.Background())
+		assert.Nil(err)
+		assert.Equal(result, ast.Dump(pr))
+		assert.Equal(0, len(parser.errors))
+	})
+
+	t.Run("const_group_empty", func(t *testing.T) {
+		lex, err := lexer.NewLexer(lexer.Config{StringOnly: true})
+		assert.Nil(err)
+		data := `package main
+
+const ()
+`
+		result := `File
+ Package: "package" @1:1 (kind=8)
+ Name: "main" @1:9 (kind=3)
+ Decls
+  GenDecl
+   Keyword: "const" @3:1 (kind=23)
+   LParen: "(" @3:7 (kind=39)
+   RParen: ")" @3:8 (kind=40)
+`
+		parser := New(lex.FetchTokensFromString(data))
+		pr, err := parser.ParseFile(context.Background())
+		assert.Nil(err)
+		assert.Equal(result, ast.Dump(pr))
+		assert.Equal(0, len(parser.errors))
+	})
+
+	t.Run("errors", func(t *testing.T) {
+		tests := []struct {
+			input    string
+			expected string
+		}{
+			{
+				input:    "package main\n\nconst (\n  A\n  B int = 1\n)\n",
+				expected: `4:3: expected type and value for the first constant of the group, got 3 "A"`,
+			},
+			{
+				input:    "package main\n\nconst (\n  A int = 1 B int = 2\n)\n",
+				expected: `4:13: expected ';' or newline after declaration, got 3 "B"`,
+			},
+			{
+				input:    "package main\n\nvar (\n  a int = 1\n",
+				expected: `5:1 expected ')' (got 1 "")`,
+			},
+		}
+
+		for _, tc := range tests {
+			lex, err := lexer.NewLexer(lexer.Config{StringOnly: true})
+			assert.Nil(err)
+			parser := New(lex.FetchTokensFromString(tc.input))
+			_, err = parser.ParseFile(context.Background())
+			assert.Nil(err)
+			if assert.Greater(len(parser.errors), 0, tc.input) {
+				assert.Equal(tc.expected, parser.errors[0].Error(), tc.input)
+			}
+		}
+	})
+}
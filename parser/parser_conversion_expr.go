@@ -0,0 +1,37 @@
+package parser
+
+import (
+	"fmt"
+
+	"github.com/orilang/gori/ast"
+	"github.com/orilang/gori/token"
+)
+
+// parseConversionExpr returns the conversion of a single
+// expression to typ like int64(x) or []byte(s)
+func (p *Parser) parseConversionExpr(typ ast.Type) ast.Expr {
+	x := &ast.ConversionExpr{Type: typ}
+	if p.kind() != token.LParen {
+		tok := p.peek()
+		p.errors = append(p.errors, fmt.Errorf("%d:%d: expected '(' after conversion type, got %v %q", tok.Line, tok.Column, tok.Kind, tok.Value))
+		return &ast.BadExpr{From: typ.Start(), To: tok, Reason: "expected '(' after conversion type"}
+	}
+	x.LParen = p.next()
+
+	if p.kind() == token.RParen {
+		tok := p.peek()
+		p.errors = append(p.errors, fmt.Errorf("%d:%d: expected expression to convert, got %v %q", tok.Line, tok.Column, tok.Kind, tok.Value))
+		x.X = &ast.BadExpr{From: x.LParen, To: tok, Reason: "expected expression to convert"}
+		x.RParen = p.next()
+		return x
+	}
+
+	x.X = p.parseExpr(LOWEST)
+	if p.kind() == token.Comma {
+		tok := p.peek()
+		p.errors = append(p.errors, fmt.Errorf("%d:%d: conversion takes a single expression, got %v %q", tok.Line, tok.Column, tok.Kind, tok.Value))
+		p.consumeTo(token.RParen)
+	}
+	x.RParen = p.expect(token.RParen, "expected ')'")
+	return x
+}
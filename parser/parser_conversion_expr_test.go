@@ -0,0 +1,311 @@
+package parser
+
+import (
+	"context"
+	"testing"
+
+	"github.com/orilang/gori/ast"
+	"github.com/orilang/gori/lexer"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParser_conversion_expr(t *testing.T) {
+	assert := assert.New(t)
+
+	t.Run("nil", func(t *testing.T) {
+		lex, err := lexer.NewLexer(lexer.Config{StringOnly: true})
+		assert.Nil(err)
+		data := `package main
+
+var p Foo = nil
+
+func f() Foo {
+  return nil
+}
+`
+		result := `File
+ Package: "package" @1:1 (kind=8)
+ Name: "main" @1:9 (kind=3)
+ Decls
+  VarDecl
+   Var: "var" @3:1 (kind=11)
+   Name: "p" @3:5 (kind=3)
+   Type
+    NamedType
+     Ident: "Foo" @3:7 (kind=3)
+   Eq: "=" @3:11 (kind=49)
+   Init
+    NilExpr
+     Nil: "nil" @3:13 (kind=81)
+  FuncDecl
+   Function: "func" @5:1 (kind=10)
+   Name: "f" @5:6 (kind=3)
+   Params
+    (none)
+   Results
+     Param
+      Type
+       NamedType
+        Ident: "Foo" @5:10 (kind=3)
+   Body
+    BlockStmt
+     LBrace: "{" @5:14 (kind=41)
+     Stmts
+      ReturnStmt
+       Values
+        NilExpr
+         Nil: "nil" @6:10 (kind=81)
+     RBrace: "}" @7:1 (kind=42)
+`
+		parser := New(lex.FetchTokensFromString(data))
+		pr, err := parser.ParseFile(context.Background())
+		assert.Nil(err)
+		assert.Equal(result, ast.Dump(pr))
+		assert.Equal(0, len(parser.errors))
+	})
+
+	t.Run("builtin_type", func(t *testing.T) {
+		lex, err := lexer.NewLexer(lexer.Config{StringOnly: true})
+		assert.Nil(err)
+		data := `package main
+
+var a int64 = int64(b) + 1
+`
+		result := `File
+ Package: "package" @1:1 (kind=8)
+ Name: "main" @1:9 (kind=3)
+ Decls
+  VarDecl
+   Var: "var" @3:1 (kind=11)
+   Name: "a" @3:5 (kind=3)
+   Type
+    NamedType
+     Ident: "int64" @3:7 (kind=15)
+   Eq: "=" @3:13 (kind=49)
+   Init
+    BinaryExpr
+     ConversionExpr
+      Type
+       NamedType
+        Ident: "int64" @3:15 (kind=15)
+      LParent: "(" @3:20 (kind=39)
+      IdentExpr
+       Name: "b" @3:21 (kind=3)
+      RParent: ")" @3:22 (kind=40)
+     Operator: "+" @3:24 (kind=51)
+     IntLitExpr
+      Value: "1" @3:26 (kind=4)
+`
+		parser := New(lex.FetchTokensFromString(data))
+		pr, err := parser.ParseFile(context.Background())
+		assert.Nil(err)
+		assert.Equal(result, ast.Dump(pr))
+		assert.Equal(0, len(parser.errors))
+	})
+
+	t.Run("slice_and_map", func(t *testing.T) {
+		lex, err := lexer.NewLexer(lexer.Config{StringOnly: true})
+		assert.Nil(err)
+		data := `package main
+
+var a []byte = []byte(s)
+
+func f() {
+  b := map[string]int(m)
+  c := []byte(s)[0]
+}
+`
+		result := `File
+ Package: "package" @1:1 (kind=8)
+ Name: "main" @1:9 (kind=3)
+ Decls
+  VarDecl
+   Var: "var" @3:1 (kind=11)
+   Name: "a" @3:5 (kind=3)
+   Type
+    SliceType:
+     LBracket: "[" @3:7 (kind=43)
+     RBracket: "]" @3:8 (kind=44)
+     NamedType
+      Ident: "byte" @3:9 (kind=3)
+   Eq: "=" @3:14 (kind=49)
+   Init
+    ConversionExpr
+     Type
+      SliceType:
+       LBracket: "[" @3:16 (kind=43)
+       RBracket: "]" @3:17 (kind=44)
+       NamedType
+        Ident: "byte" @3:18 (kind=3)
+     LParent: "(" @3:22 (kind=39)
+     IdentExpr
+      Name: "s" @3:23 (kind=3)
+     RParent: ")" @3:24 (kind=40)
+  FuncDecl
+   Function: "func" @5:1 (kind=10)
+   Name: "f" @5:6 (kind=3)
+   Params
+    (none)
+   Body
+    BlockStmt
+     LBrace: "{" @5:10 (kind=41)
+     Stmts
+      AssignStmt
+       Left
+        IdentExpr
+         Name: "b" @6:3 (kind=3)
+       Operator: ":=" @6:5 (kind=50)
+       Right
+        ConversionExpr
+         Type
+          MapType:
+           Map: "map" @6:8 (kind=79)
+           LBracket: "[" @6:11 (kind=43)
+           KeyType:
+            NamedType
+             Ident: "string" @6:12 (kind=24)
+           RBracket: "]" @6:18 (kind=44)
+           ValueType:
+            NamedType
+             Ident: "int" @6:19 (kind=12)
+         LParent: "(" @6:22 (kind=39)
+         IdentExpr
+          Name: "m" @6:23 (kind=3)
+         RParent: ")" @6:24 (kind=40)
+      AssignStmt
+       Left
+        IdentExpr
+         Name: "c" @7:3 (kind=3)
+       Operator: ":=" @7:5 (kind=50)
+       Right
+        IndexExpr
+         X:
+         ConversionExpr
+          Type
+           SliceType:
+            LBracket: "[" @7:8 (kind=43)
+            RBracket: "]" @7:9 (kind=44)
+            NamedType
+             Ident: "byte" @7:10 (kind=3)
+          LParent: "(" @7:14 (kind=39)
+          IdentExpr
+           Name: "s" @7:15 (kind=3)
+          RParent: ")" @7:16 (kind=40)
+         LBracket: "[" @7:17 (kind=43)
+          IntLitExpr
+           Value: "0" @7:18 (kind=4)
+         RBracket: "]" @7:19 (kind=44)
+     RBrace: "}" @8:1 (kind=42)
+`
+		parser := New(lex.FetchTokensFromString(data))
+		pr, err := parser.ParseFile(context.Background())
+		assert.Nil(err)
+		assert.Equal(result, ast.Dump(pr))
+		assert.Equal(0, len(parser.errors))
+	})
+
+	t.Run("builtin_calls", func(t *testing.T) {
+		lex, err := lexer.NewLexer(lexer.Config{StringOnly: true})
+		assert.Nil(err)
+		data := `package main
+
+func f() {
+  n := len(make([]int, 2))
+  g(nil, string(n))
+}
+`
+		result := `File
+ Package: "package" @1:1 (kind=8)
+ Name: "main" @1:9 (kind=3)
+ Decls
+  FuncDecl
+   Function: "func" @3:1 (kind=10)
+   Name: "f" @3:6 (kind=3)
+   Params
+    (none)
+   Body
+    BlockStmt
+     LBrace: "{" @3:10 (kind=41)
+     Stmts
+      AssignStmt
+       Left
+        IdentExpr
+         Name: "n" @4:3 (kind=3)
+       Operator: ":=" @4:5 (kind=50)
+       Right
+        CallExpr
+         Callee
+          IdentExpr
+           Name: "len" @4:8 (kind=3)
+         LParent: "(" @4:11 (kind=39)
+         Args:
+          MakeExpr:
+           Make: "make" @4:12 (kind=3)
+           LParen: "(" @4:16 (kind=39)
+           SliceType:
+            LBracket: "[" @4:17 (kind=43)
+            RBracket: "]" @4:18 (kind=44)
+            NamedType
+             Ident: "int" @4:19 (kind=12)
+           Size:
+            IntLitExpr
+             Value: "2" @4:24 (kind=4)
+           RParen: ")" @4:25 (kind=40)
+         RParent: ")" @4:26 (kind=40)
+      CallExpr
+       Callee
+        IdentExpr
+         Name: "g" @5:3 (kind=3)
+       LParent: "(" @5:4 (kind=39)
+       Args:
+        NilExpr
+         Nil: "nil" @5:5 (kind=81)
+        ConversionExpr
+         Type
+          NamedType
+           Ident: "string" @5:10 (kind=24)
+         LParent: "(" @5:16 (kind=39)
+         IdentExpr
+          Name: "n" @5:17 (kind=3)
+         RParent: ")" @5:18 (kind=40)
+       RParent: ")" @5:19 (kind=40)
+     RBrace: "}" @6:1 (kind=42)
+`
+		parser := New(lex.FetchTokensFromString(data))
+		pr, err := parser.ParseFile(context.Background())
+		assert.Nil(err)
+		assert.Equal(result, ast.Dump(pr))
+		assert.Equal(0, len(parser.errors))
+	})
+
+	t.Run("errors", func(t *testing.T) {
+		tests := []struct {
+			input    string
+			expected string
+		}{
+			{
+				input:    "package main\n\nvar a int = int64()\n",
+				expected: `3:19: expected expression to convert, got 40 ")"`,
+			},
+			{
+				input:    "package main\n\nvar a int = int64(b, c)\n",
+				expected: `3:20: conversion takes a single expression, got 45 ","`,
+			},
+			{
+				input:    "package main\n\nvar a int = int64 + 1\n",
+				expected: `3:19: expected '(' after conversion type, got 51 "+"`,
+			},
+		}
+
+		for _, tc := range tests {
+			lex, err := lexer.NewLexer(lexer.Config{StringOnly: true})
+			assert.Nil(err)
+			parser := New(lex.FetchTokensFromString(tc.input))
+			_, err = parser.ParseFile(context.Background())
+			assert.Nil(err)
+			if assert.Greater(len(parser.errors), 0, tc.input) {
+				assert.Equal(tc.expected, parser.errors[0].Error(), tc.input)
+			}
+		}
+	})
+}
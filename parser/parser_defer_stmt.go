@@ -0,0 +1,37 @@
+package parser
+
+import (
+	"fmt"
+
+	"github.com/orilang/gori/ast"
+	"github.com/orilang/gori/token"
+)
+
+// parseDeferStmt returns a call deferred until the function returns
+// like defer f.Close().
+// Deferred calls are not allowed in comptime functions
+func (p *Parser) parseDeferStmt() ast.Stmt {
+	kw := p.expect(token.KWDefer, "expected 'defer'")
+	if p.comptime {
+		p.errors = append(p.errors, fmt.Errorf("%d:%d: defer is not allowed inside comptime, got %v %q", kw.Line, kw.Column, kw.Kind, kw.Value))
+	}
+
+	if p.kind() == token.RBrace || p.kind() == token.EOF || p.kind() == token.SemiComma || p.peek().Line > kw.Line {
+		p.errors = append(p.errors, fmt.Errorf("%d:%d: expected function call after 'defer', got %v %q", p.peek().Line, p.peek().Column, p.peek().Kind, p.peek().Value))
+		return &ast.BadStmt{From: kw, To: p.peek(), Reason: "expected function call"}
+	}
+
+	x := p.parseExpr(LOWEST)
+	if _, ok := x.(*ast.CallExpr); !ok {
+		p.errors = append(p.errors, fmt.Errorf("%d:%d: expression in defer must be function call, got %v %q", x.Start().Line, x.Start().Column, x.Start().Kind, x.Start().Value))
+		return &ast.BadStmt{From: kw, To: x.End(), Reason: "expected function call"}
+	}
+
+	if p.kind() == token.SemiComma {
+		_ = p.next()
+	}
+	return &ast.DeferStmt{
+		Defer: kw,
+		Call:  x,
+	}
+}
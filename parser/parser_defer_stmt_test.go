@@ -0,0 +1,118 @@
+package parser
+
+import (
+	"context"
+	"testing"
+
+	"github.com/orilang/gori/ast"
+	"github.com/orilang/gori/lexer"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParser_defer_stmt(t *testing.T) {
+	assert := assert.New(t)
+
+	t.Run("defer_call", func(t *testing.T) {
+		lex, err := lexer.NewLexer(lexer.Config{StringOnly: true})
+		assert.Nil(err)
+		data := `package main
+
+func main() {
+  f := open("a")
+  defer f.Close()
+  defer print("done");
+}
+`
+		result := `File
+ Package: "package" @1:1 (kind=8)
+ Name: "main" @1:9 (kind=3)
+ Decls
+  FuncDecl
+   Function: "func" @3:1 (kind=10)
+   Name: "main" @3:6 (kind=3)
+   Params
+    (none)
+   Body
+    BlockStmt
+     LBrace: "{" @3:13 (kind=41)
+     Stmts
+      AssignStmt
+       Left
+        IdentExpr
+         Name: "f" @4:3 (kind=3)
+       Operator: ":=" @4:5 (kind=50)
+       Right
+        CallExpr
+         Callee
+          IdentExpr
+           Name: "open" @4:8 (kind=3)
+         LParent: "(" @4:12 (kind=39)
+         Args:
+          StringLitExpr
+           Value: "a" @4:13 (kind=6)
+         RParent: ")" @4:16 (kind=40)
+      DeferStmt
+       Defer: "defer" @5:3 (kind=85)
+       Call
+        CallExpr
+         Callee
+          SelectorExpr
+           X:
+            IdentExpr
+             Name: "f" @5:9 (kind=3)
+           Dot: "." @5:10 (kind=48)
+           Selector: "Close" @5:11 (kind=3)
+         LParent: "(" @5:16 (kind=39)
+         RParent: ")" @5:17 (kind=40)
+      DeferStmt
+       Defer: "defer" @6:3 (kind=85)
+       Call
+        CallExpr
+         Callee
+          IdentExpr
+           Name: "print" @6:9 (kind=3)
+         LParent: "(" @6:14 (kind=39)
+         Args:
+          StringLitExpr
+           Value: "done" @6:15 (kind=6)
+         RParent: ")" @6:21 (kind=40)
+     RBrace: "}" @7:1 (kind=42)
+`
+		parser := New(lex.FetchTokensFromString(data))
+		pr, err := parser.ParseFile(context.Background())
+		assert.Nil(err)
+		assert.Equal(result, ast.Dump(pr))
+		assert.Equal(0, len(parser.errors))
+	})
+
+	t.Run("errors", func(t *testing.T) {
+		tests := []struct {
+			input    string
+			expected string
+		}{
+			{
+				input:    "package main\n\nfunc main() {\n  defer x\n}\n",
+				expected: `4:9: expression in defer must be function call, got 3 "x"`,
+			},
+			{
+				input:    "package main\n\nfunc main() {\n  defer\n}\n",
+				expected: `5:1: expected function call after 'defer', got 42 "}"`,
+			},
+			{
+				input:    "package main\n\ncomptime func main() {\n  defer f()\n}\n",
+				expected: `4:3: defer is not allowed inside comptime, got 85 "defer"`,
+			},
+		}
+
+		for _, tc := range tests {
+			lex, err := lexer.NewLexer(lexer.Config{StringOnly: true})
+			assert.Nil(err)
+			parser := New(lex.FetchTokensFromString(tc.input))
+			_, err = parser.ParseFile(context.Background())
+			assert.Nil(err)
+			if assert.Greater(len(parser.errors), 0, tc.input) {
+				assert.Equal(tc.expected, parser.errors[0].Error(), tc.input)
+			}
+		}
+	})
+}
@@ -1,6 +1,7 @@
 package parser
 
 import (
+	"context"
 	"testing"
 
 	"github.com/orilang/gori/ast"
@@ -19,7 +20,8 @@ func TestParser_parse_file(t *testing.T) {
 func main(){}
 `
 		parser := New(lex.FetchTokensFromString(data))
-		pr := parser.ParseFile()
+		pr, err := parser.ParseFile(context.Background())
+		assert.Nil(err)
 		assert.NotNil(pr)
 		assert.Greater(len(parser.errors), 0)
 	})
@@ -36,7 +38,8 @@ func main(){
 }
 `
 		parser := New(lex.FetchTokensFromString(data))
-		pr := parser.ParseFile()
+		pr, err := parser.ParseFile(context.Background())
+		assert.Nil(err)
 		result := `File
  Package: "package" @1:1 (kind=8)
  Name: "main" @1:9 (kind=3)
@@ -92,7 +95,8 @@ func main(){
 		data := `package main
 `
 		parser := New(lex.FetchTokensFromString(data))
-		pr := parser.ParseFile()
+		pr, err := parser.ParseFile(context.Background())
+		assert.Nil(err)
 		result := `File
  Package: "package" @1:1 (kind=8)
  Name: "main" @1:9 (kind=3)
@@ -109,7 +113,8 @@ func main(){
 func x(a int, b string, c string){}
 `
 		parser := New(lex.FetchTokensFromString(data))
-		pr := parser.ParseFile()
+		pr, err := parser.ParseFile(context.Background())
+		assert.Nil(err)
 		result := `File
  Package: "package" @1:1 (kind=8)
  Name: "dummy" @1:9 (kind=3)
@@ -147,7 +152,8 @@ func x(a int, b string, c string){}
 func x(, a string){}
 `
 		parser := New(lex.FetchTokensFromString(data))
-		pr := parser.ParseFile()
+		pr, err := parser.ParseFile(context.Background())
+		assert.Nil(err)
 		assert.NotNil(pr)
 		assert.Greater(len(parser.errors), 0)
 	})
@@ -161,7 +167,8 @@ func x(a int,){}
 }
 `
 		parser := New(lex.FetchTokensFromString(data))
-		pr := parser.ParseFile()
+		pr, err := parser.ParseFile(context.Background())
+		assert.Nil(err)
 		assert.NotNil(pr)
 		assert.Greater(len(parser.errors), 0)
 	})
@@ -174,7 +181,8 @@ func x(a int,){}
 func x(a int,,,, a int){}
 `
 		parser := New(lex.FetchTokensFromString(data))
-		pr := parser.ParseFile()
+		pr, err := parser.ParseFile(context.Background())
+		assert.Nil(err)
 		assert.NotNil(pr)
 		assert.Greater(len(parser.errors), 0)
 	})
@@ -187,7 +195,8 @@ func x(a int,,,, a int){}
 func x(a int a){}
 `
 		parser := New(lex.FetchTokensFromString(data))
-		pr := parser.ParseFile()
+		pr, err := parser.ParseFile(context.Background())
+		assert.Nil(err)
 		assert.NotNil(pr)
 		assert.Greater(len(parser.errors), 0)
 	})
@@ -50,6 +50,7 @@ func (p *Parser) parseForStmtExpr() ast.Stmt {
 			return &ast.BadStmt{From: ftok, To: tok, Reason: "expected expression not ','"}
 		}
 
+		rstmt.KeyQualifier = p.parseQualifier()
 		k1 := p.expectValidIdent(token.Ident, false, "expected 'identifier'")
 		if k1.Kind != token.Ident {
 			return &ast.BadStmt{From: ftok, To: k1, Reason: "expected identifier"}
@@ -60,6 +61,7 @@ func (p *Parser) parseForStmtExpr() ast.Stmt {
 		if p.kind() == token.Comma {
 			_ = p.next()
 			rstmt.Key = xk1
+			rstmt.ValueQualifier = p.parseQualifier()
 			k2 := p.expectValidIdent(token.Ident, false, "expected 'identifier'")
 			if k2.Kind != token.Ident {
 				return &ast.BadStmt{From: ftok, To: k2, Reason: "expected identifier"}
@@ -200,26 +202,24 @@ func (p *Parser) parseForBlockStmt() *ast.BlockStmt {
 	return p.parseBlock()
 }
 
-// parseBreakStmt returns expressions for parseStmt func
+// parseBreakStmt returns expressions for parseStmt func.
+// Without label, it must be inside a for loop
 func (p *Parser) parseBreakStmt() ast.Stmt {
-	if p.loopDepth == 0 {
+	if p.loopDepth == 0 && !p.hasBranchLabel() {
 		tok := p.next()
 		p.errors = append(p.errors, fmt.Errorf("%d:%d: unexpected break expression outside for loop, got %v %q", tok.Line, tok.Column, tok.Kind, tok.Value))
 		return &ast.BadStmt{From: tok, Reason: "expected 'break' inside 'for' loop"}
 	}
 
 	kw := p.expect(token.KWBreak, "expected 'break'")
-	if p.kind() == token.Comment {
-		_ = p.next()
-	}
-	// we do not accept labels for now so anything unauthorized is rejected
-	// maybe labels will be supported later
+	lbl := p.parseBranchLabel(kw)
 	if p.kind() == token.RBrace || p.kind() == token.EOF || p.kind() == token.SemiComma || p.peek().Line > kw.Line {
 		if p.kind() == token.SemiComma {
 			_ = p.next()
 		}
 		return &ast.BreakStmt{
 			Break: kw,
+			Label: lbl,
 		}
 	}
 
@@ -227,18 +227,17 @@ func (p *Parser) parseBreakStmt() ast.Stmt {
 	return &ast.BadStmt{From: p.peek(), Reason: "expected '}' or 'EOF' or new line"}
 }
 
-// parseContinueStmt returns expressions for parseStmt func
+// parseContinueStmt returns expressions for parseStmt func.
+// Without label, it must be inside a for loop
 func (p *Parser) parseContinueStmt() ast.Stmt {
-	if p.loopDepth == 0 {
+	if p.loopDepth == 0 && !p.hasBranchLabel() {
 		tok := p.next()
 		p.errors = append(p.errors, fmt.Errorf("%d:%d: unexpected continue expression outside for loop, got %v %q", tok.Line, tok.Column, tok.Kind, tok.Value))
 		return &ast.BadStmt{From: tok, Reason: "expected 'continue' inside 'for' loop"}
 	}
 
 	kw := p.expect(token.KWContinue, "expected 'continue'")
-	if p.kind() == token.Comment {
-		_ = p.next()
-	}
+	lbl := p.parseBranchLabel(kw)
 	// any unauthorized statement is rejected after 'continue'
 	if p.kind() == token.RBrace || p.kind() == token.EOF || p.kind() == token.SemiComma || p.peek().Line > kw.Line {
 		if p.kind() == token.SemiComma {
@@ -246,6 +245,7 @@ func (p *Parser) parseContinueStmt() ast.Stmt {
 		}
 		return &ast.ContinueStmt{
 			Continue: kw,
+			Label:    lbl,
 		}
 	}
 
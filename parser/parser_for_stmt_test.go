@@ -1,6 +1,7 @@
 package parser
 
 import (
+	"context"
 	"testing"
 
 	"github.com/orilang/gori/ast"
@@ -23,7 +24,8 @@ func x(){
 }
 `
 		parser := New(lex.FetchTokensFromString(data))
-		pr := parser.ParseFile()
+		pr, err := parser.ParseFile(context.Background())
+		assert.Nil(err)
 		result := `File
  Package: "package" @1:1 (kind=8)
  Name: "main" @1:9 (kind=3)
@@ -63,7 +65,8 @@ func x(){
 }
 `
 		parser := New(lex.FetchTokensFromString(data))
-		pr := parser.ParseFile()
+		pr, err := parser.ParseFile(context.Background())
+		assert.Nil(err)
 		result := `File
  Package: "package" @1:1 (kind=8)
  Name: "main" @1:9 (kind=3)
@@ -97,7 +100,8 @@ func x(){
 }
 `
 		parser := New(lex.FetchTokensFromString(data))
-		pr := parser.ParseFile()
+		pr, err := parser.ParseFile(context.Background())
+		assert.Nil(err)
 		result := `File
  Package: "package" @1:1 (kind=8)
  Name: "main" @1:9 (kind=3)
@@ -144,7 +148,8 @@ func x(){
 }
 `
 		parser := New(lex.FetchTokensFromString(data))
-		pr := parser.ParseFile()
+		pr, err := parser.ParseFile(context.Background())
+		assert.Nil(err)
 		result := `File
  Package: "package" @1:1 (kind=8)
  Name: "main" @1:9 (kind=3)
@@ -185,7 +190,8 @@ func x(){
 }
 `
 		parser := New(lex.FetchTokensFromString(data))
-		pr := parser.ParseFile()
+		pr, err := parser.ParseFile(context.Background())
+		assert.Nil(err)
 		result := `File
  Package: "package" @1:1 (kind=8)
  Name: "main" @1:9 (kind=3)
@@ -250,7 +256,8 @@ func x(){
 }
 `
 		parser := New(lex.FetchTokensFromString(data))
-		pr := parser.ParseFile()
+		pr, err := parser.ParseFile(context.Background())
+		assert.Nil(err)
 		result := `File
  Package: "package" @1:1 (kind=8)
  Name: "main" @1:9 (kind=3)
@@ -309,7 +316,8 @@ func x(){
 }
 `
 		parser := New(lex.FetchTokensFromString(data))
-		pr := parser.ParseFile()
+		pr, err := parser.ParseFile(context.Background())
+		assert.Nil(err)
 		result := `File
  Package: "package" @1:1 (kind=8)
  Name: "main" @1:9 (kind=3)
@@ -373,7 +381,8 @@ func x(){
 }
 `
 		parser := New(lex.FetchTokensFromString(data))
-		pr := parser.ParseFile()
+		pr, err := parser.ParseFile(context.Background())
+		assert.Nil(err)
 		result := `File
  Package: "package" @1:1 (kind=8)
  Name: "main" @1:9 (kind=3)
@@ -426,7 +435,8 @@ func x(){
 }
 `
 		parser := New(lex.FetchTokensFromString(data))
-		pr := parser.ParseFile()
+		pr, err := parser.ParseFile(context.Background())
+		assert.Nil(err)
 		result := `File
  Package: "package" @1:1 (kind=8)
  Name: "main" @1:9 (kind=3)
@@ -480,7 +490,8 @@ func x(){
 }
 `
 		parser := New(lex.FetchTokensFromString(data))
-		pr := parser.ParseFile()
+		pr, err := parser.ParseFile(context.Background())
+		assert.Nil(err)
 		result := `File
  Package: "package" @1:1 (kind=8)
  Name: "main" @1:9 (kind=3)
@@ -519,7 +530,8 @@ func x(){
 }
 `
 		parser := New(lex.FetchTokensFromString(data))
-		pr := parser.ParseFile()
+		pr, err := parser.ParseFile(context.Background())
+		assert.Nil(err)
 		result := `File
  Package: "package" @1:1 (kind=8)
  Name: "main" @1:9 (kind=3)
@@ -561,7 +573,8 @@ func x(){
 }
 `
 		parser := New(lex.FetchTokensFromString(data))
-		pr := parser.ParseFile()
+		pr, err := parser.ParseFile(context.Background())
+		assert.Nil(err)
 		result := `File
  Package: "package" @1:1 (kind=8)
  Name: "main" @1:9 (kind=3)
@@ -599,7 +612,8 @@ func x(){
 }
 `
 		parser := New(lex.FetchTokensFromString(data))
-		pr := parser.ParseFile()
+		pr, err := parser.ParseFile(context.Background())
+		assert.Nil(err)
 		result := `File
  Package: "package" @1:1 (kind=8)
  Name: "main" @1:9 (kind=3)
@@ -643,7 +657,8 @@ func x(){
 }
 `
 		parser := New(lex.FetchTokensFromString(data))
-		pr := parser.ParseFile()
+		pr, err := parser.ParseFile(context.Background())
+		assert.Nil(err)
 		result := `File
  Package: "package" @1:1 (kind=8)
  Name: "main" @1:9 (kind=3)
@@ -684,7 +699,8 @@ func x(){
 }
 `
 		parser := New(lex.FetchTokensFromString(data))
-		pr := parser.ParseFile()
+		pr, err := parser.ParseFile(context.Background())
+		assert.Nil(err)
 		result := `File
  Package: "package" @1:1 (kind=8)
  Name: "main" @1:9 (kind=3)
@@ -739,7 +755,8 @@ func x(){
 }
 `
 		parser := New(lex.FetchTokensFromString(data))
-		pr := parser.ParseFile()
+		pr, err := parser.ParseFile(context.Background())
+		assert.Nil(err)
 		assert.NotNil(pr)
 		assert.Greater(len(parser.errors), 0)
 	})
@@ -756,7 +773,8 @@ func x(){
 }
 `
 		parser := New(lex.FetchTokensFromString(data))
-		pr := parser.ParseFile()
+		pr, err := parser.ParseFile(context.Background())
+		assert.Nil(err)
 		result := `File
  Package: "package" @1:1 (kind=8)
  Name: "main" @1:9 (kind=3)
@@ -796,7 +814,8 @@ func x(){
 }
 `
 		parser := New(lex.FetchTokensFromString(data))
-		pr := parser.ParseFile()
+		pr, err := parser.ParseFile(context.Background())
+		assert.Nil(err)
 		result := `File
  Package: "package" @1:1 (kind=8)
  Name: "main" @1:9 (kind=3)
@@ -838,7 +857,8 @@ func x(){
 }
 `
 		parser := New(lex.FetchTokensFromString(data))
-		pr := parser.ParseFile()
+		pr, err := parser.ParseFile(context.Background())
+		assert.Nil(err)
 		result := `File
  Package: "package" @1:1 (kind=8)
  Name: "main" @1:9 (kind=3)
@@ -889,7 +909,8 @@ func x(){
 }
 `
 		parser := New(lex.FetchTokensFromString(data))
-		pr := parser.ParseFile()
+		pr, err := parser.ParseFile(context.Background())
+		assert.Nil(err)
 		result := `File
  Package: "package" @1:1 (kind=8)
  Name: "main" @1:9 (kind=3)
@@ -940,7 +961,8 @@ func x(){
 }
 `
 		parser := New(lex.FetchTokensFromString(data))
-		pr := parser.ParseFile()
+		pr, err := parser.ParseFile(context.Background())
+		assert.Nil(err)
 		result := `File
  Package: "package" @1:1 (kind=8)
  Name: "main" @1:9 (kind=3)
@@ -992,7 +1014,8 @@ func x(){
 }
 `
 		parser := New(lex.FetchTokensFromString(data))
-		pr := parser.ParseFile()
+		pr, err := parser.ParseFile(context.Background())
+		assert.Nil(err)
 		result := `File
  Package: "package" @1:1 (kind=8)
  Name: "main" @1:9 (kind=3)
@@ -1049,7 +1072,8 @@ func x(){
 }
 `
 		parser := New(lex.FetchTokensFromString(data))
-		pr := parser.ParseFile()
+		pr, err := parser.ParseFile(context.Background())
+		assert.Nil(err)
 		result := `File
  Package: "package" @1:1 (kind=8)
  Name: "main" @1:9 (kind=3)
@@ -1107,7 +1131,8 @@ func x(){
 }
 `
 		parser := New(lex.FetchTokensFromString(data))
-		pr := parser.ParseFile()
+		pr, err := parser.ParseFile(context.Background())
+		assert.Nil(err)
 		result := `File
  Package: "package" @1:1 (kind=8)
  Name: "main" @1:9 (kind=3)
@@ -1164,7 +1189,8 @@ func x(){
 }
 `
 		parser := New(lex.FetchTokensFromString(data))
-		pr := parser.ParseFile()
+		pr, err := parser.ParseFile(context.Background())
+		assert.Nil(err)
 		result := `File
  Package: "package" @1:1 (kind=8)
  Name: "main" @1:9 (kind=3)
@@ -1222,7 +1248,8 @@ func x(){
 }
 `
 		parser := New(lex.FetchTokensFromString(data))
-		pr := parser.ParseFile()
+		pr, err := parser.ParseFile(context.Background())
+		assert.Nil(err)
 		result := `File
  Package: "package" @1:1 (kind=8)
  Name: "main" @1:9 (kind=3)
@@ -1282,7 +1309,8 @@ func x(){
 }
 `
 		parser := New(lex.FetchTokensFromString(data))
-		pr := parser.ParseFile()
+		pr, err := parser.ParseFile(context.Background())
+		assert.Nil(err)
 		result := `File
  Package: "package" @1:1 (kind=8)
  Name: "main" @1:9 (kind=3)
@@ -1340,7 +1368,8 @@ func x(){
 }
 `
 		parser := New(lex.FetchTokensFromString(data))
-		pr := parser.ParseFile()
+		pr, err := parser.ParseFile(context.Background())
+		assert.Nil(err)
 		result := `File
  Package: "package" @1:1 (kind=8)
  Name: "main" @1:9 (kind=3)
@@ -1400,7 +1429,8 @@ func x(){
 }
 `
 		parser := New(lex.FetchTokensFromString(data))
-		pr := parser.ParseFile()
+		pr, err := parser.ParseFile(context.Background())
+		assert.Nil(err)
 		result := `File
  Package: "package" @1:1 (kind=8)
  Name: "main" @1:9 (kind=3)
@@ -1456,7 +1486,8 @@ func x(){
 }
 `
 		parser := New(lex.FetchTokensFromString(data))
-		pr := parser.ParseFile()
+		pr, err := parser.ParseFile(context.Background())
+		assert.Nil(err)
 		assert.NotNil(pr)
 		assert.Greater(len(parser.errors), 0)
 	})
@@ -1474,7 +1505,8 @@ func x(){
 }
 `
 		parser := New(lex.FetchTokensFromString(data))
-		pr := parser.ParseFile()
+		pr, err := parser.ParseFile(context.Background())
+		assert.Nil(err)
 		assert.NotNil(pr)
 		assert.Greater(len(parser.errors), 0)
 	})
@@ -1492,7 +1524,8 @@ func x(){
 }
 `
 		parser := New(lex.FetchTokensFromString(data))
-		pr := parser.ParseFile()
+		pr, err := parser.ParseFile(context.Background())
+		assert.Nil(err)
 		assert.NotNil(pr)
 		assert.Greater(len(parser.errors), 0)
 	})
@@ -1510,7 +1543,8 @@ func x(){
 }
 `
 		parser := New(lex.FetchTokensFromString(data))
-		pr := parser.ParseFile()
+		pr, err := parser.ParseFile(context.Background())
+		assert.Nil(err)
 		assert.NotNil(pr)
 		assert.Greater(len(parser.errors), 0)
 	})
@@ -1526,7 +1560,8 @@ func x(){
 }
 `
 		parser := New(lex.FetchTokensFromString(data))
-		pr := parser.ParseFile()
+		pr, err := parser.ParseFile(context.Background())
+		assert.Nil(err)
 		assert.NotNil(pr)
 		assert.Greater(len(parser.errors), 0)
 	})
@@ -1542,7 +1577,8 @@ func x(){
 }
 `
 		parser := New(lex.FetchTokensFromString(data))
-		pr := parser.ParseFile()
+		pr, err := parser.ParseFile(context.Background())
+		assert.Nil(err)
 		assert.NotNil(pr)
 		assert.Greater(len(parser.errors), 0)
 	})
@@ -1558,7 +1594,8 @@ func x(){
 }
 `
 		parser := New(lex.FetchTokensFromString(data))
-		pr := parser.ParseFile()
+		pr, err := parser.ParseFile(context.Background())
+		assert.Nil(err)
 		assert.NotNil(pr)
 		assert.Greater(len(parser.errors), 0)
 	})
@@ -1574,7 +1611,8 @@ func x(){
 }
 `
 		parser := New(lex.FetchTokensFromString(data))
-		pr := parser.ParseFile()
+		pr, err := parser.ParseFile(context.Background())
+		assert.Nil(err)
 		assert.NotNil(pr)
 		assert.Greater(len(parser.errors), 0)
 	})
@@ -1590,7 +1628,8 @@ func x(){
 }
 `
 		parser := New(lex.FetchTokensFromString(data))
-		pr := parser.ParseFile()
+		pr, err := parser.ParseFile(context.Background())
+		assert.Nil(err)
 		assert.NotNil(pr)
 		assert.Greater(len(parser.errors), 0)
 	})
@@ -1606,7 +1645,8 @@ func x(){
 }
 `
 		parser := New(lex.FetchTokensFromString(data))
-		pr := parser.ParseFile()
+		pr, err := parser.ParseFile(context.Background())
+		assert.Nil(err)
 		assert.NotNil(pr)
 		assert.Greater(len(parser.errors), 0)
 	})
@@ -1623,7 +1663,8 @@ func x(){
 }
 `
 		parser := New(lex.FetchTokensFromString(data))
-		pr := parser.ParseFile()
+		pr, err := parser.ParseFile(context.Background())
+		assert.Nil(err)
 		assert.NotNil(pr)
 		assert.Greater(len(parser.errors), 0)
 	})
@@ -1640,7 +1681,8 @@ func x(){
 }
 `
 		parser := New(lex.FetchTokensFromString(data))
-		pr := parser.ParseFile()
+		pr, err := parser.ParseFile(context.Background())
+		assert.Nil(err)
 		assert.NotNil(pr)
 		assert.Greater(len(parser.errors), 0)
 	})
@@ -1657,7 +1699,8 @@ func x(){
 }
 `
 		parser := New(lex.FetchTokensFromString(data))
-		pr := parser.ParseFile()
+		pr, err := parser.ParseFile(context.Background())
+		assert.Nil(err)
 		assert.NotNil(pr)
 		assert.Greater(len(parser.errors), 0)
 	})
@@ -1673,7 +1716,8 @@ func x(){
 }
 `
 		parser := New(lex.FetchTokensFromString(data))
-		pr := parser.ParseFile()
+		pr, err := parser.ParseFile(context.Background())
+		assert.Nil(err)
 		assert.NotNil(pr)
 		assert.Greater(len(parser.errors), 0)
 	})
@@ -1689,7 +1733,8 @@ func x(){
 }
 `
 		parser := New(lex.FetchTokensFromString(data))
-		pr := parser.ParseFile()
+		pr, err := parser.ParseFile(context.Background())
+		assert.Nil(err)
 		assert.NotNil(pr)
 		assert.Greater(len(parser.errors), 0)
 	})
@@ -1705,7 +1750,8 @@ func x(){
 }
 `
 		parser := New(lex.FetchTokensFromString(data))
-		pr := parser.ParseFile()
+		pr, err := parser.ParseFile(context.Background())
+		assert.Nil(err)
 		assert.NotNil(pr)
 		assert.Greater(len(parser.errors), 0)
 	})
@@ -1723,7 +1769,8 @@ func x(){
 }
 `
 		parser := New(lex.FetchTokensFromString(data))
-		pr := parser.ParseFile()
+		pr, err := parser.ParseFile(context.Background())
+		assert.Nil(err)
 		assert.NotNil(pr)
 		assert.Greater(len(parser.errors), 0)
 	})
@@ -1740,7 +1787,8 @@ func x(){
 }
 `
 		parser := New(lex.FetchTokensFromString(data))
-		pr := parser.ParseFile()
+		pr, err := parser.ParseFile(context.Background())
+		assert.Nil(err)
 		assert.NotNil(pr)
 		assert.Greater(len(parser.errors), 0)
 	})
@@ -1757,7 +1805,8 @@ func x(){
 }
 `
 		parser := New(lex.FetchTokensFromString(data))
-		pr := parser.ParseFile()
+		pr, err := parser.ParseFile(context.Background())
+		assert.Nil(err)
 		assert.NotNil(pr)
 		assert.Greater(len(parser.errors), 0)
 	})
@@ -1774,7 +1823,8 @@ func x(){
 }
 `
 		parser := New(lex.FetchTokensFromString(data))
-		pr := parser.ParseFile()
+		pr, err := parser.ParseFile(context.Background())
+		assert.Nil(err)
 		assert.NotNil(pr)
 		assert.Greater(len(parser.errors), 0)
 	})
@@ -1791,7 +1841,8 @@ func x(){
 }
 `
 		parser := New(lex.FetchTokensFromString(data))
-		pr := parser.ParseFile()
+		pr, err := parser.ParseFile(context.Background())
+		assert.Nil(err)
 		assert.NotNil(pr)
 		assert.Greater(len(parser.errors), 0)
 	})
@@ -1808,7 +1859,8 @@ func x(){
 }
 `
 		parser := New(lex.FetchTokensFromString(data))
-		pr := parser.ParseFile()
+		pr, err := parser.ParseFile(context.Background())
+		assert.Nil(err)
 		assert.NotNil(pr)
 		assert.Greater(len(parser.errors), 0)
 	})
@@ -1825,7 +1877,8 @@ func x(){
 }
 `
 		parser := New(lex.FetchTokensFromString(data))
-		pr := parser.ParseFile()
+		pr, err := parser.ParseFile(context.Background())
+		assert.Nil(err)
 		assert.NotNil(pr)
 		assert.Greater(len(parser.errors), 0)
 	})
@@ -1842,7 +1895,8 @@ func x(){
 }
 `
 		parser := New(lex.FetchTokensFromString(data))
-		pr := parser.ParseFile()
+		pr, err := parser.ParseFile(context.Background())
+		assert.Nil(err)
 		assert.NotNil(pr)
 		assert.Greater(len(parser.errors), 0)
 	})
@@ -1860,7 +1914,8 @@ func x(){
 }
 `
 		parser := New(lex.FetchTokensFromString(data))
-		pr := parser.ParseFile()
+		pr, err := parser.ParseFile(context.Background())
+		assert.Nil(err)
 		assert.NotNil(pr)
 		assert.Greater(len(parser.errors), 0)
 	})
@@ -1877,7 +1932,8 @@ func x(){
 }
 `
 		parser := New(lex.FetchTokensFromString(data))
-		pr := parser.ParseFile()
+		pr, err := parser.ParseFile(context.Background())
+		assert.Nil(err)
 		assert.NotNil(pr)
 		assert.Greater(len(parser.errors), 0)
 	})
@@ -1894,7 +1950,8 @@ func x(){
 }
 `
 		parser := New(lex.FetchTokensFromString(data))
-		pr := parser.ParseFile()
+		pr, err := parser.ParseFile(context.Background())
+		assert.Nil(err)
 		assert.NotNil(pr)
 		assert.Greater(len(parser.errors), 0)
 	})
@@ -1913,7 +1970,8 @@ func x(){
 }
 `
 		parser := New(lex.FetchTokensFromString(data))
-		pr := parser.ParseFile()
+		pr, err := parser.ParseFile(context.Background())
+		assert.Nil(err)
 		assert.NotNil(pr)
 		assert.Greater(len(parser.errors), 0)
 	})
@@ -1933,7 +1991,8 @@ func x(){
 }
 `
 		parser := New(lex.FetchTokensFromString(data))
-		pr := parser.ParseFile()
+		pr, err := parser.ParseFile(context.Background())
+		assert.Nil(err)
 		assert.NotNil(pr)
 		assert.Greater(len(parser.errors), 0)
 	})
@@ -1953,7 +2012,8 @@ func x(){
 }
 `
 		parser := New(lex.FetchTokensFromString(data))
-		pr := parser.ParseFile()
+		pr, err := parser.ParseFile(context.Background())
+		assert.Nil(err)
 		assert.NotNil(pr)
 		assert.Greater(len(parser.errors), 0)
 	})
@@ -1970,7 +2030,8 @@ func x(){
 }
 `
 		parser := New(lex.FetchTokensFromString(data))
-		pr := parser.ParseFile()
+		pr, err := parser.ParseFile(context.Background())
+		assert.Nil(err)
 		assert.NotNil(pr)
 		assert.Greater(len(parser.errors), 0)
 	})
@@ -1987,7 +2048,8 @@ func x(){
 }
 `
 		parser := New(lex.FetchTokensFromString(data))
-		pr := parser.ParseFile()
+		pr, err := parser.ParseFile(context.Background())
+		assert.Nil(err)
 		assert.NotNil(pr)
 		assert.Greater(len(parser.errors), 0)
 	})
@@ -2002,7 +2064,8 @@ func x(){
 }
 `
 		parser := New(lex.FetchTokensFromString(data))
-		pr := parser.ParseFile()
+		pr, err := parser.ParseFile(context.Background())
+		assert.Nil(err)
 		assert.NotNil(pr)
 		assert.Greater(len(parser.errors), 0)
 	})
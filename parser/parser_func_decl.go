@@ -10,13 +10,19 @@ import (
 // parseFuncDecl returns function declaration
 func (p *Parser) parseFuncDecl() ast.Decl {
 	kw := p.expect(token.KWFunc, "expected 'func'")
-	name := p.expectValidIdent(token.Ident, false, "expected function name")
-	_ = p.expect(token.LParen, "expected '(' after function name")
-
 	f := &ast.FuncDecl{
 		FuncKW: kw,
-		Name:   name,
 	}
+	if p.kind() == token.LParen {
+		f.Recv = p.parseFuncRecv()
+	}
+	f.Name = p.expectValidIdent(token.Ident, false, "expected function name")
+	if p.kind() == token.LBracket {
+		f.TypeParams = p.parseTypeParams()
+		p.declareGeneric(f)
+	}
+	_ = p.expect(token.LParen, "expected '(' after function name")
+
 	for p.kind() != token.RParen && p.kind() != token.EOF {
 		if p.kind() == token.Comma {
 			tok := p.expect(token.Comma, "expected ','")
@@ -40,53 +46,33 @@ func (p *Parser) parseFuncDecl() ast.Decl {
 	}
 
 	_ = p.expect(token.RParen, "expected ')' after function name")
+	p.checkVariadic(f.Params, true)
 
 	f.Results = p.parseFuncReturnTypes()
+	p.checkVariadic(f.Results.List, false)
+	p.labels, p.funcLabels, p.labelRefs = nil, make(map[string]bool), nil
 	body := p.parseBlock()
 	f.Body = body
+	p.resolveLabels()
+	p.typeParams = nil
 
 	return f
 }
 
+// parseFuncRecv returns the receiver of a method like (s view Stack)
+func (p *Parser) parseFuncRecv() *ast.Param {
+	_ = p.expect(token.LParen, "expected '('")
+	recv := p.parseFuncParam(false)
+	_ = p.expect(token.RParen, "expected ')' after receiver")
+	p.checkVariadic([]ast.Param{recv}, false)
+	return &recv
+}
+
 // parseFuncParam returns function parameter
 func (p *Parser) parseFuncParam(forbidBlankIdentifier bool) ast.Param {
 	name := p.expectValidIdent(token.Ident, forbidBlankIdentifier, "expected parameter identifier")
-	if p.kind() == token.LBracket && p.kindNext(p.position+1) == token.RBracket {
-		return ast.Param{Name: name, Type: p.parseSliceOrArrayType()}
-	}
-
-	if p.kind() == token.LBracket && p.kindNext(p.position+1) == token.IntLit && p.kindNext(p.position+2) == token.RBracket {
-		return ast.Param{Name: name, Type: p.parseSliceOrArrayType()}
-	}
-
-	typ, btyp, bad := p.parseFuncParamType(true)
-	if bad {
-		return ast.Param{Name: name, Type: btyp}
-	}
-	return ast.Param{Name: name, Type: typ}
-}
-
-// parseFuncParamType returns func parameter type
-func (p *Parser) parseFuncParamType(forbidBlankIdentifier bool) (*ast.NamedType, *ast.BadType, bool) {
-	typ := &ast.NamedType{}
-	btyp := &ast.BadType{}
-	var bad bool
-
-	if token.IsFuncParamTypes(p.kind()) {
-		if p.kind() == token.Ident {
-			typ.Parts = append(typ.Parts, p.expectValidIdent(p.kind(), forbidBlankIdentifier, "expected valid ident"))
-		} else {
-			typ.Parts = append(typ.Parts, p.next())
-		}
-	} else {
-		tok := p.next()
-		p.errors = append(p.errors, fmt.Errorf("%d:%d: unsupported type with %v %q", tok.Line, tok.Column, tok.Kind, tok.Value))
-		btyp.From = tok
-		btyp.Reason = "unexpected type name"
-		bad = true
-	}
-
-	return typ, btyp, bad
+	qualifier := p.parseQualifier()
+	return ast.Param{Name: name, Qualifier: qualifier, Ellipsis: p.parseEllipsis(), Type: p.parseType()}
 }
 
 // parseFuncReturnTypes returns func return types
@@ -123,7 +109,7 @@ func (p *Parser) parseFuncReturnTypes() ast.ReturnTypes {
 
 		result.LParen = lp
 		// entering into kind: (indentA indentB, indentC indentD) or (indentA indentB)
-		if p.kindNext(p.position+1) == token.LBracket || p.kindNext(p.position+2) == token.Comma || p.kindNext(p.position+2) == token.RParen {
+		if p.isNamedParam(p.position) {
 			for p.kind() != token.RParen && p.kind() != token.LBrace && p.kind() != token.EOF {
 				param := p.parseFuncParam(true)
 				_, bad := param.Type.(*ast.BadType)
@@ -161,15 +147,11 @@ func (p *Parser) parseFuncReturnTypes() ast.ReturnTypes {
 		} else {
 			// entering into kind: (type, type)
 			for p.kind() != token.RParen && p.kind() != token.LBrace && p.kind() != token.EOF {
-				if p.kind() == token.LBracket {
-					result.List = append(result.List, ast.Param{Type: p.parseSliceOrArrayType()})
-				} else {
-					typ, btyp, bad := p.parseFuncParamType(true)
-					if bad {
-						result.List = append(result.List, ast.Param{Type: btyp})
-						return result
-					}
-					result.List = append(result.List, ast.Param{Type: typ})
+				qualifier := p.parseQualifier()
+				typ := p.parseType()
+				result.List = append(result.List, ast.Param{Qualifier: qualifier, Type: typ})
+				if _, bad := typ.(*ast.BadType); bad {
+					return result
 				}
 
 				if p.kind() != token.Comma && p.kind() != token.RParen {
@@ -214,16 +196,12 @@ func (p *Parser) parseFuncReturnTypes() ast.ReturnTypes {
 		return result
 	}
 
-	if p.kind() == token.LBracket {
-		result.List = append(result.List, ast.Param{Type: p.parseSliceOrArrayType()})
-	} else {
-		typ, btyp, bad := p.parseFuncParamType(true)
-		if bad {
-			p.errors = append(p.errors, fmt.Errorf("%d:%d: unexpected expression, got %v %q", btyp.From.Line, btyp.From.Column, btyp.From.Kind, btyp.From.Value))
-			result.List = append(result.List, ast.Param{Type: btyp})
-			return result
-		}
-		result.List = append(result.List, ast.Param{Type: typ})
+	qualifier := p.parseQualifier()
+	typ := p.parseType()
+	result.List = append(result.List, ast.Param{Qualifier: qualifier, Type: typ})
+	if btyp, bad := typ.(*ast.BadType); bad {
+		p.errors = append(p.errors, fmt.Errorf("%d:%d: unexpected expression, got %v %q", btyp.From.Line, btyp.From.Column, btyp.From.Kind, btyp.From.Value))
+		return result
 	}
 
 	next := p.peek()
@@ -240,3 +218,19 @@ func (p *Parser) parseFuncReturnTypes() ast.ReturnTypes {
 
 	return result
 }
+
+// isNamedParam returns true when the tokens at pos start
+// a named parameter like a int, a []int or a view []int
+func (p *Parser) isNamedParam(pos int) bool {
+	if p.kindNext(pos) != token.Ident {
+		return false
+	}
+
+	pos += p.qualifierOffset(pos + 1)
+	next := p.kindNext(pos + 1)
+	if next == token.LBracket {
+		k := p.kindNext(pos + 2)
+		return k == token.RBracket || k == token.IntLit
+	}
+	return token.IsTypeStart(next) || next == token.Ellipsis
+}
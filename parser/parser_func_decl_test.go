@@ -1,6 +1,7 @@
 package parser
 
 import (
+	"context"
 	"testing"
 
 	"github.com/orilang/gori/ast"
@@ -20,7 +21,8 @@ func x()int{
 }
 `
 		parser := New(lex.FetchTokensFromString(data))
-		pr := parser.ParseFile()
+		pr, err := parser.ParseFile(context.Background())
+		assert.Nil(err)
 		result := `File
  Package: "package" @1:1 (kind=8)
  Name: "main" @1:9 (kind=3)
@@ -50,7 +52,8 @@ func x()(int,int){
 }
 `
 		parser := New(lex.FetchTokensFromString(data))
-		pr := parser.ParseFile()
+		pr, err := parser.ParseFile(context.Background())
+		assert.Nil(err)
 		result := `File
  Package: "package" @1:1 (kind=8)
  Name: "main" @1:9 (kind=3)
@@ -85,7 +88,8 @@ func x()(int,int){
 func x()(a int,b int){}
 `
 		parser := New(lex.FetchTokensFromString(data))
-		pr := parser.ParseFile()
+		pr, err := parser.ParseFile(context.Background())
+		assert.Nil(err)
 		result := `File
  Package: "package" @1:1 (kind=8)
  Name: "main" @1:9 (kind=3)
@@ -122,7 +126,8 @@ func x()(a int,b int){}
 func x(){}
 `
 		parser := New(lex.FetchTokensFromString(data))
-		pr := parser.ParseFile()
+		pr, err := parser.ParseFile(context.Background())
+		assert.Nil(err)
 		result := `File
  Package: "package" @1:1 (kind=8)
  Name: "main" @1:9 (kind=3)
@@ -146,7 +151,8 @@ func x(){}
 func x()(a z, b z){}
 `
 		parser := New(lex.FetchTokensFromString(data))
-		pr := parser.ParseFile()
+		pr, err := parser.ParseFile(context.Background())
+		assert.Nil(err)
 		result := `File
  Package: "package" @1:1 (kind=8)
  Name: "main" @1:9 (kind=3)
@@ -183,7 +189,8 @@ func x()(a z, b z){}
 func x()(a z){}
 `
 		parser := New(lex.FetchTokensFromString(data))
-		pr := parser.ParseFile()
+		pr, err := parser.ParseFile(context.Background())
+		assert.Nil(err)
 		result := `File
  Package: "package" @1:1 (kind=8)
  Name: "main" @1:9 (kind=3)
@@ -215,7 +222,8 @@ func x()(a z){}
 func x()(a []int){}
 `
 		parser := New(lex.FetchTokensFromString(data))
-		pr := parser.ParseFile()
+		pr, err := parser.ParseFile(context.Background())
+		assert.Nil(err)
 		result := `File
  Package: "package" @1:1 (kind=8)
  Name: "main" @1:9 (kind=3)
@@ -250,7 +258,8 @@ func x()(a []int){}
 func x()[]int{}
 `
 		parser := New(lex.FetchTokensFromString(data))
-		pr := parser.ParseFile()
+		pr, err := parser.ParseFile(context.Background())
+		assert.Nil(err)
 		result := `File
  Package: "package" @1:1 (kind=8)
  Name: "main" @1:9 (kind=3)
@@ -282,7 +291,8 @@ func x()[]int{}
 func x()([]int,[]string){}
 `
 		parser := New(lex.FetchTokensFromString(data))
-		pr := parser.ParseFile()
+		pr, err := parser.ParseFile(context.Background())
+		assert.Nil(err)
 		result := `File
  Package: "package" @1:1 (kind=8)
  Name: "main" @1:9 (kind=3)
@@ -327,7 +337,8 @@ func x(){
 }
 `
 		parser := New(lex.FetchTokensFromString(data))
-		pr := parser.ParseFile()
+		pr, err := parser.ParseFile(context.Background())
+		assert.Nil(err)
 		result := `File
  Package: "package" @1:1 (kind=8)
  Name: "main" @1:9 (kind=3)
@@ -367,7 +378,8 @@ func x(){
 }
 `
 		parser := New(lex.FetchTokensFromString(data))
-		pr := parser.ParseFile()
+		pr, err := parser.ParseFile(context.Background())
+		assert.Nil(err)
 		result := `File
  Package: "package" @1:1 (kind=8)
  Name: "main" @1:9 (kind=3)
@@ -405,7 +417,8 @@ func x(){
 }
 `
 		parser := New(lex.FetchTokensFromString(data))
-		pr := parser.ParseFile()
+		pr, err := parser.ParseFile(context.Background())
+		assert.Nil(err)
 		result := `File
  Package: "package" @1:1 (kind=8)
  Name: "main" @1:9 (kind=3)
@@ -446,7 +459,8 @@ func x(){
 }
 `
 		parser := New(lex.FetchTokensFromString(data))
-		pr := parser.ParseFile()
+		pr, err := parser.ParseFile(context.Background())
+		assert.Nil(err)
 		result := `File
  Package: "package" @1:1 (kind=8)
  Name: "main" @1:9 (kind=3)
@@ -489,7 +503,8 @@ func x(){
 func x(),int{}
 `
 		parser := New(lex.FetchTokensFromString(data))
-		pr := parser.ParseFile()
+		pr, err := parser.ParseFile(context.Background())
+		assert.Nil(err)
 		assert.NotNil(pr)
 		assert.Greater(len(parser.errors), 0)
 	})
@@ -502,7 +517,8 @@ func x(),int{}
 func x()(int,,int){}
 `
 		parser := New(lex.FetchTokensFromString(data))
-		pr := parser.ParseFile()
+		pr, err := parser.ParseFile(context.Background())
+		assert.Nil(err)
 		assert.NotNil(pr)
 		assert.Greater(len(parser.errors), 0)
 	})
@@ -515,7 +531,8 @@ func x()(int,,int){}
 func x()(a int,b){}
 `
 		parser := New(lex.FetchTokensFromString(data))
-		pr := parser.ParseFile()
+		pr, err := parser.ParseFile(context.Background())
+		assert.Nil(err)
 		assert.NotNil(pr)
 		assert.Greater(len(parser.errors), 0)
 	})
@@ -528,7 +545,8 @@ func x()(a int,b){}
 func x()(){}
 `
 		parser := New(lex.FetchTokensFromString(data))
-		pr := parser.ParseFile()
+		pr, err := parser.ParseFile(context.Background())
+		assert.Nil(err)
 		assert.NotNil(pr)
 		assert.Greater(len(parser.errors), 0)
 	})
@@ -541,7 +559,8 @@ func x()(){}
 func x()(,a int,b){}
 `
 		parser := New(lex.FetchTokensFromString(data))
-		pr := parser.ParseFile()
+		pr, err := parser.ParseFile(context.Background())
+		assert.Nil(err)
 		assert.NotNil(pr)
 		assert.Greater(len(parser.errors), 0)
 	})
@@ -554,7 +573,8 @@ func x()(,a int,b){}
 func x()int,{}
 `
 		parser := New(lex.FetchTokensFromString(data))
-		pr := parser.ParseFile()
+		pr, err := parser.ParseFile(context.Background())
+		assert.Nil(err)
 		assert.NotNil(pr)
 		assert.Greater(len(parser.errors), 0)
 	})
@@ -567,7 +587,8 @@ func x()int,{}
 func x()(a int,b,){}
 `
 		parser := New(lex.FetchTokensFromString(data))
-		pr := parser.ParseFile()
+		pr, err := parser.ParseFile(context.Background())
+		assert.Nil(err)
 		assert.NotNil(pr)
 		assert.Greater(len(parser.errors), 0)
 	})
@@ -580,7 +601,8 @@ func x()(a int,b,){}
 func x()(a int,b b b){}
 `
 		parser := New(lex.FetchTokensFromString(data))
-		pr := parser.ParseFile()
+		pr, err := parser.ParseFile(context.Background())
+		assert.Nil(err)
 		assert.NotNil(pr)
 		assert.Greater(len(parser.errors), 0)
 	})
@@ -593,7 +615,8 @@ func x()(a int,b b b){}
 func x()(a int,b b,){}
 `
 		parser := New(lex.FetchTokensFromString(data))
-		pr := parser.ParseFile()
+		pr, err := parser.ParseFile(context.Background())
+		assert.Nil(err)
 		assert.NotNil(pr)
 		assert.Greater(len(parser.errors), 0)
 	})
@@ -606,7 +629,8 @@ func x()(a int,b b,){}
 func x()(a int,b return){}
 `
 		parser := New(lex.FetchTokensFromString(data))
-		pr := parser.ParseFile()
+		pr, err := parser.ParseFile(context.Background())
+		assert.Nil(err)
 		assert.NotNil(pr)
 		assert.Greater(len(parser.errors), 0)
 	})
@@ -619,7 +643,8 @@ func x()(a int,b return){}
 func x()(a int,b return){}
 `
 		parser := New(lex.FetchTokensFromString(data))
-		pr := parser.ParseFile()
+		pr, err := parser.ParseFile(context.Background())
+		assert.Nil(err)
 		assert.NotNil(pr)
 		assert.Greater(len(parser.errors), 0)
 	})
@@ -632,7 +657,8 @@ func x()(a int,b return){}
 func x()(a return,b return){}
 `
 		parser := New(lex.FetchTokensFromString(data))
-		pr := parser.ParseFile()
+		pr, err := parser.ParseFile(context.Background())
+		assert.Nil(err)
 		assert.NotNil(pr)
 		assert.Greater(len(parser.errors), 0)
 	})
@@ -645,7 +671,8 @@ func x()(a return,b return){}
 func x() struct {}
 `
 		parser := New(lex.FetchTokensFromString(data))
-		pr := parser.ParseFile()
+		pr, err := parser.ParseFile(context.Background())
+		assert.Nil(err)
 		assert.NotNil(pr)
 		assert.Greater(len(parser.errors), 0)
 	})
@@ -658,7 +685,8 @@ func x() struct {}
 func x()(int, struct){}
 `
 		parser := New(lex.FetchTokensFromString(data))
-		pr := parser.ParseFile()
+		pr, err := parser.ParseFile(context.Background())
+		assert.Nil(err)
 		assert.NotNil(pr)
 		assert.Greater(len(parser.errors), 0)
 	})
@@ -671,7 +699,8 @@ func x()(int, struct){}
 func x()(int struct){}
 `
 		parser := New(lex.FetchTokensFromString(data))
-		pr := parser.ParseFile()
+		pr, err := parser.ParseFile(context.Background())
+		assert.Nil(err)
 		assert.NotNil(pr)
 		assert.Greater(len(parser.errors), 0)
 	})
@@ -684,7 +713,8 @@ func x()(int struct){}
 func x()(int,int,){}
 `
 		parser := New(lex.FetchTokensFromString(data))
-		pr := parser.ParseFile()
+		pr, err := parser.ParseFile(context.Background())
+		assert.Nil(err)
 		assert.NotNil(pr)
 		assert.Greater(len(parser.errors), 0)
 	})
@@ -697,7 +727,8 @@ func x()(int,int,){}
 func x()(a, b z){}
 `
 		parser := New(lex.FetchTokensFromString(data))
-		pr := parser.ParseFile()
+		pr, err := parser.ParseFile(context.Background())
+		assert.Nil(err)
 		assert.NotNil(pr)
 		assert.Greater(len(parser.errors), 0)
 	})
@@ -710,7 +741,8 @@ func x()(a, b z){}
 func x()(_ b){}
 `
 		parser := New(lex.FetchTokensFromString(data))
-		pr := parser.ParseFile()
+		pr, err := parser.ParseFile(context.Background())
+		assert.Nil(err)
 		assert.NotNil(pr)
 		assert.Greater(len(parser.errors), 0)
 	})
@@ -723,7 +755,8 @@ func x()(_ b){}
 func x()(a _){}
 `
 		parser := New(lex.FetchTokensFromString(data))
-		pr := parser.ParseFile()
+		pr, err := parser.ParseFile(context.Background())
+		assert.Nil(err)
 		assert.NotNil(pr)
 		assert.Greater(len(parser.errors), 0)
 	})
@@ -0,0 +1,51 @@
+package parser
+
+import (
+	"fmt"
+
+	"github.com/orilang/gori/ast"
+	"github.com/orilang/gori/token"
+)
+
+// parseImportDecl returns an import declaration or a group of them
+func (p *Parser) parseImportDecl() *ast.ImportDecl {
+	d := &ast.ImportDecl{
+		ImportKW: p.expect(token.KWImport, "expected 'import'"),
+	}
+	if p.kind() != token.LParen {
+		d.Specs = append(d.Specs, p.parseImportSpec())
+		return d
+	}
+
+	d.LParen = p.next()
+	for p.kind() != token.RParen && p.kind() != token.EOF && !p.cancelled() {
+		d.Specs = append(d.Specs, p.parseImportSpec())
+
+		if p.kind() == token.SemiComma {
+			_ = p.next()
+			continue
+		}
+
+		if p.kind() == token.RParen || p.newlineSincePrev() {
+			continue
+		}
+
+		p.errors = append(p.errors, fmt.Errorf("%d:%d: expected ';' or newline after import, got %v %q", p.peek().Line, p.peek().Column, p.peek().Kind, p.peek().Value))
+		p.consumeTo(token.RParen)
+	}
+	d.RParen = p.expect(token.RParen, "expected ')'")
+
+	return d
+}
+
+// parseImportSpec returns an imported path with its optional name
+// like lib "github.com/orilang/lib"
+func (p *Parser) parseImportSpec() *ast.ImportSpec {
+	s := &ast.ImportSpec{}
+	if p.kind() == token.Ident {
+		s.Name = p.expectValidIdent(token.Ident, false, "expected import name")
+	}
+	s.Path = p.expect(token.StringLit, "expected import path")
+
+	return s
+}
@@ -0,0 +1,97 @@
+package parser
+
+import (
+	"context"
+	"testing"
+
+	"github.com/orilang/gori/ast"
+	"github.com/orilang/gori/lexer"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParser_import_decl(t *testing.T) {
+	assert := assert.New(t)
+
+	t.Run("import_x1", func(t *testing.T) {
+		lex, err := lexer.NewLexer(lexer.Config{StringOnly: true})
+		assert.Nil(err)
+		data := `package main
+
+import "github.com/orilang/app/lib"
+import (
+  log "github.com/orilang/log"
+  "github.com/orilang/app/util"
+)
+
+func main() {}
+`
+		parser := New(lex.FetchTokensFromString(data))
+		pr, err := parser.ParseFile(context.Background())
+		assert.Nil(err)
+		result := `File
+ Package: "package" @1:1 (kind=8)
+ Name: "main" @1:9 (kind=3)
+ Imports
+  ImportDecl
+   Import: "import" @3:1 (kind=9)
+   ImportSpec
+    Path: "github.com/orilang/app/lib" @3:8 (kind=6)
+  ImportDecl
+   Import: "import" @4:1 (kind=9)
+   LParen: "(" @4:8 (kind=39)
+   ImportSpec
+    Name: "log" @5:3 (kind=3)
+    Path: "github.com/orilang/log" @5:7 (kind=6)
+   ImportSpec
+    Path: "github.com/orilang/app/util" @6:3 (kind=6)
+   RParen: ")" @7:1 (kind=40)
+ Decls
+  FuncDecl
+   Function: "func" @9:1 (kind=10)
+   Name: "main" @9:6 (kind=3)
+   Params
+    (none)
+   Body
+`
+		assert.Equal(result, ast.Dump(pr))
+		assert.Equal(0, len(parser.errors))
+	})
+
+	t.Run("bad_x1", func(t *testing.T) {
+		lex, err := lexer.NewLexer(lexer.Config{StringOnly: true})
+		assert.Nil(err)
+		data := `package main
+
+func main() {}
+
+import "lib"
+`
+		parser := New(lex.FetchTokensFromString(data))
+		pr, err := parser.ParseFile(context.Background())
+		assert.Nil(err)
+		assert.NotNil(pr)
+		assert.Equal(`5:1: imports must appear before other declarations, got 9 "import"`, parser.errors[0].Error())
+	})
+
+	t.Run("bad_x2", func(t *testing.T) {
+		lex, err := lexer.NewLexer(lexer.Config{StringOnly: true})
+		assert.Nil(err)
+		data := `package main
+
+import (
+  "a" "b"
+)
+import 12
+func main() {}
+`
+		parser := New(lex.FetchTokensFromString(data))
+		pr, err := parser.ParseFile(context.Background())
+		assert.Nil(err)
+		assert.NotNil(pr)
+		if assert.Equal(2, len(parser.errors)) {
+			assert.Equal(`4:7: expected ';' or newline after import, got 6 "\"b\""`, parser.errors[0].Error())
+			assert.Equal(`6:8 expected import path (got 4 "12")`, parser.errors[1].Error())
+		}
+		assert.Equal(1, len(pr.Decls))
+	})
+}
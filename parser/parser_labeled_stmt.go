@@ -0,0 +1,83 @@
+package parser
+
+import (
+	"fmt"
+
+	"github.com/orilang/gori/ast"
+	"github.com/orilang/gori/token"
+)
+
+// parseLabeledStmt returns a statement with a label like L: for { ... }
+func (p *Parser) parseLabeledStmt() ast.Stmt {
+	s := &ast.LabeledStmt{
+		Label: p.next(),
+		Colon: p.next(),
+	}
+
+	if p.funcLabels == nil {
+		p.funcLabels = make(map[string]bool)
+	}
+	if p.funcLabels[s.Label.Value] {
+		p.errors = append(p.errors, fmt.Errorf("%d:%d: label already defined, got %v %q", s.Label.Line, s.Label.Column, s.Label.Kind, s.Label.Value))
+	}
+	p.funcLabels[s.Label.Value] = true
+
+	if p.kind() == token.RBrace || p.kind() == token.EOF {
+		p.errors = append(p.errors, fmt.Errorf("%d:%d: expected statement after label, got %v %q", p.peek().Line, p.peek().Column, p.peek().Kind, p.peek().Value))
+		return &ast.BadStmt{From: s.Label, To: p.peek(), Reason: "expected statement after label"}
+	}
+
+	p.labels = append(p.labels, label{name: s.Label, kind: p.kind()})
+	s.Stmt = p.parseStmt()
+	p.labels = p.labels[:len(p.labels)-1]
+	return s
+}
+
+// hasBranchLabel returns true when the break or continue
+// keyword is followed by a label on the same line
+func (p *Parser) hasBranchLabel() bool {
+	next := p.peekNext(p.position + 1)
+	return next.Kind == token.Ident && next.Line == p.peek().Line
+}
+
+// parseBranchLabel returns the label following the break or continue
+// keyword or an empty token when there is none.
+// A continue label must label a loop while a break label
+// must label a loop, a switch or a match
+func (p *Parser) parseBranchLabel(kw token.Token) token.Token {
+	if p.kind() != token.Ident || p.peek().Line != kw.Line {
+		return token.Token{}
+	}
+
+	lbl := p.next()
+	for i := len(p.labels) - 1; i >= 0; i-- {
+		if p.labels[i].name.Value != lbl.Value {
+			continue
+		}
+
+		switch kind := p.labels[i].kind; {
+		case kw.Kind == token.KWContinue && kind != token.KWFor:
+			p.errors = append(p.errors, fmt.Errorf("%d:%d: continue label must label a loop, got %v %q", lbl.Line, lbl.Column, lbl.Kind, lbl.Value))
+		case kw.Kind == token.KWBreak && kind != token.KWFor && kind != token.KWSwitch && kind != token.KWMatch:
+			p.errors = append(p.errors, fmt.Errorf("%d:%d: break label must label a loop, switch or match, got %v %q", lbl.Line, lbl.Column, lbl.Kind, lbl.Value))
+		}
+		return lbl
+	}
+
+	p.labelRefs = append(p.labelRefs, labelRef{stmt: kw, label: lbl})
+	return lbl
+}
+
+// resolveLabels reports the break and continue labels
+// not enclosing their statement in the function parsed
+func (p *Parser) resolveLabels() {
+	for _, ref := range p.labelRefs {
+		lbl := ref.label
+		if p.funcLabels[lbl.Value] {
+			p.errors = append(p.errors, fmt.Errorf("%d:%d: label does not enclose '%s', got %v %q", lbl.Line, lbl.Column, ref.stmt.Value, lbl.Kind, lbl.Value))
+			continue
+		}
+		p.errors = append(p.errors, fmt.Errorf("%d:%d: undefined label, got %v %q", lbl.Line, lbl.Column, lbl.Kind, lbl.Value))
+	}
+	p.labels, p.funcLabels, p.labelRefs = nil, nil, nil
+}
@@ -0,0 +1,215 @@
+package parser
+
+import (
+	"context"
+	"testing"
+
+	"github.com/orilang/gori/ast"
+	"github.com/orilang/gori/lexer"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParser_labeled_stmt(t *testing.T) {
+	assert := assert.New(t)
+
+	t.Run("labeled_break_continue", func(t *testing.T) {
+		lex, err := lexer.NewLexer(lexer.Config{StringOnly: true})
+		assert.Nil(err)
+		data := `package main
+
+func main() {
+outer:
+  for i := 0; i < 3; i++ {
+    switch i {
+    case 1:
+      continue outer
+    case 2:
+      break outer
+    }
+  }
+}
+`
+		result := `File
+ Package: "package" @1:1 (kind=8)
+ Name: "main" @1:9 (kind=3)
+ Decls
+  FuncDecl
+   Function: "func" @3:1 (kind=10)
+   Name: "main" @3:6 (kind=3)
+   Params
+    (none)
+   Body
+    BlockStmt
+     LBrace: "{" @3:13 (kind=41)
+     Stmts
+      LabeledStmt
+       Label: "outer" @4:1 (kind=3)
+       Colon: ":" @4:6 (kind=47)
+       Stmt
+        ForStmt
+         For: "for" @5:3 (kind=31)
+         Init
+          AssignStmt
+           Left
+            IdentExpr
+             Name: "i" @5:7 (kind=3)
+           Operator: ":=" @5:9 (kind=50)
+           Right
+            IntLitExpr
+             Value: "0" @5:12 (kind=4)
+         Condition
+          BinaryExpr
+           IdentExpr
+            Name: "i" @5:15 (kind=3)
+           Operator: "<" @5:17 (kind=64)
+           IntLitExpr
+            Value: "3" @5:19 (kind=4)
+         Post
+          IncDecStmt
+           X:
+            IdentExpr
+             Name: "i" @5:22 (kind=3)
+           Operator: "++" @5:23 (kind=53)
+          BlockStmt
+           LBrace: "{" @5:26 (kind=41)
+           Stmts
+            SwitchStmt
+             Switch: "switch" @6:5 (kind=34)
+             Init:
+              IdentExpr
+               Name: "i" @6:12 (kind=3)
+             LBrace: "{" @6:14 (kind=41)
+             Case: "case" @7:5 (kind=35)
+              Values:
+               IntLitExpr
+                Value: "1" @7:10 (kind=4)
+             Colon: ":" @7:11 (kind=47)
+              Body:
+               ContinueStmt
+                Continue: "continue" @8:7 (kind=33)
+                Label: "outer" @8:16 (kind=3)
+             Case: "case" @9:5 (kind=35)
+              Values:
+               IntLitExpr
+                Value: "2" @9:10 (kind=4)
+             Colon: ":" @9:11 (kind=47)
+              Body:
+               BreakStmt
+                Break: "break" @10:7 (kind=32)
+                Label: "outer" @10:13 (kind=3)
+             RBrace: "}" @11:5 (kind=42)
+           RBrace: "}" @12:3 (kind=42)
+     RBrace: "}" @13:1 (kind=42)
+`
+		parser := New(lex.FetchTokensFromString(data))
+		pr, err := parser.ParseFile(context.Background())
+		assert.Nil(err)
+		assert.Equal(result, ast.Dump(pr))
+		assert.Equal(0, len(parser.errors))
+	})
+
+	t.Run("labeled_switch_break", func(t *testing.T) {
+		lex, err := lexer.NewLexer(lexer.Config{StringOnly: true})
+		assert.Nil(err)
+		data := `package main
+
+func main() {
+  L: switch x {
+  case 1:
+    break L
+  }
+}
+`
+		result := `File
+ Package: "package" @1:1 (kind=8)
+ Name: "main" @1:9 (kind=3)
+ Decls
+  FuncDecl
+   Function: "func" @3:1 (kind=10)
+   Name: "main" @3:6 (kind=3)
+   Params
+    (none)
+   Body
+    BlockStmt
+     LBrace: "{" @3:13 (kind=41)
+     Stmts
+      LabeledStmt
+       Label: "L" @4:3 (kind=3)
+       Colon: ":" @4:4 (kind=47)
+       Stmt
+        SwitchStmt
+         Switch: "switch" @4:6 (kind=34)
+         Init:
+          IdentExpr
+           Name: "x" @4:13 (kind=3)
+         LBrace: "{" @4:15 (kind=41)
+         Case: "case" @5:3 (kind=35)
+          Values:
+           IntLitExpr
+            Value: "1" @5:8 (kind=4)
+         Colon: ":" @5:9 (kind=47)
+          Body:
+           BreakStmt
+            Break: "break" @6:5 (kind=32)
+            Label: "L" @6:11 (kind=3)
+         RBrace: "}" @7:3 (kind=42)
+     RBrace: "}" @8:1 (kind=42)
+`
+		parser := New(lex.FetchTokensFromString(data))
+		pr, err := parser.ParseFile(context.Background())
+		assert.Nil(err)
+		assert.Equal(result, ast.Dump(pr))
+		assert.Equal(0, len(parser.errors))
+	})
+
+	t.Run("errors", func(t *testing.T) {
+		tests := []struct {
+			input    string
+			expected string
+		}{
+			{
+				input:    "package main\n\nfunc main() {\n  for {\n    break L\n  }\n}\n",
+				expected: `5:11: undefined label, got 3 "L"`,
+			},
+			{
+				input:    "package main\n\nfunc main() {\n  L: for {\n  }\n  for {\n    continue L\n  }\n}\n",
+				expected: `7:14: label does not enclose 'continue', got 3 "L"`,
+			},
+			{
+				input:    "package main\n\nfunc main() {\n  for {\n    break L\n  }\n  L: for {\n  }\n}\n",
+				expected: `5:11: label does not enclose 'break', got 3 "L"`,
+			},
+			{
+				input:    "package main\n\nfunc main() {\n  L: switch x {\n  case 1:\n    continue L\n  }\n}\n",
+				expected: `6:14: continue label must label a loop, got 3 "L"`,
+			},
+			{
+				input:    "package main\n\nfunc main() {\n  L: if true {\n    break L\n  }\n}\n",
+				expected: `5:11: break label must label a loop, switch or match, got 3 "L"`,
+			},
+			{
+				input:    "package main\n\nfunc main() {\n  L: for {\n  }\n  L: for {\n  }\n}\n",
+				expected: `6:3: label already defined, got 3 "L"`,
+			},
+			{
+				input:    "package main\n\nfunc main() {\n  for {\n  L:\n  }\n}\n",
+				expected: `6:3: expected statement after label, got 42 "}"`,
+			},
+			{
+				input:    "package main\n\nfunc main() {\n  break\n}\n",
+				expected: `4:3: unexpected break expression outside for loop, got 32 "break"`,
+			},
+		}
+
+		for _, tc := range tests {
+			lex, err := lexer.NewLexer(lexer.Config{StringOnly: true})
+			assert.Nil(err)
+			parser := New(lex.FetchTokensFromString(tc.input))
+			_, err = parser.ParseFile(context.Background())
+			assert.Nil(err)
+			if assert.Greater(len(parser.errors), 0, tc.input) {
+				assert.Equal(tc.expected, parser.errors[0].Error(), tc.input)
+			}
+		}
+	})
+}
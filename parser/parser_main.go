@@ -1,60 +1,247 @@
 package parser
 
 import (
+	"context"
+	"errors"
 	"fmt"
 	"os"
+	"path/filepath"
+	"slices"
+	"strings"
 
 	"github.com/orilang/gori/ast"
 	"github.com/orilang/gori/lexer"
+	"github.com/orilang/gori/modfile"
+	"github.com/orilang/gori/pool"
 	"github.com/orilang/gori/token"
 	"github.com/orilang/gori/walk"
 )
 
 // NewParser returns files config to StartParsing
 func NewParser(config Config) (*Files, error) {
-	w, err := walk.Walk(walk.Config{File: config.File, Directory: config.Directory})
+	w, err := walk.Walk(walk.Config{File: config.File, Directory: config.Directory, Tags: config.Tags})
 	if err != nil {
 		return nil, err
 	}
 
 	return &Files{
-		Files:  w.Files,
-		output: config.Output,
+		Files:    w.Files,
+		output:   config.Output,
+		jobs:     config.Jobs,
+		tabWidth: config.TabWidth,
 	}, nil
 }
 
-// StartParsing ranges over files to return the AST
-func (f *Files) StartParsing() error {
-	for _, file := range f.Files {
-		data, err := os.ReadFile(file)
-		if err != nil {
+// StartParsing parses files in parallel with at most jobs workers.
+// Output and errors are emitted in files order and
+// lexing and parsing errors of all files are returned joined together
+func (f *Files) StartParsing(ctx context.Context) error {
+	var errs []error
+	err := pool.Run(ctx, f.jobs, len(f.Files),
+		func(ctx context.Context, i int) (result, error) {
+			return f.parseFile(ctx, f.Files[i])
+		},
+		func(i int, r result) error {
+			errs = append(errs, lexer.FileErrors(f.Files[i], r.errors)...)
+			_, err := os.Stdout.Write(r.output)
 			return err
-		}
+		},
+	)
+	if err != nil {
+		return err
+	}
+	return errors.Join(errs...)
+}
 
-		l := lexer.New(data)
-		l.Tokenize()
-		p := New(l.Tokens)
+// parseFile streams the tokens of the provided file to the parser
+// and returns its output with the lexing and parsing errors found.
+// .mod files are parsed as module manifests
+func (f *Files) parseFile(ctx context.Context, file string) (result, error) {
+	if filepath.Ext(file) == ".mod" {
+		return f.parseModFile(file)
+	}
 
-		if f.output {
-			fmt.Printf("%s\n", ast.Dump(p.ParseFile()))
-		}
+	fd, err := os.Open(file)
+	if err != nil {
+		return result{}, err
 	}
-	return nil
+	defer func() {
+		_ = fd.Close()
+	}()
+
+	l := lexer.NewReader(fd)
+	l.TabWidth = f.tabWidth
+	p := NewStream(l)
+	tree, err := p.ParseFile(ctx)
+	if err != nil {
+		return result{}, err
+	}
+
+	var r result
+	if f.output {
+		r.output = fmt.Appendf(nil, "%s\n", ast.Dump(tree))
+	}
+	r.errors = append(slices.Clip(l.Errors), p.errors...)
+	return r, nil
 }
 
+// parseModFile parses the module manifest of the provided file
+// and returns its output with the errors found
+func (f *Files) parseModFile(file string) (result, error) {
+	src, err := os.ReadFile(file)
+	if err != nil {
+		return result{}, err
+	}
+
+	manifest, errs := modfile.Parse(src)
+	r := result{errors: errs}
+	if f.output {
+		r.output = fmt.Appendf(nil, "%s\n", modfile.Dump(manifest))
+	}
+	return r, nil
+}
+
+// New returns a parser working on the whole list of tokens
 func New(tokens []token.Token) *Parser {
+	p := &Parser{}
+	for _, tok := range tokens {
+		p.push(tok)
+	}
+	p.size = len(p.Tokens)
+	return p
+}
+
+// NewStream returns a parser pulling tokens from src on demand.
+// Only a bounded lookahead window is kept in memory
+func NewStream(src TokenSource) *Parser {
 	return &Parser{
-		Tokens: tokens,
-		size:   len(tokens),
+		src: src,
 	}
 }
 
 // peek returns only the current token
 func (p *Parser) peek() token.Token {
-	if p.position >= len(p.Tokens) {
-		return token.Token{Kind: token.EOF}
+	return p.peekNext(p.position)
+}
+
+// fill pulls tokens from the source until pos is buffered
+// and returns false when pos is out of the buffer
+func (p *Parser) fill(pos int) bool {
+	for p.src != nil && pos >= p.base+len(p.Tokens) {
+		tok := p.src.Next()
+		p.push(tok)
+		if tok.Kind == token.EOF {
+			p.src = nil
+		}
+	}
+	return pos >= p.base && pos < p.base+len(p.Tokens)
+}
+
+// push appends tok to the tokens to parse.
+// Comments are kept aside: directives are recorded and
+// a comment group ending right above tok documents it
+func (p *Parser) push(tok token.Token) {
+	if token.IsComment(tok.Kind) {
+		p.comment(tok)
+		return
+	}
+
+	if len(p.group) > 0 && p.groupEnd+1 == tok.Line {
+		p.docs = append(p.docs, doc{
+			pos:   p.base + len(p.Tokens),
+			group: &ast.CommentGroup{List: p.group},
+		})
+	}
+	p.group = nil
+	p.prevLine = tok.Line
+	p.Tokens = append(p.Tokens, tok)
+}
+
+// comment adds tok to the current comment group.
+// Comments on the same line as the previous token are not docs
+func (p *Parser) comment(tok token.Token) {
+	end := tok.Line + strings.Count(tok.Value, "\n")
+	if tok.Kind == token.Directive {
+		p.directive(tok)
+		if len(p.group) > 0 && p.groupEnd+1 == tok.Line {
+			p.groupEnd = end
+		}
+		return
+	}
+
+	if tok.Line == p.prevLine {
+		p.group = nil
+		return
+	}
+
+	if len(p.group) > 0 && p.groupEnd+1 != tok.Line {
+		p.group = nil
 	}
-	return p.Tokens[p.position]
+	p.group = append(p.group, tok)
+	p.groupEnd = end
+}
+
+// directive parses //ori:name args and records it
+func (p *Parser) directive(tok token.Token) {
+	d, ok := ast.ParseDirective(tok)
+	if !ok {
+		p.errors = append(p.errors, fmt.Errorf("%d:%d: expected directive name, got %v %q", tok.Line, tok.Column, tok.Kind, tok.Value))
+		return
+	}
+	p.directives = append(p.directives, d)
+}
+
+// takeDoc returns the comment group documenting the current token
+// and drops the ones of the tokens already parsed
+func (p *Parser) takeDoc() *ast.CommentGroup {
+	p.fill(p.position)
+	for len(p.docs) > 0 && p.docs[0].pos < p.position {
+		p.docs = p.docs[1:]
+	}
+
+	if len(p.docs) > 0 && p.docs[0].pos == p.position {
+		g := p.docs[0].group
+		p.docs = p.docs[1:]
+		return g
+	}
+	return nil
+}
+
+// setDoc attaches the comment group to the declaration
+func setDoc(decl ast.Decl, g *ast.CommentGroup) {
+	switch v := decl.(type) {
+	case *ast.FuncDecl:
+		v.Doc = g
+	case *ast.ConstDecl:
+		v.Doc = g
+	case *ast.VarDecl:
+		v.Doc = g
+	case *ast.GenDecl:
+		v.Doc = g
+	case *ast.StructDecl:
+		v.Doc = g
+	case *ast.InterfaceDecl:
+		v.Doc = g
+	case *ast.EnumDecl:
+		v.Doc = g
+	case *ast.SumDecl:
+		v.Doc = g
+	case *ast.DefinedTypeDecl:
+		v.Doc = g
+	}
+}
+
+// shrink drops consumed tokens from the lookahead window
+// keeping only the previous one
+func (p *Parser) shrink() {
+	if p.src == nil || p.position-p.base < windowSize {
+		return
+	}
+
+	drop := p.position - 1 - p.base
+	n := copy(p.Tokens, p.Tokens[drop:])
+	p.Tokens = p.Tokens[:n]
+	p.base += drop
 }
 
 // kind returns only the current token kind
@@ -75,6 +262,7 @@ func (p *Parser) next() token.Token {
 	tok := p.peek()
 	if tok.Kind != token.EOF {
 		p.position++
+		p.shrink()
 	}
 	return tok
 }
@@ -87,10 +275,10 @@ func (p *Parser) kindNext(pos int) token.Kind {
 // peekNext returns only the next token without
 // advancing its position. Used as lookahead.
 func (p *Parser) peekNext(pos int) token.Token {
-	if pos >= len(p.Tokens) {
+	if !p.fill(pos) {
 		return token.Token{Kind: token.EOF}
 	}
-	return p.Tokens[pos]
+	return p.Tokens[pos-p.base]
 }
 
 // match compares the current token with the provided token Kind
@@ -145,26 +333,84 @@ func (p *Parser) consumeTo(k token.Kind) {
 	}
 }
 
+// failedDecl returns true when the declarations just parsed are bad
+// or when errors were reported and the parser stopped in the middle of a line
+func (p *Parser) failedDecl(decls []ast.Decl, errs int) bool {
+	for _, decl := range decls {
+		if _, ok := decl.(*ast.BadDecl); ok {
+			return true
+		}
+	}
+	return len(p.errors) > errs && p.kind() != token.EOF && !p.newlineSincePrev()
+}
+
+// syncDecl skips tokens up to the next file level declaration
+// which is a declaration keyword starting a line outside braces
+func (p *Parser) syncDecl() {
+	depth := 0
+	for p.kind() != token.EOF {
+		switch p.kind() {
+		case token.LBrace:
+			depth++
+		case token.RBrace:
+			depth--
+		default:
+			if depth <= 0 && token.IsDeclStart(p.kind()) && p.newlineSincePrev() {
+				return
+			}
+		}
+		_ = p.next()
+	}
+}
+
+// syncStmt skips tokens up to the next statement which is either
+// a statement keyword, a ';', a new line or the '}' closing the block.
+// Nested parentheses, brackets and braces are skipped entirely
+func (p *Parser) syncStmt() {
+	depth := 0
+	for p.kind() != token.EOF {
+		if depth == 0 && p.newlineSincePrev() {
+			return
+		}
+
+		switch p.kind() {
+		case token.LBrace, token.LParen, token.LBracket:
+			depth++
+		case token.RBrace, token.RParen, token.RBracket:
+			if depth == 0 && p.kind() == token.RBrace {
+				return
+			}
+			if depth > 0 {
+				depth--
+			}
+		case token.SemiComma:
+			if depth == 0 {
+				return
+			}
+		default:
+			if depth == 0 && token.IsStmtStart(p.kind()) {
+				return
+			}
+		}
+		_ = p.next()
+	}
+}
+
 // newlineSincePrev is a boolean validating if we change line
 func (p *Parser) newlineSincePrev() bool {
 	if p.peek().Line == 0 {
 		return false
 	}
-	return p.peek().Line > p.Tokens[p.position-1].Line
+	return p.peek().Line > p.peekNext(p.position-1).Line
 }
 
 // lookForInForHeader loops against for statements to find
 // provided token Kind and returns true when found
 func (p *Parser) lookForInForHeader(k token.Kind) bool {
-	pos := p.position
-	if pos >= len(p.Tokens) {
-		return false
-	}
-	for p.Tokens[pos].Kind != token.LBrace && p.Tokens[pos].Kind != token.EOF {
-		if p.Tokens[pos].Kind == k {
+	for pos := p.position; p.kindNext(pos) != token.LBrace && p.kindNext(pos) != token.EOF; pos++ {
+		if p.kindNext(pos) == k {
 			return true
 		}
-		pos++
 	}
 
 	return false
@@ -173,15 +419,10 @@ func (p *Parser) lookForInForHeader(k token.Kind) bool {
 // lookForInSwitchHeader loops against for statements to find
 // provided token Kind and returns true when found
 func (p *Parser) lookForInSwitchHeader(k token.Kind) bool {
-	pos := p.position
-	if pos >= len(p.Tokens) {
-		return false
-	}
-	for p.Tokens[pos].Kind != token.LBrace && p.Tokens[pos].Kind != token.EOF {
-		if p.Tokens[pos].Kind == k {
+	for pos := p.position; p.kindNext(pos) != token.LBrace && p.kindNext(pos) != token.EOF; pos++ {
+		if p.kindNext(pos) == k {
 			return true
 		}
-		pos++
 	}
 
 	return false
@@ -190,39 +431,25 @@ func (p *Parser) lookForInSwitchHeader(k token.Kind) bool {
 // lookForInSwitchCaseHeader loops against for statements to find
 // provided token Kind and returns true when found
 func (p *Parser) lookForInSwitchCaseHeader(k token.Kind) bool {
-	pos := p.position
-	if pos >= len(p.Tokens) {
-		return false
-	}
-	for p.Tokens[pos].Kind != token.Colon && p.Tokens[pos].Kind != token.EOF {
-		if p.Tokens[pos].Kind == k {
+	for pos := p.position; p.kindNext(pos) != token.Colon && p.kindNext(pos) != token.EOF; pos++ {
+		if p.kindNext(pos) == k {
 			return true
 		}
-		pos++
 	}
 
 	return false
 }
 
-// lookForInSliceHeader loops against for statements to find
-// provided token Kind and returns true when found
-func (p *Parser) lookForInSliceHeader(k token.Kind) bool {
-	pos := p.position
-	if pos >= len(p.Tokens) {
-		return false
-	}
-	for p.Tokens[pos].Kind != token.RBracket && p.Tokens[pos].Kind != token.EOF {
-		if p.Tokens[pos].Kind == k {
-			return true
-		}
-		pos++
-	}
-
-	return false
+// cancelled returns true when the context passed to ParseFile is done
+func (p *Parser) cancelled() bool {
+	return p.ctx != nil && p.ctx.Err() != nil
 }
 
-// ParseFile returns the content of the file being parsed
-func (p *Parser) ParseFile() *ast.File {
+// ParseFile returns the content of the file being parsed.
+// Cancellation is checked before each declaration and statement
+// and ctx.Err() is returned with the partial file when ctx is done
+func (p *Parser) ParseFile(ctx context.Context) (*ast.File, error) {
+	p.ctx = ctx
 	kw := p.expect(token.KWPackage, "expected 'package'")
 	name := p.expectValidIdent(token.Ident, true, "expected package name")
 	f := &ast.File{
@@ -231,30 +458,47 @@ func (p *Parser) ParseFile() *ast.File {
 	}
 
 	for p.kind() != token.EOF {
+		if p.cancelled() {
+			f.Directives = p.directives
+			return f, ctx.Err()
+		}
+
+		count, errs := len(f.Decls), len(p.errors)
+		doc := p.takeDoc()
 		switch p.kind() {
+		case token.KWImport:
+			if count > 0 {
+				tok := p.peek()
+				p.errors = append(p.errors, fmt.Errorf("%d:%d: imports must appear before other declarations, got %v %q", tok.Line, tok.Column, tok.Kind, tok.Value))
+			}
+			f.Imports = append(f.Imports, p.parseImportDecl())
+
 		case token.KWConst:
 			f.Decls = append(f.Decls, p.parseConstDecl())
 
+		case token.KWVar:
+			f.Decls = append(f.Decls, p.parseVarDecl())
+
 		case token.KWFunc:
 			f.Decls = append(f.Decls, p.parseFuncDecl())
 
 		case token.KWType:
-			if token.IsValidTypeDecl(p.kindNext(p.position + 2)) {
-				if p.kindNext(p.position+2) == token.KWStruct {
+			if kind := p.typeDeclKind(); token.IsValidTypeDecl(kind) {
+				if kind == token.KWStruct {
 					f.Decls = append(f.Decls, p.parseStructDecl())
-				} else if p.kindNext(p.position+2) == token.KWInterface {
+				} else if kind == token.KWInterface {
 					f.Decls = append(f.Decls, p.parseInterfaceDecl())
-				} else if p.kindNext(p.position+2) == token.KWEnum {
+				} else if kind == token.KWEnum {
 					f.Decls = append(f.Decls, p.parseEnumDecl())
-				} else if p.kindNext(p.position+2) == token.KWSum {
+				} else if kind == token.KWSum {
 					f.Decls = append(f.Decls, p.parseSumDecl())
 				} else {
 					f.Decls = append(f.Decls, p.parseDefinedDecl())
 				}
 			} else {
-				tok := p.peek()
+				tok := p.next()
 				p.errors = append(p.errors, fmt.Errorf("%d:%d: unsupported file statement starting with %d %q", tok.Line, tok.Column, tok.Kind, tok.Value))
-				p.consumeTo(token.RBrace)
+				p.syncDecl()
 			}
 
 		case token.KWComptime:
@@ -264,14 +508,31 @@ func (p *Parser) ParseFile() *ast.File {
 			if p.kindNext(p.position+1) == token.KWImplements {
 				f.Decls = append(f.Decls, p.parseImplementsDecl())
 			} else {
-				tok := p.peek()
+				tok := p.next()
 				p.errors = append(p.errors, fmt.Errorf("%d:%d: unsupported file statement starting with %d %q", tok.Line, tok.Column, tok.Kind, tok.Value))
-				_ = p.next()
+				p.syncDecl()
 			}
 		}
+
+		if p.failedDecl(f.Decls[count:], errs) {
+			p.syncDecl()
+		}
+
+		if doc != nil && len(f.Decls) > count {
+			setDoc(f.Decls[len(f.Decls)-1], doc)
+		}
+	}
+
+	f.Directives = p.directives
+	if p.cancelled() {
+		return f, ctx.Err()
 	}
+	return f, nil
+}
 
-	return f
+// Errors returns the parsing errors found
+func (p *Parser) Errors() []error {
+	return p.errors
 }
 
 // parseBlock returns declaration within curly braces
@@ -279,9 +540,23 @@ func (p *Parser) parseBlock() *ast.BlockStmt {
 	lb := p.expect(token.LBrace, "expected '{'")
 	var stmts []ast.Stmt
 
-	for p.kind() != token.RBrace && p.kind() != token.EOF {
-		stmts = append(stmts, p.parseStmt())
+	p.blockDepth++
+	for p.kind() != token.RBrace && p.kind() != token.EOF && !p.cancelled() {
+		pos, errs := p.position, len(p.errors)
+		stmt := p.parseStmt()
+		stmts = append(stmts, stmt)
+		if _, ok := stmt.(*ast.BadStmt); ok || len(p.errors) > errs {
+			if p.position == pos {
+				_ = p.next()
+			}
+			p.syncStmt()
+			continue
+		}
+		if p.kind() == token.SemiComma {
+			_ = p.next()
+		}
 	}
+	p.blockDepth--
 	rb := p.expect(token.RBrace, "expected '}'")
 
 	return &ast.BlockStmt{
@@ -317,6 +592,10 @@ func (p *Parser) parseStmt() ast.Stmt {
 		return p.parseForStmtExpr()
 	}
 
+	if p.kind() == token.Ident && p.kindNext(p.position+1) == token.Colon {
+		return p.parseLabeledStmt()
+	}
+
 	if p.kind() == token.KWBreak {
 		return p.parseBreakStmt()
 	}
@@ -333,21 +612,29 @@ func (p *Parser) parseStmt() ast.Stmt {
 		return p.parseFallThroughStmt()
 	}
 
-	if p.kind() == token.KWType && token.IsValidTypeDecl(p.kindNext(p.position+2)) {
-		{
-			if p.kindNext(p.position+2) == token.KWStruct {
+	if p.kind() == token.KWMatch {
+		return p.parseMatchStmt()
+	}
+
+	if p.kind() == token.KWDefer {
+		return p.parseDeferStmt()
+	}
+
+	if p.kind() == token.KWType {
+		if kind := p.typeDeclKind(); token.IsValidTypeDecl(kind) {
+			if kind == token.KWStruct {
 				return &ast.DeclStmt{
 					Decl: p.parseStructDecl(),
 				}
-			} else if p.kindNext(p.position+2) == token.KWInterface {
+			} else if kind == token.KWInterface {
 				return &ast.DeclStmt{
 					Decl: p.parseInterfaceDecl(),
 				}
-			} else if p.kindNext(p.position+2) == token.KWEnum {
+			} else if kind == token.KWEnum {
 				return &ast.DeclStmt{
 					Decl: p.parseEnumDecl(),
 				}
-			} else if p.kindNext(p.position+2) == token.KWSum {
+			} else if kind == token.KWSum {
 				return &ast.DeclStmt{
 					Decl: p.parseSumDecl(),
 				}
@@ -365,7 +652,10 @@ func (p *Parser) parseStmt() ast.Stmt {
 	_, xok := left.(*ast.IndexExpr)
 	if iok || sok || xok {
 		if token.IsAssignment(p.kind()) {
-			return p.parseStmtExpr(left)
+			return p.parseStmtExpr([]ast.Expr{left})
+		}
+		if p.kind() == token.Comma {
+			return p.parseAssignList(left)
 		}
 		if token.IsIncDec(p.kind()) {
 			return p.parseIncDecStmtExpr(left)
@@ -394,7 +684,10 @@ func (p *Parser) parseSimpleStmt() ast.Stmt {
 	_, xok := left.(*ast.IndexExpr)
 	if iok || sok || xok {
 		if token.IsAssignment(p.kind()) {
-			return p.parseStmtExpr(left)
+			return p.parseStmtExpr([]ast.Expr{left})
+		}
+		if p.kind() == token.Comma {
+			return p.parseAssignList(left)
 		}
 		if token.IsIncDec(p.kind()) {
 			return p.parseIncDecStmtExpr(left)
@@ -417,15 +710,29 @@ func (p *Parser) parseSimpleStmt() ast.Stmt {
 
 // parseExpr returns the type of declaration being parsed
 func (p *Parser) parseExpr(minPrecedence int) ast.Expr {
-	if !token.IsPrefix(p.kind()) {
+	if p.kind() == token.Illegal {
 		tok := p.next()
+		p.errors = append(p.errors, fmt.Errorf("%d:%d: illegal token %q", tok.Line, tok.Column, tok.Value))
+		return &ast.BadExpr{From: tok, Reason: "illegal token"}
+	}
+
+	// a declaration or a statement starting a new line is left
+	// to be parsed as it is likely not part of the expression
+	if !token.IsPrefix(p.kind()) {
+		tok := p.peek()
+		if !p.newlineSincePrev() || !token.IsDeclStart(tok.Kind) && !token.IsStmtStart(tok.Kind) {
+			_ = p.next()
+		}
 		p.errors = append(p.errors, fmt.Errorf("%d:%d: expected prefix expression, got %v %q", tok.Line, tok.Column, tok.Kind, tok.Value))
 		return &ast.BadExpr{From: tok, Reason: "unexpected prefix expression"}
 	}
 
-	var left ast.Expr
-	left = p.parsePrefix()
+	return p.parseExprFrom(p.parsePrefix(), minPrecedence)
+}
 
+// parseExprFrom returns the expression continuing
+// the already parsed left operand
+func (p *Parser) parseExprFrom(left ast.Expr, minPrecedence int) ast.Expr {
 	for p.kind() != token.EOF && p.peekPrecedence() >= minPrecedence {
 		if token.IsPostfix(p.kind()) {
 			left = p.parsePostfix(left)
@@ -455,13 +762,27 @@ func (p *Parser) parsePrefix() ast.Expr {
 		expr = &ast.StringLitExpr{Name: p.next()}
 
 	case token.Ident:
+		if b, ok := token.LookupBuiltin(p.peek().Value); ok && b.TypeArg && p.kindNext(p.position+1) == token.LParen {
+			return p.parseMakeExpr()
+		}
 		expr = &ast.IdentExpr{Name: p.expectValidIdent(p.kind(), true, "expected valid ident")}
 
+	case token.KWNil:
+		expr = &ast.NilExpr{Nil: p.next()}
+
+	case token.KWComptime:
+		expr = p.parseComptimeExpr()
+
 	case token.LParen:
 		expr = p.parseGroupExpr()
 
 	case token.Minus, token.Not:
 		expr = p.parseUnaryExpr()
+
+	default:
+		if token.IsConversionType(p.kind()) {
+			expr = p.parseConversionExpr(p.parseType())
+		}
 	}
 
 	return expr
@@ -495,7 +816,11 @@ func (p *Parser) parsePostfix(left ast.Expr) ast.Expr {
 		expr = p.parseSelectorExpr(left)
 
 	case token.LBracket:
-		expr = p.parseIndexExpr(left)
+		if p.isTypeArgsCall(left, p.position) {
+			expr = p.parseGenericCallExpr(left)
+		} else {
+			expr = p.parseIndexOrSliceExpr(left)
+		}
 
 	case token.LParen:
 		expr = p.parseCallExpr(left)
@@ -517,9 +842,12 @@ func (p *Parser) parseGroupExpr() *ast.ParenExpr {
 		return g
 	}
 
+	errs := len(p.errors)
 	g.Inner = p.parseExpr(LOWEST)
-	to := p.expect(token.RParen, "expected ')'")
-	g.Right = to
+	if _, ok := g.Inner.(*ast.BadExpr); ok && len(p.errors) > errs && p.kind() != token.RParen {
+		return g
+	}
+	g.Right = p.expect(token.RParen, "expected ')'")
 
 	return g
 }
@@ -542,19 +870,6 @@ func (p *Parser) parseSelectorExpr(left ast.Expr) ast.Expr {
 	}
 }
 
-// parseIndexSelector returns expressions for parsePostfix func
-func (p *Parser) parseIndexExpr(left ast.Expr) ast.Expr {
-	lb := p.expect(token.LBracket, "expected '['")
-	index := p.parseExpr(LOWEST)
-	rb := p.expect(token.RBracket, "expected ']'")
-	return &ast.IndexExpr{
-		X:        left,
-		LBracket: lb,
-		Index:    index,
-		RBracket: rb,
-	}
-}
-
 func (p *Parser) parseCallExpr(left ast.Expr) ast.Expr {
 	lb := p.expect(token.LParen, "expected '('")
 
@@ -568,6 +883,7 @@ func (p *Parser) parseCallExpr(left ast.Expr) ast.Expr {
 	}
 
 	var args []ast.Expr
+	var ellipsis token.Token
 	for p.kind() != token.RParen && p.kind() != token.EOF {
 		if p.kind() == token.Comma {
 			tok := p.next()
@@ -576,6 +892,15 @@ func (p *Parser) parseCallExpr(left ast.Expr) ast.Expr {
 		}
 
 		args = append(args, p.parseExpr(LOWEST))
+		if p.kind() == token.Ellipsis {
+			ellipsis = p.next()
+			if p.kind() != token.RParen {
+				tok := p.peek()
+				p.errors = append(p.errors, fmt.Errorf("%d:%d: can only use '...' with the last argument, got %v %q", tok.Line, tok.Column, tok.Kind, tok.Value))
+				p.consumeTo(token.RParen)
+			}
+			break
+		}
 		if p.kind() != token.Comma && p.kind() != token.RParen && p.kind() != token.EOF {
 			tok := p.next()
 			p.errors = append(p.errors, fmt.Errorf("%d:%d: unexpected expression, got %v %q", tok.Line, tok.Column, tok.Kind, tok.Value))
@@ -593,22 +918,81 @@ func (p *Parser) parseCallExpr(left ast.Expr) ast.Expr {
 
 	rb := p.expect(token.RParen, "expected ')'")
 	return &ast.CallExpr{
-		Callee: left,
-		LParen: lb,
-		Args:   args,
-		RParen: rb,
+		Callee:   left,
+		LParen:   lb,
+		Args:     args,
+		Ellipsis: ellipsis,
+		RParen:   rb,
 	}
 }
 
 // parseStmtExpr returns expressions for parseStmt func
-func (p *Parser) parseStmtExpr(left ast.Expr) *ast.AssignStmt {
+func (p *Parser) parseStmtExpr(left []ast.Expr) *ast.AssignStmt {
 	op := p.peek()
 	_ = p.next()
+	right := []ast.Expr{p.parseExpr(LOWEST)}
+	for p.kind() == token.Comma {
+		_ = p.next()
+		right = append(right, p.parseExpr(LOWEST))
+	}
+
+	if op.Kind == token.Define {
+		for _, x := range left {
+			if _, ok := x.(*ast.IdentExpr); !ok {
+				p.errors = append(p.errors, fmt.Errorf("%d:%d: expected identifier on left side of ':=', got %v %q", x.Start().Line, x.Start().Column, x.Start().Kind, x.Start().Value))
+			}
+		}
+	}
+
+	if op.Kind != token.Assign && op.Kind != token.Define && (len(left) > 1 || len(right) > 1) {
+		p.errors = append(p.errors, fmt.Errorf("%d:%d: expected single value with assignment operator, got %v %q", op.Line, op.Column, op.Kind, op.Value))
+	} else if !p.validValueCount(len(left), right) {
+		p.errors = append(p.errors, fmt.Errorf("%d:%d: assignment mismatch: %d variable(s) but %d value(s), got %v %q", op.Line, op.Column, len(left), len(right), op.Kind, op.Value))
+	}
+
 	return &ast.AssignStmt{
 		Left:     left,
 		Operator: op,
-		Right:    p.parseExpr(LOWEST),
+		Right:    right,
+	}
+}
+
+// parseAssignList returns assignments with multiple expressions
+// on the left side like a, b = b, a or q, r := divmod(x, y)
+func (p *Parser) parseAssignList(left ast.Expr) ast.Stmt {
+	lefts := []ast.Expr{left}
+	for p.kind() == token.Comma {
+		_ = p.next()
+		x := p.parseExpr(LOWEST)
+		_, iok := x.(*ast.IdentExpr)
+		_, sok := x.(*ast.SelectorExpr)
+		_, xok := x.(*ast.IndexExpr)
+		if !iok && !sok && !xok {
+			p.errors = append(p.errors, fmt.Errorf("%d:%d: expected assignable expression, got %v %q", x.Start().Line, x.Start().Column, x.Start().Kind, x.Start().Value))
+			return &ast.BadStmt{From: left.Start(), To: x.End(), Reason: "expected assignable expression"}
+		}
+		lefts = append(lefts, x)
+	}
+
+	if !token.IsAssignment(p.kind()) {
+		tok := p.peek()
+		p.errors = append(p.errors, fmt.Errorf("%d:%d: expected '=' or ':=' after expression list, got %v %q", tok.Line, tok.Column, tok.Kind, tok.Value))
+		return &ast.BadStmt{From: left.Start(), To: tok, Reason: "expected '=' or ':='"}
+	}
+	return p.parseStmtExpr(lefts)
+}
+
+// validValueCount returns true when there is one value per variable
+// or a single call returning multiple values
+func (p *Parser) validValueCount(vars int, values []ast.Expr) bool {
+	if vars == len(values) {
+		return true
+	}
+	if len(values) != 1 {
+		return false
 	}
+	_, ok := values[0].(*ast.CallExpr)
+	return ok
 }
 
 // isPublic returns if the field is public or not
@@ -0,0 +1,98 @@
+package parser
+
+import (
+	"fmt"
+
+	"github.com/orilang/gori/ast"
+	"github.com/orilang/gori/token"
+)
+
+// parseMatchStmt returns pattern matching statement like
+// match s { case Circle(r) if r > 0: ... case _: ... }
+func (p *Parser) parseMatchStmt() ast.Stmt {
+	kw := p.expect(token.KWMatch, "expected 'match'")
+	s := &ast.MatchStmt{
+		Match: kw,
+	}
+
+	if p.kind() == token.LBrace {
+		p.errors = append(p.errors, fmt.Errorf("%d:%d: expected expression after 'match', got %v %q", p.peek().Line, p.peek().Column, p.peek().Kind, p.peek().Value))
+		return &ast.BadStmt{From: kw, To: p.peek(), Reason: "expected expression before '{'"}
+	}
+	s.X = p.parseExpr(LOWEST)
+	s.LBrace = p.expect(token.LBrace, "expected '{'")
+
+	for p.kind() != token.RBrace && p.kind() != token.EOF && !p.cancelled() {
+		if p.kind() != token.KWCase {
+			p.errors = append(p.errors, fmt.Errorf("%d:%d: expected 'case', got %v %q", p.peek().Line, p.peek().Column, p.peek().Kind, p.peek().Value))
+			return &ast.BadStmt{From: kw, To: p.peek(), Reason: "expected 'case'"}
+		}
+
+		mc := ast.MatchClause{
+			Case: p.expect(token.KWCase, "expected 'case'"),
+		}
+		mc.Pattern = p.parsePattern(true)
+		if p.kind() == token.KWIf {
+			mc.If = p.next()
+			mc.Guard = p.parseExpr(LOWEST)
+		}
+
+		if p.kind() != token.Colon {
+			p.errors = append(p.errors, fmt.Errorf("%d:%d: expected ':' after pattern, got %v %q", p.peek().Line, p.peek().Column, p.peek().Kind, p.peek().Value))
+			return &ast.BadStmt{From: kw, To: p.peek(), Reason: "expected ':' after pattern"}
+		}
+		mc.Colon = p.next()
+
+		for p.kind() != token.KWCase && p.kind() != token.RBrace && p.kind() != token.EOF && !p.cancelled() {
+			mc.Body = append(mc.Body, p.parseStmt())
+		}
+		s.Cases = append(s.Cases, mc)
+	}
+	s.RBrace = p.expect(token.RBrace, "expected '}'")
+
+	return s
+}
+
+// parsePattern returns a match pattern.
+// At the top level, an identifier is a variant like Empty
+// while inside variant params it binds the value
+func (p *Parser) parsePattern(top bool) ast.Pattern {
+	tok := p.peek()
+	if tok.Kind != token.Ident {
+		_ = p.next()
+		p.errors = append(p.errors, fmt.Errorf("%d:%d: expected pattern, got %v %q", tok.Line, tok.Column, tok.Kind, tok.Value))
+		return &ast.WildcardPattern{Underscore: tok}
+	}
+
+	_ = p.next()
+	if tok.Value == "_" {
+		return &ast.WildcardPattern{Underscore: tok}
+	}
+
+	if p.kind() != token.LParen {
+		if top {
+			return &ast.VariantPattern{Name: tok}
+		}
+		return &ast.BindingPattern{Name: tok}
+	}
+
+	vp := &ast.VariantPattern{
+		Name:   tok,
+		LParen: p.next(),
+	}
+	for p.kind() != token.RParen && p.kind() != token.EOF {
+		vp.Params = append(vp.Params, p.parsePattern(false))
+		if p.kind() == token.Comma {
+			_ = p.next()
+			continue
+		}
+		if p.kind() != token.RParen {
+			tok := p.peek()
+			p.errors = append(p.errors, fmt.Errorf("%d:%d: expected ',' or ')' after pattern, got %v %q", tok.Line, tok.Column, tok.Kind, tok.Value))
+			p.consumeTo(token.RParen)
+		}
+	}
+	vp.RParen = p.expect(token.RParen, "expected ')'")
+
+	return vp
+}
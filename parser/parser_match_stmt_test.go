@@ -0,0 +1,239 @@
+package parser
+
+import (
+	"context"
+	"testing"
+
+	"github.com/orilang/gori/ast"
+	"github.com/orilang/gori/lexer"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParser_match_stmt(t *testing.T) {
+	assert := assert.New(t)
+
+	t.Run("match_variants", func(t *testing.T) {
+		lex, err := lexer.NewLexer(lexer.Config{StringOnly: true})
+		assert.Nil(err)
+		data := `package main
+
+func area(s Shape) float {
+  match s {
+  case Circle(r) if r > 0:
+    return r * r
+  case Rect(w, _):
+    return w
+  case Empty:
+    return 0
+  case _:
+    return 1
+  }
+}
+`
+		result := `File
+ Package: "package" @1:1 (kind=8)
+ Name: "main" @1:9 (kind=3)
+ Decls
+  FuncDecl
+   Function: "func" @3:1 (kind=10)
+   Name: "area" @3:6 (kind=3)
+   Params
+    Param
+     Ident: "s" @3:11 (kind=3)
+     Type
+      NamedType
+       Ident: "Shape" @3:13 (kind=3)
+   Results
+     Param
+      Type
+       NamedType
+        Ident: "float" @3:20 (kind=20)
+   Body
+    BlockStmt
+     LBrace: "{" @3:26 (kind=41)
+     Stmts
+      MatchStmt
+       Match: "match" @4:3 (kind=84)
+       X
+        IdentExpr
+         Name: "s" @4:9 (kind=3)
+       LBrace: "{" @4:11 (kind=41)
+       Case: "case" @5:3 (kind=35)
+        Pattern
+         VariantPattern
+          Name: "Circle" @5:8 (kind=3)
+          LParen: "(" @5:14 (kind=39)
+           BindingPattern
+            Name: "r" @5:15 (kind=3)
+          RParen: ")" @5:16 (kind=40)
+        If: "if" @5:18 (kind=29)
+        Guard
+         BinaryExpr
+          IdentExpr
+           Name: "r" @5:21 (kind=3)
+          Operator: ">" @5:23 (kind=66)
+          IntLitExpr
+           Value: "0" @5:25 (kind=4)
+       Colon: ":" @5:26 (kind=47)
+        Body:
+         ReturnStmt
+          Values
+           BinaryExpr
+            IdentExpr
+             Name: "r" @6:12 (kind=3)
+            Operator: "*" @6:14 (kind=57)
+            IdentExpr
+             Name: "r" @6:16 (kind=3)
+       Case: "case" @7:3 (kind=35)
+        Pattern
+         VariantPattern
+          Name: "Rect" @7:8 (kind=3)
+          LParen: "(" @7:12 (kind=39)
+           BindingPattern
+            Name: "w" @7:13 (kind=3)
+           WildcardPattern
+            Underscore: "_" @7:16 (kind=3)
+          RParen: ")" @7:17 (kind=40)
+       Colon: ":" @7:18 (kind=47)
+        Body:
+         ReturnStmt
+          Values
+           IdentExpr
+            Name: "w" @8:12 (kind=3)
+       Case: "case" @9:3 (kind=35)
+        Pattern
+         VariantPattern
+          Name: "Empty" @9:8 (kind=3)
+       Colon: ":" @9:13 (kind=47)
+        Body:
+         ReturnStmt
+          Values
+           IntLitExpr
+            Value: "0" @10:12 (kind=4)
+       Case: "case" @11:3 (kind=35)
+        Pattern
+         WildcardPattern
+          Underscore: "_" @11:8 (kind=3)
+       Colon: ":" @11:9 (kind=47)
+        Body:
+         ReturnStmt
+          Values
+           IntLitExpr
+            Value: "1" @12:12 (kind=4)
+       RBrace: "}" @13:3 (kind=42)
+     RBrace: "}" @14:1 (kind=42)
+`
+		parser := New(lex.FetchTokensFromString(data))
+		pr, err := parser.ParseFile(context.Background())
+		assert.Nil(err)
+		assert.Equal(result, ast.Dump(pr))
+		assert.Equal(0, len(parser.errors))
+	})
+
+	t.Run("match_nested", func(t *testing.T) {
+		lex, err := lexer.NewLexer(lexer.Config{StringOnly: true})
+		assert.Nil(err)
+		data := `package main
+
+func main() {
+  match x {
+  case Some(Pair(a, b)):
+    print(a, b)
+  }
+}
+`
+		result := `File
+ Package: "package" @1:1 (kind=8)
+ Name: "main" @1:9 (kind=3)
+ Decls
+  FuncDecl
+   Function: "func" @3:1 (kind=10)
+   Name: "main" @3:6 (kind=3)
+   Params
+    (none)
+   Body
+    BlockStmt
+     LBrace: "{" @3:13 (kind=41)
+     Stmts
+      MatchStmt
+       Match: "match" @4:3 (kind=84)
+       X
+        IdentExpr
+         Name: "x" @4:9 (kind=3)
+       LBrace: "{" @4:11 (kind=41)
+       Case: "case" @5:3 (kind=35)
+        Pattern
+         VariantPattern
+          Name: "Some" @5:8 (kind=3)
+          LParen: "(" @5:12 (kind=39)
+           VariantPattern
+            Name: "Pair" @5:13 (kind=3)
+            LParen: "(" @5:17 (kind=39)
+             BindingPattern
+              Name: "a" @5:18 (kind=3)
+             BindingPattern
+              Name: "b" @5:21 (kind=3)
+            RParen: ")" @5:22 (kind=40)
+          RParen: ")" @5:23 (kind=40)
+       Colon: ":" @5:24 (kind=47)
+        Body:
+         CallExpr
+          Callee
+           IdentExpr
+            Name: "print" @6:5 (kind=3)
+          LParent: "(" @6:10 (kind=39)
+          Args:
+           IdentExpr
+            Name: "a" @6:11 (kind=3)
+           IdentExpr
+            Name: "b" @6:14 (kind=3)
+          RParent: ")" @6:15 (kind=40)
+       RBrace: "}" @7:3 (kind=42)
+     RBrace: "}" @8:1 (kind=42)
+`
+		parser := New(lex.FetchTokensFromString(data))
+		pr, err := parser.ParseFile(context.Background())
+		assert.Nil(err)
+		assert.Equal(result, ast.Dump(pr))
+		assert.Equal(0, len(parser.errors))
+	})
+
+	t.Run("errors", func(t *testing.T) {
+		tests := []struct {
+			input    string
+			expected string
+		}{
+			{
+				input:    "package main\n\nfunc main() {\n  match {\n  }\n}\n",
+				expected: `4:9: expected expression after 'match', got 41 "{"`,
+			},
+			{
+				input:    "package main\n\nfunc main() {\n  match x {\n  default:\n  }\n}\n",
+				expected: `5:3: expected 'case', got 36 "default"`,
+			},
+			{
+				input:    "package main\n\nfunc main() {\n  match x {\n  case Circle(r;\n  }\n}\n",
+				expected: `5:16: expected ',' or ')' after pattern, got 46 ";"`,
+			},
+			{
+				input:    "package main\n\nfunc main() {\n  match x {\n  case 1:\n  }\n}\n",
+				expected: `5:8: expected pattern, got 4 "1"`,
+			},
+			{
+				input:    "package main\n\nfunc main() {\n  match x {\n  case Circle(r) r > 0:\n  }\n}\n",
+				expected: `5:18: expected ':' after pattern, got 3 "r"`,
+			},
+		}
+
+		for _, tc := range tests {
+			lex, err := lexer.NewLexer(lexer.Config{StringOnly: true})
+			assert.Nil(err)
+			parser := New(lex.FetchTokensFromString(tc.input))
+			_, err = parser.ParseFile(context.Background())
+			assert.Nil(err)
+			if assert.Greater(len(parser.errors), 0, tc.input) {
+				assert.Equal(tc.expected, parser.errors[0].Error(), tc.input)
+			}
+		}
+	})
+}
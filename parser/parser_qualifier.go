@@ -0,0 +1,45 @@
+package parser
+
+import (
+	"fmt"
+
+	"github.com/orilang/gori/ast"
+	"github.com/orilang/gori/token"
+)
+
+// parseQualifier returns the ownership qualifier view or shared
+// or an empty token when there is none
+func (p *Parser) parseQualifier() token.Token {
+	if !token.IsQualifier(p.kind()) {
+		return token.Token{}
+	}
+
+	q := p.next()
+	for token.IsQualifier(p.kind()) {
+		tok := p.next()
+		p.errors = append(p.errors, fmt.Errorf("%d:%d: only one qualifier is allowed, got %v %q", tok.Line, tok.Column, tok.Kind, tok.Value))
+	}
+	return q
+}
+
+// checkViewInit reports a view qualifier initialized with a literal
+// value like var x view []int = []int{1} as there is nothing to borrow from
+func (p *Parser) checkViewInit(q token.Token, init ast.Expr) {
+	if q.Kind != token.KWView || init == nil {
+		return
+	}
+
+	switch init.(type) {
+	case *ast.IntLitExpr, *ast.FloatLitExpr, *ast.BoolLitExpr, *ast.StringLitExpr, *ast.SliceLitExpr:
+		tok := init.Start()
+		p.errors = append(p.errors, fmt.Errorf("%d:%d: view cannot borrow a literal value, got %v %q", tok.Line, tok.Column, tok.Kind, tok.Value))
+	}
+}
+
+// qualifierOffset returns 1 when the token at pos is a qualifier
+func (p *Parser) qualifierOffset(pos int) int {
+	if token.IsQualifier(p.kindNext(pos)) {
+		return 1
+	}
+	return 0
+}
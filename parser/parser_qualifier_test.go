@@ -0,0 +1,350 @@
+package parser
+
+import (
+	"context"
+	"testing"
+
+	"github.com/orilang/gori/ast"
+	"github.com/orilang/gori/lexer"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParser_qualifier(t *testing.T) {
+	assert := assert.New(t)
+
+	t.Run("func_params", func(t *testing.T) {
+		lex, err := lexer.NewLexer(lexer.Config{StringOnly: true})
+		assert.Nil(err)
+		data := `package main
+
+func push(s shared Stack, items view []int) (n view []int) {}
+`
+		result := `File
+ Package: "package" @1:1 (kind=8)
+ Name: "main" @1:9 (kind=3)
+ Decls
+  FuncDecl
+   Function: "func" @3:1 (kind=10)
+   Name: "push" @3:6 (kind=3)
+   Params
+    Param
+     Ident: "s" @3:11 (kind=3)
+     Qualifier: "shared" @3:13 (kind=77)
+     Type
+      NamedType
+       Ident: "Stack" @3:20 (kind=3)
+    Param
+     Ident: "items" @3:27 (kind=3)
+     Qualifier: "view" @3:33 (kind=76)
+     Type
+      SliceType:
+       LBracket: "[" @3:38 (kind=43)
+       RBracket: "]" @3:39 (kind=44)
+       NamedType
+        Ident: "int" @3:40 (kind=12)
+   Results
+    LParent: "(" @3:45 (kind=39)
+     Param
+      Ident: "n" @3:46 (kind=3)
+      Qualifier: "view" @3:48 (kind=76)
+      Type
+       SliceType:
+        LBracket: "[" @3:53 (kind=43)
+        RBracket: "]" @3:54 (kind=44)
+        NamedType
+         Ident: "int" @3:55 (kind=12)
+    RParent: ")" @3:58 (kind=40)
+   Body
+`
+		parser := New(lex.FetchTokensFromString(data))
+		pr, err := parser.ParseFile(context.Background())
+		assert.Nil(err)
+		assert.Equal(result, ast.Dump(pr))
+		assert.Equal(0, len(parser.errors))
+	})
+
+	t.Run("func_results", func(t *testing.T) {
+		lex, err := lexer.NewLexer(lexer.Config{StringOnly: true})
+		assert.Nil(err)
+		data := `package main
+
+func first(x view []int) view []int {}
+`
+		result := `File
+ Package: "package" @1:1 (kind=8)
+ Name: "main" @1:9 (kind=3)
+ Decls
+  FuncDecl
+   Function: "func" @3:1 (kind=10)
+   Name: "first" @3:6 (kind=3)
+   Params
+    Param
+     Ident: "x" @3:12 (kind=3)
+     Qualifier: "view" @3:14 (kind=76)
+     Type
+      SliceType:
+       LBracket: "[" @3:19 (kind=43)
+       RBracket: "]" @3:20 (kind=44)
+       NamedType
+        Ident: "int" @3:21 (kind=12)
+   Results
+     Param
+      Qualifier: "view" @3:26 (kind=76)
+      Type
+       SliceType:
+        LBracket: "[" @3:31 (kind=43)
+        RBracket: "]" @3:32 (kind=44)
+        NamedType
+         Ident: "int" @3:33 (kind=12)
+   Body
+`
+		parser := New(lex.FetchTokensFromString(data))
+		pr, err := parser.ParseFile(context.Background())
+		assert.Nil(err)
+		assert.Equal(result, ast.Dump(pr))
+		assert.Equal(0, len(parser.errors))
+	})
+
+	t.Run("method_receiver", func(t *testing.T) {
+		lex, err := lexer.NewLexer(lexer.Config{StringOnly: true})
+		assert.Nil(err)
+		data := `package main
+
+func (s view Stack) Len() int {}
+`
+		result := `File
+ Package: "package" @1:1 (kind=8)
+ Name: "main" @1:9 (kind=3)
+ Decls
+  FuncDecl
+   Function: "func" @3:1 (kind=10)
+   Recv
+    Ident: "s" @3:7 (kind=3)
+    Qualifier: "view" @3:9 (kind=76)
+    Type
+     NamedType
+      Ident: "Stack" @3:14 (kind=3)
+   Name: "Len" @3:21 (kind=3)
+   Params
+    (none)
+   Results
+     Param
+      Type
+       NamedType
+        Ident: "int" @3:27 (kind=12)
+   Body
+`
+		parser := New(lex.FetchTokensFromString(data))
+		pr, err := parser.ParseFile(context.Background())
+		assert.Nil(err)
+		assert.Equal(result, ast.Dump(pr))
+		assert.Equal(0, len(parser.errors))
+	})
+
+	t.Run("struct_fields", func(t *testing.T) {
+		lex, err := lexer.NewLexer(lexer.Config{StringOnly: true})
+		assert.Nil(err)
+		data := `package main
+
+type Node struct {
+  next shared Node
+  data view []int
+}
+`
+		result := `File
+ Package: "package" @1:1 (kind=8)
+ Name: "main" @1:9 (kind=3)
+ Decls
+  StructDecl:
+   Type: "type" @3:1 (kind=26)
+   Name: "Node" @3:6 (kind=3)
+   Struct: "struct" @3:11 (kind=27)
+   Public: true
+   LBrace: "{" @3:18 (kind=41)
+    Name: "next" @4:3 (kind=3)
+    Qualifier: "shared" @4:8 (kind=77)
+    Type:
+     NamedType
+      Ident: "Node" @4:15 (kind=3)
+    Name: "data" @5:3 (kind=3)
+    Qualifier: "view" @5:8 (kind=76)
+    Type:
+     SliceType:
+      LBracket: "[" @5:13 (kind=43)
+      RBracket: "]" @5:14 (kind=44)
+      NamedType
+       Ident: "int" @5:15 (kind=12)
+   RBrace: "}" @6:1 (kind=42)
+`
+		parser := New(lex.FetchTokensFromString(data))
+		pr, err := parser.ParseFile(context.Background())
+		assert.Nil(err)
+		assert.Equal(result, ast.Dump(pr))
+		assert.Equal(0, len(parser.errors))
+	})
+
+	t.Run("range_vars", func(t *testing.T) {
+		lex, err := lexer.NewLexer(lexer.Config{StringOnly: true})
+		assert.Nil(err)
+		data := `package main
+
+func main() {
+  for i, view v := range xs {}
+}
+`
+		result := `File
+ Package: "package" @1:1 (kind=8)
+ Name: "main" @1:9 (kind=3)
+ Decls
+  FuncDecl
+   Function: "func" @3:1 (kind=10)
+   Name: "main" @3:6 (kind=3)
+   Params
+    (none)
+   Body
+    BlockStmt
+     LBrace: "{" @3:13 (kind=41)
+     Stmts
+      RangeStmt
+       For: "for" @4:3 (kind=31)
+       Key
+        IdentExpr
+         Name: "i" @4:7 (kind=3)
+       Condition
+        Qualifier: "view" @4:10 (kind=76)
+        IdentExpr
+         Name: "v" @4:15 (kind=3)
+       Op: ":=" @4:17 (kind=50)
+       Range: "range" @4:20 (kind=71)
+        IdentExpr
+         Name: "xs" @4:26 (kind=3)
+     RBrace: "}" @5:1 (kind=42)
+`
+		parser := New(lex.FetchTokensFromString(data))
+		pr, err := parser.ParseFile(context.Background())
+		assert.Nil(err)
+		assert.Equal(result, ast.Dump(pr))
+		assert.Equal(0, len(parser.errors))
+	})
+
+	t.Run("var_shared", func(t *testing.T) {
+		lex, err := lexer.NewLexer(lexer.Config{StringOnly: true})
+		assert.Nil(err)
+		data := `package main
+
+func main() {
+  var x shared Node = y
+}
+`
+		result := `File
+ Package: "package" @1:1 (kind=8)
+ Name: "main" @1:9 (kind=3)
+ Decls
+  FuncDecl
+   Function: "func" @3:1 (kind=10)
+   Name: "main" @3:6 (kind=3)
+   Params
+    (none)
+   Body
+    BlockStmt
+     LBrace: "{" @3:13 (kind=41)
+     Stmts
+      VarDecl
+       Var: "var" @4:3 (kind=11)
+       Name: "x" @4:7 (kind=3)
+       Qualifier: "shared" @4:9 (kind=77)
+       Type
+        NamedType
+         Ident: "Node" @4:16 (kind=3)
+       Eq: "=" @4:21 (kind=49)
+       Init
+        IdentExpr
+         Name: "y" @4:23 (kind=3)
+     RBrace: "}" @5:1 (kind=42)
+`
+		parser := New(lex.FetchTokensFromString(data))
+		pr, err := parser.ParseFile(context.Background())
+		assert.Nil(err)
+		assert.Equal(result, ast.Dump(pr))
+		assert.Equal(0, len(parser.errors))
+	})
+
+	t.Run("interface_method", func(t *testing.T) {
+		lex, err := lexer.NewLexer(lexer.Config{StringOnly: true})
+		assert.Nil(err)
+		data := `package main
+
+type Reader interface {
+  Read(b view Buffer) (view Buffer, int)
+}
+`
+		result := `File
+ Package: "package" @1:1 (kind=8)
+ Name: "main" @1:9 (kind=3)
+ Decls
+  InterfaceDecl:
+   Type: "type" @3:1 (kind=26)
+   Name: "Reader" @3:6 (kind=3)
+   Interface: "interface" @3:13 (kind=28)
+   Public: true
+   LBrace: "{" @3:23 (kind=41)
+   Name: "Read" @4:3 (kind=3)
+   Params
+    Param
+     Ident: "b" @4:8 (kind=3)
+     Qualifier: "view" @4:10 (kind=76)
+     Type
+      NamedType
+       Ident: "Buffer" @4:15 (kind=3)
+   Results
+    LParent: "(" @4:23 (kind=39)
+     Param
+      Qualifier: "view" @4:24 (kind=76)
+      Type
+       NamedType
+        Ident: "Buffer" @4:29 (kind=3)
+     Param
+      Type
+       NamedType
+        Ident: "int" @4:37 (kind=12)
+    RParent: ")" @4:40 (kind=40)
+   RBrace: "}" @5:1 (kind=42)
+`
+		parser := New(lex.FetchTokensFromString(data))
+		pr, err := parser.ParseFile(context.Background())
+		assert.Nil(err)
+		assert.Equal(result, ast.Dump(pr))
+		assert.Equal(0, len(parser.errors))
+	})
+
+	t.Run("errors", func(t *testing.T) {
+		tests := []struct {
+			input    string
+			expected string
+		}{
+			{
+				input:    "package main\n\nfunc main() {\n  var x view []int = []int{1, 2}\n}\n",
+				expected: `4:22: view cannot borrow a literal value, got 43 "["`,
+			},
+			{
+				input:    "package main\n\nfunc main() {\n  var x view string = \"a\"\n}\n",
+				expected: `4:23: view cannot borrow a literal value, got 6 "\"a\""`,
+			},
+			{
+				input:    "package main\n\nfunc f(x view shared int) {}\n",
+				expected: `3:15: only one qualifier is allowed, got 77 "shared"`,
+			},
+		}
+
+		for _, tc := range tests {
+			lex, err := lexer.NewLexer(lexer.Config{StringOnly: true})
+			assert.Nil(err)
+			parser := New(lex.FetchTokensFromString(tc.input))
+			_, err = parser.ParseFile(context.Background())
+			assert.Nil(err)
+			if assert.Greater(len(parser.errors), 0, tc.input) {
+				assert.Equal(tc.expected, parser.errors[0].Error(), tc.input)
+			}
+		}
+	})
+}
@@ -2,83 +2,23 @@ package parser
 
 import (
 	"fmt"
-	"slices"
 
 	"github.com/orilang/gori/ast"
 	"github.com/orilang/gori/token"
 )
 
-// parseSliceOrArrayType returns slice or array type
-func (p *Parser) parseSliceOrArrayType() ast.Type {
-	var (
-		slice ast.SliceType
-		array ast.ArrayType
-		nt    ast.NamedType
-	)
-	kindList := []token.Kind{
-		token.Comma,
-		token.SemiComma,
-		token.RParen,
-		token.LBrace,
-		token.Assign,
-		token.EOF,
-	}
-
-	lb := p.expect(token.LBracket, "expected '['")
-	if p.kind() == token.IntLit {
-		array.LBracket = lb
-		size := p.parseExpr(LOWEST)
-		rb := p.expect(token.RBracket, "expected ']'")
-		array.Len = size
-		array.RBracket = rb
-
-		for !slices.Contains(kindList, p.kind()) {
-			if !token.IsSliceType(p.kind()) {
-				p.errors = append(p.errors, fmt.Errorf("%d:%d: unexpected array type, got %v %q", p.peek().Line, p.peek().Column, p.peek().Kind, p.peek().Value))
-				p.consumeTo(token.EOF)
-				return &array
-			}
-			nt.Parts = append(nt.Parts, p.next())
-
-			if p.newlineSincePrev() {
-				break
-			}
-		}
-
-		array.Elem = &nt
-		return &array
-	}
-
-	rb := p.expect(token.RBracket, "expected ']'")
-	slice.LBracket = lb
-	slice.RBracket = rb
-
-	for !slices.Contains(kindList, p.kind()) {
-		if !token.IsSliceType(p.kind()) {
-			p.errors = append(p.errors, fmt.Errorf("%d:%d: unexpected slice type, got %v %q", p.peek().Line, p.peek().Column, p.peek().Kind, p.peek().Value))
-			p.consumeTo(token.EOF)
-			return &slice
-		}
-		nt.Parts = append(nt.Parts, p.next())
-
-		if p.newlineSincePrev() {
-			break
-		}
-	}
-
-	slice.Elem = &nt
-	return &slice
-}
-
-// parseSliceElements returns slice elements
-func (p *Parser) parseSliceElements() *ast.SliceLitExpr {
+// parseSliceElements returns slice elements.
+// After an invalid element, tokens are skipped up to a ';',
+// a new line or the '}' closing the literal
+func (p *Parser) parseSliceElements(typ ast.Type) *ast.SliceLitExpr {
 	se := &ast.SliceLitExpr{
-		Type: p.parseSliceOrArrayType(),
+		Type: typ,
 	}
 	lb := p.expect(token.LBrace, "expected '{'")
 	se.LBrace = lb
 
 	for p.kind() != token.RBrace && p.kind() != token.EOF {
+		errs := len(p.errors)
 		se.Elements = append(se.Elements, p.parseExpr(LOWEST))
 
 		if p.kind() == token.Comma {
@@ -88,6 +28,15 @@ func (p *Parser) parseSliceElements() *ast.SliceLitExpr {
 		if p.kind() == token.RBrace {
 			break
 		}
+
+		if len(p.errors) == errs {
+			tok := p.peek()
+			p.errors = append(p.errors, fmt.Errorf("%d:%d: expected ',' or '}' after slice element, got %v %q", tok.Line, tok.Column, tok.Kind, tok.Value))
+		}
+		p.syncStmt()
+		if p.kind() != token.RBrace {
+			return se
+		}
 	}
 
 	rb := p.expect(token.RBrace, "expected '}'")
@@ -100,38 +49,56 @@ func (p *Parser) parseSliceElements() *ast.SliceLitExpr {
 	return se
 }
 
-// parseSliceExpr returns expressions for parsePostfix func
-func (p *Parser) parseSliceExpr(left ast.Expr) *ast.SliceExpr {
-	lb := p.expect(token.LBracket, "LBracket expected '['")
-	x := &ast.SliceExpr{
-		X:        left,
-		LBracket: lb,
+// parseIndexOrSliceExpr returns an index expression like a[i]
+// or a slice expression like a[:], a[i:], a[:j], a[i:j] or a[i:j:k]
+func (p *Parser) parseIndexOrSliceExpr(left ast.Expr) ast.Expr {
+	lb := p.expect(token.LBracket, "expected '['")
+	if p.kind() == token.RBracket {
+		tok := p.next()
+		p.errors = append(p.errors, fmt.Errorf("%d:%d: expected index or slice bounds, got %v %q", tok.Line, tok.Column, tok.Kind, tok.Value))
+		return &ast.BadExpr{From: lb, To: tok, Reason: "expected index or slice bounds"}
 	}
 
-	if p.kind() == token.Colon {
-		x.Colon = p.next()
-		if p.kind() != token.RBracket {
-			x.High = p.parseExpr(LOWEST)
+	var low ast.Expr
+	if p.kind() != token.Colon {
+		low = p.parseExpr(LOWEST)
+		if p.kind() != token.Colon {
+			return &ast.IndexExpr{
+				X:        left,
+				LBracket: lb,
+				Index:    low,
+				RBracket: p.expect(token.RBracket, "expected ']'"),
+			}
 		}
-		x.RBracket = p.expect(token.RBracket, "RBracket expected ']'")
-		return x
 	}
 
-	x.Low = p.parseExpr(LOWEST)
-	if p.kind() != token.Colon {
-		x.RBracket = p.expect(token.RBracket, "RBracket expected ']'")
-		return x
+	x := &ast.SliceExpr{
+		X:        left,
+		LBracket: lb,
+		Low:      low,
+		Colon:    p.next(),
 	}
-
-	x.Colon = p.next()
-	if p.kind() != token.RBracket {
+	if p.kind() != token.Colon && p.kind() != token.RBracket {
 		x.High = p.parseExpr(LOWEST)
 	}
-	x.RBracket = p.expect(token.RBracket, "RBracket expected ']'")
 
-	if p.kind() == token.SemiComma {
-		_ = p.expect(token.SemiComma, "expected ';'")
+	if p.kind() == token.Colon {
+		x.Colon2 = p.next()
+		if x.High == nil {
+			p.errors = append(p.errors, fmt.Errorf("%d:%d: middle index required in 3-index slice, got %v %q", x.Colon2.Line, x.Colon2.Column, x.Colon2.Kind, x.Colon2.Value))
+		}
+		if p.kind() == token.RBracket {
+			tok := p.peek()
+			p.errors = append(p.errors, fmt.Errorf("%d:%d: final index required in 3-index slice, got %v %q", tok.Line, tok.Column, tok.Kind, tok.Value))
+		} else {
+			x.Max = p.parseExpr(LOWEST)
+		}
+		if p.kind() == token.Colon {
+			tok := p.peek()
+			p.errors = append(p.errors, fmt.Errorf("%d:%d: expected ']' after 3-index slice, got %v %q", tok.Line, tok.Column, tok.Kind, tok.Value))
+			p.consumeTo(token.RBracket)
+		}
 	}
-
+	x.RBracket = p.expect(token.RBracket, "expected ']'")
 	return x
 }
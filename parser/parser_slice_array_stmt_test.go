@@ -1,6 +1,7 @@
 package parser
 
 import (
+	"context"
 	"testing"
 
 	"github.com/orilang/gori/ast"
@@ -19,7 +20,8 @@ func TestParser_slice_array_stmt(t *testing.T) {
 const x []int=[]int{1,2,3}
 `
 		parser := New(lex.FetchTokensFromString(data))
-		pr := parser.ParseFile()
+		pr, err := parser.ParseFile(context.Background())
+		assert.Nil(err)
 		result := `File
  Package: "package" @1:1 (kind=8)
  Name: "main" @1:9 (kind=3)
@@ -65,7 +67,8 @@ func main(){
 }
 `
 		parser := New(lex.FetchTokensFromString(data))
-		pr := parser.ParseFile()
+		pr, err := parser.ParseFile(context.Background())
+		assert.Nil(err)
 		result := `File
  Package: "package" @1:1 (kind=8)
  Name: "main" @1:9 (kind=3)
@@ -145,7 +148,8 @@ func main(){
 }
 `
 		parser := New(lex.FetchTokensFromString(data))
-		pr := parser.ParseFile()
+		pr, err := parser.ParseFile(context.Background())
+		assert.Nil(err)
 		result := `File
  Package: "package" @1:1 (kind=8)
  Name: "main" @1:9 (kind=3)
@@ -221,7 +225,8 @@ func main(){
 }
 `
 		parser := New(lex.FetchTokensFromString(data))
-		pr := parser.ParseFile()
+		pr, err := parser.ParseFile(context.Background())
+		assert.Nil(err)
 		result := `File
  Package: "package" @1:1 (kind=8)
  Name: "main" @1:9 (kind=3)
@@ -276,7 +281,8 @@ func main(){
 }
 `
 		parser := New(lex.FetchTokensFromString(data))
-		pr := parser.ParseFile()
+		pr, err := parser.ParseFile(context.Background())
+		assert.Nil(err)
 		result := `File
  Package: "package" @1:1 (kind=8)
  Name: "main" @1:9 (kind=3)
@@ -334,7 +340,8 @@ func main(){
 }
 `
 		parser := New(lex.FetchTokensFromString(data))
-		pr := parser.ParseFile()
+		pr, err := parser.ParseFile(context.Background())
+		assert.Nil(err)
 		result := `File
  Package: "package" @1:1 (kind=8)
  Name: "main" @1:9 (kind=3)
@@ -408,7 +415,8 @@ func main(){
 }
 `
 		parser := New(lex.FetchTokensFromString(data))
-		pr := parser.ParseFile()
+		pr, err := parser.ParseFile(context.Background())
+		assert.Nil(err)
 		result := `File
  Package: "package" @1:1 (kind=8)
  Name: "main" @1:9 (kind=3)
@@ -450,7 +458,7 @@ func main(){
       VarDecl
        Var: "var" @5:3 (kind=11)
        Name: "y" @5:7 (kind=3)
-       View: "view" @5:9 (kind=76)
+       Qualifier: "view" @5:9 (kind=76)
        Type
         SliceType:
          LBracket: "[" @5:14 (kind=43)
@@ -510,7 +518,8 @@ func main(){
 }
 `
 		parser := New(lex.FetchTokensFromString(data))
-		pr := parser.ParseFile()
+		pr, err := parser.ParseFile(context.Background())
+		assert.Nil(err)
 		result := `File
  Package: "package" @1:1 (kind=8)
  Name: "main" @1:9 (kind=3)
@@ -527,7 +536,7 @@ func main(){
       VarDecl
        Var: "var" @4:3 (kind=11)
        Name: "y" @4:7 (kind=3)
-       View: "view" @4:9 (kind=76)
+       Qualifier: "view" @4:9 (kind=76)
        Type
         SliceType:
          LBracket: "[" @4:14 (kind=43)
@@ -560,7 +569,8 @@ func main(){
 }
 `
 		parser := New(lex.FetchTokensFromString(data))
-		pr := parser.ParseFile()
+		pr, err := parser.ParseFile(context.Background())
+		assert.Nil(err)
 		result := `File
  Package: "package" @1:1 (kind=8)
  Name: "main" @1:9 (kind=3)
@@ -577,7 +587,7 @@ func main(){
       VarDecl
        Var: "var" @4:3 (kind=11)
        Name: "y" @4:7 (kind=3)
-       View: "view" @4:9 (kind=76)
+       Qualifier: "view" @4:9 (kind=76)
        Type
         SliceType:
          LBracket: "[" @4:14 (kind=43)
@@ -586,12 +596,13 @@ func main(){
           Ident: "int" @4:16 (kind=12)
        Eq: "=" @4:19 (kind=49)
        Init
-        SliceExpr
+        IndexExpr
+         X:
          IdentExpr
           Name: "x" @4:20 (kind=3)
          LBracket: "[" @4:21 (kind=43)
-         IntLitExpr
-          Value: "6" @4:22 (kind=4)
+          IntLitExpr
+           Value: "6" @4:22 (kind=4)
          RBracket: "]" @4:23 (kind=44)
      RBrace: "}" @5:1 (kind=42)
 `
@@ -607,7 +618,8 @@ func main(){
 const x [3]int=[]int{1,2,3}
 `
 		parser := New(lex.FetchTokensFromString(data))
-		pr := parser.ParseFile()
+		pr, err := parser.ParseFile(context.Background())
+		assert.Nil(err)
 		result := `File
  Package: "package" @1:1 (kind=8)
  Name: "main" @1:9 (kind=3)
@@ -654,7 +666,8 @@ func main(){
 }
 `
 		parser := New(lex.FetchTokensFromString(data))
-		pr := parser.ParseFile()
+		pr, err := parser.ParseFile(context.Background())
+		assert.Nil(err)
 		result := `File
  Package: "package" @1:1 (kind=8)
  Name: "main" @1:9 (kind=3)
@@ -736,7 +749,8 @@ func main(){
 }
 `
 		parser := New(lex.FetchTokensFromString(data))
-		pr := parser.ParseFile()
+		pr, err := parser.ParseFile(context.Background())
+		assert.Nil(err)
 		result := `File
  Package: "package" @1:1 (kind=8)
  Name: "main" @1:9 (kind=3)
@@ -794,7 +808,8 @@ func main(){
 }
 `
 		parser := New(lex.FetchTokensFromString(data))
-		pr := parser.ParseFile()
+		pr, err := parser.ParseFile(context.Background())
+		assert.Nil(err)
 		result := `File
  Package: "package" @1:1 (kind=8)
  Name: "main" @1:9 (kind=3)
@@ -838,7 +853,7 @@ func main(){
       VarDecl
        Var: "var" @5:2 (kind=11)
        Name: "y" @5:6 (kind=3)
-       View: "view" @5:8 (kind=76)
+       Qualifier: "view" @5:8 (kind=76)
        Type
         SliceType:
          LBracket: "[" @5:13 (kind=43)
@@ -871,7 +886,8 @@ func main(){
 }
 `
 		parser := New(lex.FetchTokensFromString(data))
-		pr := parser.ParseFile()
+		pr, err := parser.ParseFile(context.Background())
+		assert.Nil(err)
 		result := `File
  Package: "package" @1:1 (kind=8)
  Name: "main" @1:9 (kind=3)
@@ -915,7 +931,7 @@ func main(){
       VarDecl
        Var: "var" @5:2 (kind=11)
        Name: "y" @5:6 (kind=3)
-       View: "view" @5:8 (kind=76)
+       Qualifier: "view" @5:8 (kind=76)
        Type
         SliceType:
          LBracket: "[" @5:13 (kind=43)
@@ -977,7 +993,8 @@ func main(){
 }
 `
 		parser := New(lex.FetchTokensFromString(data))
-		pr := parser.ParseFile()
+		pr, err := parser.ParseFile(context.Background())
+		assert.Nil(err)
 		result := `File
  Package: "package" @1:1 (kind=8)
  Name: "main" @1:9 (kind=3)
@@ -994,7 +1011,7 @@ func main(){
       VarDecl
        Var: "var" @4:2 (kind=11)
        Name: "y" @4:6 (kind=3)
-       View: "view" @4:8 (kind=76)
+       Qualifier: "view" @4:8 (kind=76)
        Type
         ArrayType:
          LBracket: "[" @4:13 (kind=43)
@@ -1029,7 +1046,8 @@ func main(){
 }
 `
 		parser := New(lex.FetchTokensFromString(data))
-		pr := parser.ParseFile()
+		pr, err := parser.ParseFile(context.Background())
+		assert.Nil(err)
 		result := `File
  Package: "package" @1:1 (kind=8)
  Name: "main" @1:9 (kind=3)
@@ -1046,7 +1064,7 @@ func main(){
       VarDecl
        Var: "var" @4:2 (kind=11)
        Name: "y" @4:6 (kind=3)
-       View: "view" @4:8 (kind=76)
+       Qualifier: "view" @4:8 (kind=76)
        Type
         SliceType:
          LBracket: "[" @4:13 (kind=43)
@@ -1055,12 +1073,13 @@ func main(){
           Ident: "int" @4:15 (kind=12)
        Eq: "=" @4:18 (kind=49)
        Init
-        SliceExpr
+        IndexExpr
+         X:
          IdentExpr
           Name: "x" @4:19 (kind=3)
          LBracket: "[" @4:20 (kind=43)
-         IntLitExpr
-          Value: "6" @4:21 (kind=4)
+          IntLitExpr
+           Value: "6" @4:21 (kind=4)
          RBracket: "]" @4:22 (kind=44)
      RBrace: "}" @5:1 (kind=42)
 `
@@ -1077,7 +1096,8 @@ const x []int
 }
 `
 		parser := New(lex.FetchTokensFromString(data))
-		pr := parser.ParseFile()
+		pr, err := parser.ParseFile(context.Background())
+		assert.Nil(err)
 		assert.NotNil(pr)
 		assert.Greater(len(parser.errors), 0)
 	})
@@ -1091,7 +1111,8 @@ var x []int
 }
 `
 		parser := New(lex.FetchTokensFromString(data))
-		pr := parser.ParseFile()
+		pr, err := parser.ParseFile(context.Background())
+		assert.Nil(err)
 		assert.NotNil(pr)
 		assert.Greater(len(parser.errors), 0)
 	})
@@ -1105,11 +1126,41 @@ const x []struct=[]int{1,2,3}
 }
 `
 		parser := New(lex.FetchTokensFromString(data))
-		pr := parser.ParseFile()
+		pr, err := parser.ParseFile(context.Background())
+		assert.Nil(err)
 		assert.NotNil(pr)
 		assert.Greater(len(parser.errors), 0)
 	})
 
+	t.Run("bad_slice_recovery", func(t *testing.T) {
+		lex, err := lexer.NewLexer(lexer.Config{StringOnly: true})
+		assert.Nil(err)
+		data := `package main
+
+func main() {
+  var a []int = []int{1 2
+  var b int = 3
+  var c []int = []int{1, 2 3}; var d int = 4
+}
+`
+		parser := New(lex.FetchTokensFromString(data))
+		pr, err := parser.ParseFile(context.Background())
+		assert.Nil(err)
+		if assert.Equal(2, len(parser.errors)) {
+			assert.Equal(`4:25: expected ',' or '}' after slice element, got 4 "2"`, parser.errors[0].Error())
+			assert.Equal(`6:28: expected ',' or '}' after slice element, got 4 "3"`, parser.errors[1].Error())
+		}
+
+		body := pr.Decls[0].(*ast.FuncDecl).Body.Stmts
+		if assert.Equal(4, len(body)) {
+			assert.Equal("b", body[1].(*ast.DeclStmt).Decl.(*ast.VarDecl).Names[0].Value)
+			c := body[2].(*ast.DeclStmt).Decl.(*ast.VarDecl).Values[0].(*ast.SliceLitExpr)
+			assert.Equal(2, len(c.Elements))
+			assert.Equal("}", c.RBrace.Value)
+			assert.Equal("d", body[3].(*ast.DeclStmt).Decl.(*ast.VarDecl).Names[0].Value)
+		}
+	})
+
 	t.Run("bad_array_x1", func(t *testing.T) {
 		lex, err := lexer.NewLexer(lexer.Config{StringOnly: true})
 		assert.Nil(err)
@@ -1119,7 +1170,8 @@ const x [5]int
 }
 `
 		parser := New(lex.FetchTokensFromString(data))
-		pr := parser.ParseFile()
+		pr, err := parser.ParseFile(context.Background())
+		assert.Nil(err)
 		assert.NotNil(pr)
 		assert.Greater(len(parser.errors), 0)
 	})
@@ -1133,8 +1185,107 @@ const x [5]struct=[]int{1,2,3}
 }
 `
 		parser := New(lex.FetchTokensFromString(data))
-		pr := parser.ParseFile()
+		pr, err := parser.ParseFile(context.Background())
+		assert.Nil(err)
 		assert.NotNil(pr)
 		assert.Greater(len(parser.errors), 0)
 	})
+
+	t.Run("slice_expr_3_index", func(t *testing.T) {
+		lex, err := lexer.NewLexer(lexer.Config{StringOnly: true})
+		assert.Nil(err)
+		data := `package main
+
+func main(){
+  y := x[1:2:3]
+  z := x[:]
+}
+`
+		parser := New(lex.FetchTokensFromString(data))
+		pr, err := parser.ParseFile(context.Background())
+		assert.Nil(err)
+		result := `File
+ Package: "package" @1:1 (kind=8)
+ Name: "main" @1:9 (kind=3)
+ Decls
+  FuncDecl
+   Function: "func" @3:1 (kind=10)
+   Name: "main" @3:6 (kind=3)
+   Params
+    (none)
+   Body
+    BlockStmt
+     LBrace: "{" @3:12 (kind=41)
+     Stmts
+      AssignStmt
+       Left
+        IdentExpr
+         Name: "y" @4:3 (kind=3)
+       Operator: ":=" @4:5 (kind=50)
+       Right
+        SliceExpr
+         IdentExpr
+          Name: "x" @4:8 (kind=3)
+         LBracket: "[" @4:9 (kind=43)
+         IntLitExpr
+          Value: "1" @4:10 (kind=4)
+          Colon: ":" @4:11 (kind=47)
+         IntLitExpr
+          Value: "2" @4:12 (kind=4)
+          Colon: ":" @4:13 (kind=47)
+         IntLitExpr
+          Value: "3" @4:14 (kind=4)
+         RBracket: "]" @4:15 (kind=44)
+      AssignStmt
+       Left
+        IdentExpr
+         Name: "z" @5:3 (kind=3)
+       Operator: ":=" @5:5 (kind=50)
+       Right
+        SliceExpr
+         IdentExpr
+          Name: "x" @5:8 (kind=3)
+         LBracket: "[" @5:9 (kind=43)
+          Colon: ":" @5:10 (kind=47)
+         RBracket: "]" @5:11 (kind=44)
+     RBrace: "}" @6:1 (kind=42)
+`
+		assert.Equal(result, ast.Dump(pr))
+		assert.Equal(0, len(parser.errors))
+	})
+
+	t.Run("slice_expr_errors", func(t *testing.T) {
+		tests := []struct {
+			input    string
+			expected string
+		}{
+			{
+				input:    "package main\n\nfunc main(){\n  y := x[]\n}\n",
+				expected: `4:10: expected index or slice bounds, got 44 "]"`,
+			},
+			{
+				input:    "package main\n\nfunc main(){\n  y := x[::3]\n}\n",
+				expected: `4:11: middle index required in 3-index slice, got 47 ":"`,
+			},
+			{
+				input:    "package main\n\nfunc main(){\n  y := x[1:2:]\n}\n",
+				expected: `4:14: final index required in 3-index slice, got 44 "]"`,
+			},
+			{
+				input:    "package main\n\nfunc main(){\n  y := x[1:2:3:4]\n}\n",
+				expected: `4:15: expected ']' after 3-index slice, got 47 ":"`,
+			},
+		}
+
+		for _, tc := range tests {
+			lex, err := lexer.NewLexer(lexer.Config{StringOnly: true})
+			assert.Nil(err)
+			parser := New(lex.FetchTokensFromString(tc.input))
+			_, err = parser.ParseFile(context.Background())
+			assert.Nil(err)
+			if assert.Greater(len(parser.errors), 0, tc.input) {
+				assert.Equal(tc.expected, parser.errors[0].Error(), tc.input)
+			}
+		}
+	})
 }
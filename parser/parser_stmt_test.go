@@ -1,6 +1,7 @@
 package parser
 
 import (
+	"context"
 	"testing"
 
 	"github.com/orilang/gori/ast"
@@ -21,7 +22,8 @@ func x(){
 }
 `
 		parser := New(lex.FetchTokensFromString(data))
-		pr := parser.ParseFile()
+		pr, err := parser.ParseFile(context.Background())
+		assert.Nil(err)
 		result := `File
  Package: "package" @1:1 (kind=8)
  Name: "main" @1:9 (kind=3)
@@ -59,7 +61,8 @@ func x(a z){
 }
 `
 		parser := New(lex.FetchTokensFromString(data))
-		pr := parser.ParseFile()
+		pr, err := parser.ParseFile(context.Background())
+		assert.Nil(err)
 		result := `File
  Package: "package" @1:1 (kind=8)
  Name: "main" @1:9 (kind=3)
@@ -105,7 +108,8 @@ func x(a z){
 }
 `
 		parser := New(lex.FetchTokensFromString(data))
-		pr := parser.ParseFile()
+		pr, err := parser.ParseFile(context.Background())
+		assert.Nil(err)
 		result := `File
  Package: "package" @1:1 (kind=8)
  Name: "main" @1:9 (kind=3)
@@ -153,7 +157,8 @@ func x(){
 }
 `
 		parser := New(lex.FetchTokensFromString(data))
-		pr := parser.ParseFile()
+		pr, err := parser.ParseFile(context.Background())
+		assert.Nil(err)
 		result := `File
  Package: "package" @1:1 (kind=8)
  Name: "main" @1:9 (kind=3)
@@ -195,7 +200,8 @@ func x(){
 }
 `
 		parser := New(lex.FetchTokensFromString(data))
-		pr := parser.ParseFile()
+		pr, err := parser.ParseFile(context.Background())
+		assert.Nil(err)
 		result := `File
  Package: "package" @1:1 (kind=8)
  Name: "main" @1:9 (kind=3)
@@ -231,7 +237,8 @@ func x(a []int){
 }
 `
 		parser := New(lex.FetchTokensFromString(data))
-		pr := parser.ParseFile()
+		pr, err := parser.ParseFile(context.Background())
+		assert.Nil(err)
 		result := `File
  Package: "package" @1:1 (kind=8)
  Name: "main" @1:9 (kind=3)
@@ -274,7 +281,8 @@ func x(a [5]int){
 }
 `
 		parser := New(lex.FetchTokensFromString(data))
-		pr := parser.ParseFile()
+		pr, err := parser.ParseFile(context.Background())
+		assert.Nil(err)
 		result := `File
  Package: "package" @1:1 (kind=8)
  Name: "main" @1:9 (kind=3)
@@ -319,7 +327,8 @@ func x(_ z){
 }
 `
 		parser := New(lex.FetchTokensFromString(data))
-		pr := parser.ParseFile()
+		pr, err := parser.ParseFile(context.Background())
+		assert.Nil(err)
 		result := `File
  Package: "package" @1:1 (kind=8)
  Name: "main" @1:9 (kind=3)
@@ -367,7 +376,8 @@ func x(){
 }
 `
 		parser := New(lex.FetchTokensFromString(data))
-		pr := parser.ParseFile()
+		pr, err := parser.ParseFile(context.Background())
+		assert.Nil(err)
 		assert.NotNil(pr)
 		assert.Greater(len(parser.errors), 0)
 	})
@@ -382,8 +392,263 @@ func x(){
 }
 `
 		parser := New(lex.FetchTokensFromString(data))
-		pr := parser.ParseFile()
+		pr, err := parser.ParseFile(context.Background())
+		assert.Nil(err)
 		assert.NotNil(pr)
 		assert.Greater(len(parser.errors), 0)
 	})
+
+	t.Run("multiple_assign_swap", func(t *testing.T) {
+		lex, err := lexer.NewLexer(lexer.Config{StringOnly: true})
+		assert.Nil(err)
+		data := `package main
+
+func main() {
+  a, b = b, a
+}
+`
+		result := `File
+ Package: "package" @1:1 (kind=8)
+ Name: "main" @1:9 (kind=3)
+ Decls
+  FuncDecl
+   Function: "func" @3:1 (kind=10)
+   Name: "main" @3:6 (kind=3)
+   Params
+    (none)
+   Body
+    BlockStmt
+     LBrace: "{" @3:13 (kind=41)
+     Stmts
+      AssignStmt
+       Left
+        IdentExpr
+         Name: "a" @4:3 (kind=3)
+        IdentExpr
+         Name: "b" @4:6 (kind=3)
+       Operator: "=" @4:8 (kind=49)
+       Right
+        IdentExpr
+         Name: "b" @4:10 (kind=3)
+        IdentExpr
+         Name: "a" @4:13 (kind=3)
+     RBrace: "}" @5:1 (kind=42)
+`
+		parser := New(lex.FetchTokensFromString(data))
+		pr, err := parser.ParseFile(context.Background())
+		assert.Nil(err)
+		assert.Equal(result, ast.Dump(pr))
+		assert.Equal(0, len(parser.errors))
+	})
+
+	t.Run("multiple_assign_define_call", func(t *testing.T) {
+		lex, err := lexer.NewLexer(lexer.Config{StringOnly: true})
+		assert.Nil(err)
+		data := `package main
+
+func main() {
+  q, r := divmod(x, y)
+}
+`
+		result := `File
+ Package: "package" @1:1 (kind=8)
+ Name: "main" @1:9 (kind=3)
+ Decls
+  FuncDecl
+   Function: "func" @3:1 (kind=10)
+   Name: "main" @3:6 (kind=3)
+   Params
+    (none)
+   Body
+    BlockStmt
+     LBrace: "{" @3:13 (kind=41)
+     Stmts
+      AssignStmt
+       Left
+        IdentExpr
+         Name: "q" @4:3 (kind=3)
+        IdentExpr
+         Name: "r" @4:6 (kind=3)
+       Operator: ":=" @4:8 (kind=50)
+       Right
+        CallExpr
+         Callee
+          IdentExpr
+           Name: "divmod" @4:11 (kind=3)
+         LParent: "(" @4:17 (kind=39)
+         Args:
+          IdentExpr
+           Name: "x" @4:18 (kind=3)
+          IdentExpr
+           Name: "y" @4:21 (kind=3)
+         RParent: ")" @4:22 (kind=40)
+     RBrace: "}" @5:1 (kind=42)
+`
+		parser := New(lex.FetchTokensFromString(data))
+		pr, err := parser.ParseFile(context.Background())
+		assert.Nil(err)
+		assert.Equal(result, ast.Dump(pr))
+		assert.Equal(0, len(parser.errors))
+	})
+
+	t.Run("multiple_var_decl", func(t *testing.T) {
+		lex, err := lexer.NewLexer(lexer.Config{StringOnly: true})
+		assert.Nil(err)
+		data := `package main
+
+func main() {
+  var a, b int = 1, 2
+}
+`
+		result := `File
+ Package: "package" @1:1 (kind=8)
+ Name: "main" @1:9 (kind=3)
+ Decls
+  FuncDecl
+   Function: "func" @3:1 (kind=10)
+   Name: "main" @3:6 (kind=3)
+   Params
+    (none)
+   Body
+    BlockStmt
+     LBrace: "{" @3:13 (kind=41)
+     Stmts
+      VarDecl
+       Var: "var" @4:3 (kind=11)
+       Name: "a" @4:7 (kind=3)
+       Name: "b" @4:10 (kind=3)
+       Type
+        NamedType
+         Ident: "int" @4:12 (kind=12)
+       Eq: "=" @4:16 (kind=49)
+       Init
+        IntLitExpr
+         Value: "1" @4:18 (kind=4)
+        IntLitExpr
+         Value: "2" @4:21 (kind=4)
+     RBrace: "}" @5:1 (kind=42)
+`
+		parser := New(lex.FetchTokensFromString(data))
+		pr, err := parser.ParseFile(context.Background())
+		assert.Nil(err)
+		assert.Equal(result, ast.Dump(pr))
+		assert.Equal(0, len(parser.errors))
+	})
+
+	t.Run("multiple_assign_for_init_post", func(t *testing.T) {
+		lex, err := lexer.NewLexer(lexer.Config{StringOnly: true})
+		assert.Nil(err)
+		data := `package main
+
+func main() {
+  for i, j := 0, 10; i < j; i, j = i+1, j-1 {}
+}
+`
+		result := `File
+ Package: "package" @1:1 (kind=8)
+ Name: "main" @1:9 (kind=3)
+ Decls
+  FuncDecl
+   Function: "func" @3:1 (kind=10)
+   Name: "main" @3:6 (kind=3)
+   Params
+    (none)
+   Body
+    BlockStmt
+     LBrace: "{" @3:13 (kind=41)
+     Stmts
+      ForStmt
+       For: "for" @4:3 (kind=31)
+       Init
+        AssignStmt
+         Left
+          IdentExpr
+           Name: "i" @4:7 (kind=3)
+          IdentExpr
+           Name: "j" @4:10 (kind=3)
+         Operator: ":=" @4:12 (kind=50)
+         Right
+          IntLitExpr
+           Value: "0" @4:15 (kind=4)
+          IntLitExpr
+           Value: "10" @4:18 (kind=4)
+       Condition
+        BinaryExpr
+         IdentExpr
+          Name: "i" @4:22 (kind=3)
+         Operator: "<" @4:24 (kind=64)
+         IdentExpr
+          Name: "j" @4:26 (kind=3)
+       Post
+        AssignStmt
+         Left
+          IdentExpr
+           Name: "i" @4:29 (kind=3)
+          IdentExpr
+           Name: "j" @4:32 (kind=3)
+         Operator: "=" @4:34 (kind=49)
+         Right
+          BinaryExpr
+           IdentExpr
+            Name: "i" @4:36 (kind=3)
+           Operator: "+" @4:37 (kind=51)
+           IntLitExpr
+            Value: "1" @4:38 (kind=4)
+          BinaryExpr
+           IdentExpr
+            Name: "j" @4:41 (kind=3)
+           Operator: "-" @4:42 (kind=54)
+           IntLitExpr
+            Value: "1" @4:43 (kind=4)
+     RBrace: "}" @5:1 (kind=42)
+`
+		parser := New(lex.FetchTokensFromString(data))
+		pr, err := parser.ParseFile(context.Background())
+		assert.Nil(err)
+		assert.Equal(result, ast.Dump(pr))
+		assert.Equal(0, len(parser.errors))
+	})
+
+	t.Run("multiple_assign_errors", func(t *testing.T) {
+		tests := []struct {
+			input    string
+			expected string
+		}{
+			{
+				input:    "package main\n\nfunc main() {\n  a, b = 1, 2, 3\n}\n",
+				expected: `4:8: assignment mismatch: 2 variable(s) but 3 value(s), got 49 "="`,
+			},
+			{
+				input:    "package main\n\nfunc main() {\n  a, b += 1, 2\n}\n",
+				expected: `4:8: expected single value with assignment operator, got 52 "+="`,
+			},
+			{
+				input:    "package main\n\nfunc main() {\n  a, b.c := 1, 2\n}\n",
+				expected: `4:6: expected identifier on left side of ':=', got 3 "b"`,
+			},
+			{
+				input:    "package main\n\nfunc main() {\n  a, 1 = 1, 2\n}\n",
+				expected: `4:6: expected assignable expression, got 4 "1"`,
+			},
+			{
+				input:    "package main\n\nfunc main() {\n  a, b\n}\n",
+				expected: `5:1: expected '=' or ':=' after expression list, got 42 "}"`,
+			},
+			{
+				input:    "package main\n\nfunc main() {\n  var a, b int = 1\n}\n",
+				expected: `4:16: assignment mismatch: 2 variable(s) but 1 value(s), got 49 "="`,
+			},
+		}
+
+		for _, tc := range tests {
+			lex, err := lexer.NewLexer(lexer.Config{StringOnly: true})
+			assert.Nil(err)
+			parser := New(lex.FetchTokensFromString(tc.input))
+			_, err = parser.ParseFile(context.Background())
+			assert.Nil(err)
+			if assert.Greater(len(parser.errors), 0, tc.input) {
+				assert.Equal(tc.expected, parser.errors[0].Error(), tc.input)
+			}
+		}
+	})
 }
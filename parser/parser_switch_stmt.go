@@ -138,10 +138,6 @@ func (p *Parser) parseSwitchCasesStmt(s ast.SwitchStmt) ast.Stmt {
 // parseFallThroughStmt returns expressions for parseStmt func
 func (p *Parser) parseFallThroughStmt() ast.Stmt {
 	kw := p.expect(token.KWFallThrough, "expected 'fallthrough'")
-	if p.kind() == token.Comment {
-		_ = p.next()
-	}
-
 	// any unauthorized statement is rejected after 'fallthrough'
 	if p.kind() == token.RBrace || p.kind() == token.EOF || p.kind() == token.KWCase || p.kind() == token.KWDefault {
 		return &ast.FallThroughStmt{
@@ -0,0 +1,112 @@
+package parser
+
+import (
+	"fmt"
+
+	"github.com/orilang/gori/ast"
+	"github.com/orilang/gori/token"
+)
+
+// parseType returns any type like int, pkg.Type, Box[int], []T, [4]T,
+// map[K]V, hashmap[K]V, func(int) string or struct { a int }
+func (p *Parser) parseType() ast.Type {
+	switch {
+	case p.kind() == token.LBracket:
+		lb := p.next()
+		if p.kind() == token.RBracket {
+			return &ast.SliceType{LBracket: lb, RBracket: p.next(), Elem: p.parseType()}
+		}
+
+		size := p.parseExpr(LOWEST)
+		rb := p.expect(token.RBracket, "expected ']'")
+		return &ast.ArrayType{LBracket: lb, Len: size, RBracket: rb, Elem: p.parseType()}
+
+	case token.IsMapType(p.kind()):
+		x := &ast.MapType{KindKW: p.next()}
+		x.LBracket = p.expect(token.LBracket, "expected '['")
+		x.KeyType = p.parseType()
+		x.RBracket = p.expect(token.RBracket, "expected ']'")
+		x.ValueType = p.parseType()
+		return x
+
+	case p.kind() == token.KWFunc:
+		return p.parseFuncType()
+
+	case p.kind() == token.KWStruct:
+		return p.parseStructType()
+
+	case token.IsTypeName(p.kind()):
+		return p.parseNamedType()
+	}
+
+	tok := p.next()
+	p.errors = append(p.errors, fmt.Errorf("%d:%d: unsupported type with %v %q", tok.Line, tok.Column, tok.Kind, tok.Value))
+	return &ast.BadType{From: tok, Reason: "unexpected type name"}
+}
+
+// parseNamedType returns types like Type, pkg.Type or Box[int].
+// The blank identifier is not a type
+func (p *Parser) parseNamedType() *ast.NamedType {
+	nt := &ast.NamedType{}
+	if p.kind() == token.Ident {
+		nt.Parts = append(nt.Parts, p.expectValidIdent(token.Ident, true, "expected type name"))
+	} else {
+		nt.Parts = append(nt.Parts, p.next())
+	}
+	for nt.Parts[0].Kind == token.Ident && p.kind() == token.Dot {
+		nt.Parts = append(nt.Parts, p.next())
+		nt.Parts = append(nt.Parts, p.expectValidIdent(token.Ident, true, "expected ident after '.'"))
+	}
+	p.parseTypeArgs(nt)
+	return nt
+}
+
+// parseFuncType returns a function type like func(a int) (string, bool).
+// Results are only read when they start on the line of ')'
+func (p *Parser) parseFuncType() *ast.FuncType {
+	ft := &ast.FuncType{Func: p.expect(token.KWFunc, "expected 'func'")}
+	ft.LParen, ft.Params, ft.RParen = p.parseFuncTypeParams()
+	p.checkVariadic(ft.Params, true)
+
+	switch {
+	case p.peek().Line != ft.RParen.Line:
+	case p.kind() == token.LParen:
+		ft.Results.LParen, ft.Results.List, ft.Results.RParen = p.parseFuncTypeParams()
+	case token.IsTypeStart(p.kind()) || token.IsQualifier(p.kind()):
+		qualifier := p.parseQualifier()
+		ft.Results.List = append(ft.Results.List, ast.Param{Qualifier: qualifier, Type: p.parseType()})
+	}
+	p.checkVariadic(ft.Results.List, false)
+	return ft
+}
+
+// parseFuncTypeParams returns the parameters of a function type
+// within parentheses like (int, string) or (a int, b string)
+func (p *Parser) parseFuncTypeParams() (token.Token, []ast.Param, token.Token) {
+	lp := p.expect(token.LParen, "expected '('")
+	named := p.isNamedParam(p.position)
+
+	var params []ast.Param
+	for p.kind() != token.RParen && p.kind() != token.EOF {
+		var param ast.Param
+		if named {
+			param.Name = p.expectValidIdent(token.Ident, false, "expected parameter identifier")
+		}
+		param.Qualifier = p.parseQualifier()
+		param.Ellipsis = p.parseEllipsis()
+		param.Type = p.parseType()
+		params = append(params, param)
+
+		if p.kind() == token.Comma {
+			_ = p.next()
+			continue
+		}
+		if p.kind() != token.RParen {
+			tok := p.peek()
+			p.errors = append(p.errors, fmt.Errorf("%d:%d: expected ',' or ')' after parameter, got %v %q", tok.Line, tok.Column, tok.Kind, tok.Value))
+			p.consumeTo(token.RParen)
+		}
+	}
+	rp := p.expect(token.RParen, "expected ')'")
+	return lp, params, rp
+}
@@ -1,6 +1,8 @@
 package parser
 
 import (
+	"fmt"
+
 	"github.com/orilang/gori/ast"
 	"github.com/orilang/gori/token"
 )
@@ -15,9 +17,13 @@ func (p *Parser) parseDefinedDecl() ast.Decl {
 		Name:     kwi,
 	}
 
-	x := &ast.NamedType{}
-	x.Parts = append(x.Parts, p.next())
-	dt.Type = x
+	if p.kind() == token.LBracket && p.isTypeParams(p.position) {
+		tok := p.peek()
+		p.errors = append(p.errors, fmt.Errorf("%d:%d: type parameters are only allowed on struct, sum and interface types, got %v %q", tok.Line, tok.Column, tok.Kind, tok.Value))
+		_ = p.parseTypeParams()
+	}
+
+	dt.Type = p.parseType()
 
 	if p.kind() == token.SemiComma {
 		_ = p.expect(token.SemiComma, "expected ';'")
@@ -33,10 +33,6 @@ func (p *Parser) parseEnumDecl() ast.Decl {
 			ed.Variants = append(ed.Variants, p.next())
 		}
 
-		if p.kind() == token.Comment {
-			_ = p.next()
-		}
-
 		if p.kind() == token.SemiComma {
 			_ = p.next()
 			continue
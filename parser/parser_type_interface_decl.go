@@ -11,28 +11,33 @@ import (
 func (p *Parser) parseInterfaceDecl() ast.Decl {
 	kwt := p.expect(token.KWType, "expected 'type'")
 	kwi := p.expectValidIdent(token.Ident, true, "expected 'ident'")
+	var params []ast.TypeParam
+	if p.kind() == token.LBracket {
+		params = p.parseTypeParams()
+	}
 	kws := p.expect(token.KWInterface, "expected 'interface'")
 	lbrace := p.expect(token.LBrace, "expected '{'")
 
 	it := &ast.InterfaceDecl{
-		TypeDecl:  kwt,
-		Name:      kwi,
-		Public:    isPublic(kwi),
-		Interface: kws,
-		LBrace:    lbrace,
+		TypeDecl:   kwt,
+		Name:       kwi,
+		TypeParams: params,
+		Public:     isPublic(kwi),
+		Interface:  kws,
+		LBrace:     lbrace,
 	}
 
 	for p.kind() != token.RBrace && p.kind() != token.EOF {
-		if p.kind() == token.Ident {
-			if p.kindNext(p.position+1) == token.LParen {
-				it.Methods = append(it.Methods, p.parseFuncSignature())
-			} else {
-				it.Embeds = append(it.Embeds, p.parseInterfaceTypeEmbbed())
-			}
-		}
-
-		if p.kind() == token.Comment {
-			_ = p.next()
+		if p.kind() == token.Ident && p.kindNext(p.position+1) == token.LParen {
+			it.Methods = append(it.Methods, p.parseFuncSignature())
+		} else if p.kind() == token.Ident && p.kindNext(p.position+1) != token.Pipe && p.kindNext(p.position+1) != token.LBracket {
+			it.Embeds = append(it.Embeds, p.parseInterfaceTypeEmbbed())
+		} else if token.IsTypeStart(p.kind()) {
+			// constraint interfaces hold type sets like int | float64
+			it.Embeds = append(it.Embeds, p.parseConstraint())
+		} else {
+			tok := p.next()
+			p.errors = append(p.errors, fmt.Errorf("%d:%d: expected method or embedded type, got %v %q", tok.Line, tok.Column, tok.Kind, tok.Value))
 		}
 
 		if p.kind() == token.SemiComma {
@@ -93,7 +98,9 @@ func (p *Parser) parseFuncSignature() ast.InterfaceMethod {
 	}
 
 	_ = p.expect(token.RParen, "expected ')' after function name")
+	p.checkVariadic(f.Params, true)
 	f.Results = p.parseFuncSignatureReturnTypes()
+	p.checkVariadic(f.Results.List, false)
 
 	return f
 }
@@ -101,44 +108,36 @@ func (p *Parser) parseFuncSignature() ast.InterfaceMethod {
 // parseFuncSignatureParam returns function parameter
 func (p *Parser) parseFuncSignatureParam() ast.Param {
 	name := p.expectValidIdent(token.Ident, true, "expected parameter identifier")
-	return ast.Param{Name: name, Type: p.parseFuncSignatureParamType()}
+	qualifier := p.parseQualifier()
+	return ast.Param{Name: name, Qualifier: qualifier, Ellipsis: p.parseEllipsis(), Type: p.parseType()}
 }
 
-// parseFuncSignatureParamType returns func parameter type
-func (p *Parser) parseFuncSignatureParamType() ast.Type {
-	typ := &ast.NamedType{}
-	tok := p.next()
-
-	if token.IsFuncParamTypes(tok.Kind) {
-		typ.Parts = append(typ.Parts, tok)
-	} else {
-		p.errors = append(p.errors, fmt.Errorf("%d:%d: unsupported type with %v %q", tok.Line, tok.Column, tok.Kind, tok.Value))
-		p.consumeTo(token.RParen)
-	}
-	return typ
-}
-
-// parseFuncSignatureReturnTypes returns func return types
+// parseFuncSignatureReturnTypes returns func return types.
+// Methods without results end with a new line, ';' or '}'
 func (p *Parser) parseFuncSignatureReturnTypes() ast.ReturnTypes {
 	var result ast.ReturnTypes
+	if p.kind() == token.SemiComma || p.kind() == token.RBrace || p.newlineSincePrev() {
+		return result
+	}
 	if p.kind() == token.LParen {
 		lp := p.expect(token.LParen, "expected '('")
 		if p.kind() == token.RParen {
 			p.errors = append(p.errors, fmt.Errorf("%d:%d: expected parameter(s) before ')', got %v %q", p.peek().Line, p.peek().Column, p.peek().Kind, p.peek().Value))
-			result.List = append(result.List, ast.Param{Type: p.parseFuncSignatureParamType()})
+			result.List = append(result.List, ast.Param{Type: p.parseType()})
 			return result
 		}
 
 		if p.kind() == token.Comma {
 			p.errors = append(p.errors, fmt.Errorf("%d:%d: expected expression before ',', got %v %q", p.peek().Line, p.peek().Column, p.peek().Kind, p.peek().Value))
 
-			result.List = append(result.List, ast.Param{Type: p.parseFuncSignatureParamType()})
+			result.List = append(result.List, ast.Param{Type: p.parseType()})
 			return result
 		}
 
 		result.LParen = lp
 		// entering into kind: (indentA indentB, indentC indentD) or (indentA indentB)
-		if p.kindNext(p.position+2) == token.Comma || p.kindNext(p.position+2) == token.RParen {
+		next := p.kindNext(p.position + 2 + p.qualifierOffset(p.position+1))
+		if !token.IsQualifier(p.kind()) && (next == token.Comma || next == token.RParen) {
 			for p.kind() != token.RParen && p.kind() != token.LBrace && p.kind() != token.EOF {
 				result.List = append(result.List, p.parseFuncSignatureParam())
 
@@ -158,7 +157,8 @@ func (p *Parser) parseFuncSignatureReturnTypes() ast.ReturnTypes {
 		} else {
 			// entering into kind: (type, type)
 			for p.kind() != token.RParen && p.kind() != token.LBrace && p.kind() != token.EOF {
-				result.List = append(result.List, ast.Param{Type: p.parseFuncSignatureParamType()})
+				qualifier := p.parseQualifier()
+				result.List = append(result.List, ast.Param{Qualifier: qualifier, Type: p.parseType()})
 
 				if p.kind() != token.Comma && p.kind() != token.RParen {
 					p.errors = append(p.errors, fmt.Errorf("%d:%d: expected ',' after parameter(s), got %v %q", p.peek().Line, p.peek().Column, p.peek().Kind, p.peek().Value))
@@ -185,7 +185,8 @@ func (p *Parser) parseFuncSignatureReturnTypes() ast.ReturnTypes {
 		return result
 	}
 
-	result.List = append(result.List, ast.Param{Type: p.parseFuncSignatureParamType()})
+	qualifier := p.parseQualifier()
+	result.List = append(result.List, ast.Param{Qualifier: qualifier, Type: p.parseType()})
 	return result
 }
 
@@ -0,0 +1,233 @@
+package parser
+
+import (
+	"fmt"
+
+	"github.com/orilang/gori/ast"
+	"github.com/orilang/gori/token"
+)
+
+// parseTypeParams returns type parameters like [K comparable, V any].
+// Consecutive names share the following constraint like [K, V any]
+func (p *Parser) parseTypeParams() []ast.TypeParam {
+	lb := p.expect(token.LBracket, "expected '['")
+	if p.kind() == token.RBracket {
+		rb := p.next()
+		p.errors = append(p.errors, fmt.Errorf("%d:%d: expected type parameter(s) inside brackets, got %v %q", rb.Line, rb.Column, rb.Kind, rb.Value))
+		return []ast.TypeParam{{Name: rb, Constraint: &ast.BadType{From: lb, To: rb, Reason: "expected type parameter(s)"}}}
+	}
+
+	var (
+		params  []ast.TypeParam
+		pending int
+	)
+	for p.kind() != token.RBracket && p.kind() != token.EOF {
+		name := p.expectValidIdent(token.Ident, true, "expected type parameter name")
+		params = append(params, ast.TypeParam{Name: name})
+		pending++
+
+		if p.kind() != token.Comma && p.kind() != token.RBracket {
+			constraint := p.parseConstraint()
+			for i := len(params) - pending; i < len(params); i++ {
+				params[i].Constraint = constraint
+			}
+			pending = 0
+		}
+
+		if p.kind() == token.Comma {
+			_ = p.next()
+			continue
+		}
+		if p.kind() != token.RBracket {
+			tok := p.peek()
+			p.errors = append(p.errors, fmt.Errorf("%d:%d: expected ',' or ']' after type parameter, got %v %q", tok.Line, tok.Column, tok.Kind, tok.Value))
+			p.consumeTo(token.RBracket)
+		}
+	}
+
+	rb := p.expect(token.RBracket, "expected ']'")
+	if pending > 0 {
+		p.errors = append(p.errors, fmt.Errorf("%d:%d: expected constraint for type parameter, got %v %q", rb.Line, rb.Column, rb.Kind, rb.Value))
+		for i := len(params) - pending; i < len(params); i++ {
+			params[i].Constraint = &ast.BadType{From: params[i].Name, To: rb, Reason: "missing constraint"}
+		}
+	}
+	return params
+}
+
+// parseConstraint returns a type parameter constraint which is
+// either a type like any or Number or a union like int | float64
+func (p *Parser) parseConstraint() ast.Type {
+	typ := p.parseType()
+	if p.kind() != token.Pipe {
+		return typ
+	}
+
+	union := &ast.UnionType{Terms: []ast.Type{typ}}
+	for p.kind() == token.Pipe {
+		_ = p.next()
+		union.Terms = append(union.Terms, p.parseType())
+	}
+	return union
+}
+
+// parseTypeArgs sets the type arguments of the named type
+// when it is instantiated like Box[int]
+func (p *Parser) parseTypeArgs(nt *ast.NamedType) {
+	if p.kind() != token.LBracket || len(nt.Parts) == 0 || nt.Parts[0].Kind != token.Ident {
+		return
+	}
+	nt.TypeArgs = p.parseTypeArgList()
+}
+
+// parseTypeArgList returns type arguments like [int, string]
+func (p *Parser) parseTypeArgList() []ast.Type {
+	lb := p.expect(token.LBracket, "expected '['")
+	if p.kind() == token.RBracket {
+		rb := p.next()
+		p.errors = append(p.errors, fmt.Errorf("%d:%d: expected type argument(s) inside brackets, got %v %q", rb.Line, rb.Column, rb.Kind, rb.Value))
+		return []ast.Type{&ast.BadType{From: lb, To: rb, Reason: "expected type argument(s)"}}
+	}
+
+	var args []ast.Type
+	for p.kind() != token.RBracket && p.kind() != token.EOF {
+		args = append(args, p.parseType())
+		if p.kind() == token.Comma {
+			_ = p.next()
+			continue
+		}
+		if p.kind() != token.RBracket {
+			tok := p.peek()
+			p.errors = append(p.errors, fmt.Errorf("%d:%d: expected ',' or ']' after type argument, got %v %q", tok.Line, tok.Column, tok.Kind, tok.Value))
+			p.consumeTo(token.RBracket)
+		}
+	}
+	_ = p.expect(token.RBracket, "expected ']'")
+	return args
+}
+
+// closingBracket returns the position of the ']' matching
+// the '[' located at pos or -1 if not found
+func (p *Parser) closingBracket(pos int) int {
+	depth := 0
+	for ; p.kindNext(pos) != token.EOF; pos++ {
+		switch p.kindNext(pos) {
+		case token.LBracket:
+			depth++
+		case token.RBracket:
+			depth--
+			if depth == 0 {
+				return pos
+			}
+		case token.LBrace, token.RBrace, token.SemiComma, token.Assign, token.Define:
+			return -1
+		}
+	}
+	return -1
+}
+
+// typeDeclKind returns the kind following the name of
+// the type declaration, skipping its type parameters
+func (p *Parser) typeDeclKind() token.Kind {
+	if p.kindNext(p.position+2) != token.LBracket {
+		return p.kindNext(p.position + 2)
+	}
+	if !p.isTypeParams(p.position + 2) {
+		return token.LBracket
+	}
+
+	end := p.closingBracket(p.position + 2)
+	if end == -1 {
+		return token.Illegal
+	}
+	return p.kindNext(end + 1)
+}
+
+// isTypeParams returns true when the '[' located at pos starts
+// type parameters like [T any] instead of an array type like [N+1]int.
+// The name must be followed by a constraint or another name
+func (p *Parser) isTypeParams(pos int) bool {
+	if p.kindNext(pos+1) != token.Ident {
+		return false
+	}
+	k := p.kindNext(pos + 2)
+	return token.IsTypeName(k) || k == token.Comma
+}
+
+// declareGeneric records the generic function f and
+// its type parameters visible in its body
+func (p *Parser) declareGeneric(f *ast.FuncDecl) {
+	p.typeParams = make(map[string]bool, len(f.TypeParams))
+	for _, tp := range f.TypeParams {
+		p.typeParams[tp.Name.Value] = true
+	}
+
+	if f.Recv != nil {
+		return
+	}
+	if p.generics == nil {
+		p.generics = make(map[string]bool)
+	}
+	p.generics[f.Name.Value] = true
+}
+
+// isTypeArgsCall returns true when the '[' located at pos starts
+// type arguments of a call like f[int](x).
+// The brackets must hold types and either the callee is a generic
+// function declared before or one of them can only be a type
+// like int, []T, map[K]V, pkg.T or a type parameter.
+// Otherwise, like fns[i](x), the callee is an index expression
+func (p *Parser) isTypeArgsCall(callee ast.Expr, pos int) bool {
+	end := p.closingBracket(pos)
+	if end == -1 || p.kindNext(end+1) != token.LParen {
+		return false
+	}
+
+	for i := pos + 1; i < end; i++ {
+		k := p.kindNext(i)
+		if !token.IsTypeStart(k) && k != token.Dot && k != token.Comma && k != token.LBracket && k != token.RBracket && k != token.IntLit {
+			return false
+		}
+		if k == token.IntLit && (i-1 == pos || p.kindNext(i-1) != token.LBracket) {
+			return false
+		}
+	}
+
+	if ident, ok := callee.(*ast.IdentExpr); ok && p.generics[ident.Name.Value] {
+		return true
+	}
+	for i := pos; i < end; i++ {
+		if k := p.kindNext(i); k != token.LBracket && k != token.Comma {
+			continue
+		}
+		if p.onlyType(i + 1) {
+			return true
+		}
+	}
+	return false
+}
+
+// onlyType returns true when the type argument located
+// at pos can not be an expression like int, []T,
+// map[K]V, pkg.T or a type parameter
+func (p *Parser) onlyType(pos int) bool {
+	k := p.kindNext(pos)
+	switch {
+	case k == token.Ident:
+		next := p.kindNext(pos + 1)
+		return next == token.Dot || p.typeParams[p.peekNext(pos).Value] && (next == token.Comma || next == token.RBracket)
+	case k == token.LBracket:
+		return p.kindNext(pos+1) == token.RBracket || p.kindNext(pos+1) == token.IntLit
+	}
+	return token.IsTypeStart(k)
+}
+
+// parseGenericCallExpr returns a call with type arguments like f[int](x)
+func (p *Parser) parseGenericCallExpr(left ast.Expr) ast.Expr {
+	args := p.parseTypeArgList()
+	expr := p.parseCallExpr(left)
+	if call, ok := expr.(*ast.CallExpr); ok {
+		call.TypeArgs = args
+	}
+	return expr
+}
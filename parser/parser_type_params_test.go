@@ -0,0 +1,607 @@
+package parser
+
+import (
+	"context"
+	"testing"
+
+	"github.com/orilang/gori/ast"
+	"github.com/orilang/gori/lexer"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParser_type_params(t *testing.T) {
+	assert := assert.New(t)
+
+	t.Run("generic_struct", func(t *testing.T) {
+		lex, err := lexer.NewLexer(lexer.Config{StringOnly: true})
+		assert.Nil(err)
+		data := `package main
+
+type List[T any] struct {
+  items []T
+  next Box[T]
+}
+`
+		result := `File
+ Package: "package" @1:1 (kind=8)
+ Name: "main" @1:9 (kind=3)
+ Decls
+  StructDecl:
+   Type: "type" @3:1 (kind=26)
+   Name: "List" @3:6 (kind=3)
+   TypeParams
+    TypeParam
+     Ident: "T" @3:11 (kind=3)
+     Constraint
+      NamedType
+       Ident: "any" @3:13 (kind=3)
+   Struct: "struct" @3:18 (kind=27)
+   Public: true
+   LBrace: "{" @3:25 (kind=41)
+    Name: "items" @4:3 (kind=3)
+    Type:
+     SliceType:
+      LBracket: "[" @4:9 (kind=43)
+      RBracket: "]" @4:10 (kind=44)
+      NamedType
+       Ident: "T" @4:11 (kind=3)
+    Name: "next" @5:3 (kind=3)
+    Type:
+     NamedType
+      Ident: "Box" @5:8 (kind=3)
+      TypeArgs
+       NamedType
+        Ident: "T" @5:12 (kind=3)
+   RBrace: "}" @6:1 (kind=42)
+`
+		parser := New(lex.FetchTokensFromString(data))
+		pr, err := parser.ParseFile(context.Background())
+		assert.Nil(err)
+		assert.Equal(result, ast.Dump(pr))
+		assert.Equal(0, len(parser.errors))
+	})
+
+	t.Run("generic_func", func(t *testing.T) {
+		lex, err := lexer.NewLexer(lexer.Config{StringOnly: true})
+		assert.Nil(err)
+		data := `package main
+
+func Map[T, U any](s List[T]) List[U] {
+  return New[U](s)
+}
+`
+		result := `File
+ Package: "package" @1:1 (kind=8)
+ Name: "main" @1:9 (kind=3)
+ Decls
+  FuncDecl
+   Function: "func" @3:1 (kind=10)
+   Name: "Map" @3:6 (kind=3)
+   TypeParams
+    TypeParam
+     Ident: "T" @3:10 (kind=3)
+     Constraint
+      NamedType
+       Ident: "any" @3:15 (kind=3)
+    TypeParam
+     Ident: "U" @3:13 (kind=3)
+     Constraint
+      NamedType
+       Ident: "any" @3:15 (kind=3)
+   Params
+    Param
+     Ident: "s" @3:20 (kind=3)
+     Type
+      NamedType
+       Ident: "List" @3:22 (kind=3)
+       TypeArgs
+        NamedType
+         Ident: "T" @3:27 (kind=3)
+   Results
+     Param
+      Type
+       NamedType
+        Ident: "List" @3:31 (kind=3)
+        TypeArgs
+         NamedType
+          Ident: "U" @3:36 (kind=3)
+   Body
+    BlockStmt
+     LBrace: "{" @3:39 (kind=41)
+     Stmts
+      ReturnStmt
+       Values
+        CallExpr
+         Callee
+          IdentExpr
+           Name: "New" @4:10 (kind=3)
+         TypeArgs:
+          NamedType
+           Ident: "U" @4:14 (kind=3)
+         LParent: "(" @4:16 (kind=39)
+         Args:
+          IdentExpr
+           Name: "s" @4:17 (kind=3)
+         RParent: ")" @4:18 (kind=40)
+     RBrace: "}" @5:1 (kind=42)
+`
+		parser := New(lex.FetchTokensFromString(data))
+		pr, err := parser.ParseFile(context.Background())
+		assert.Nil(err)
+		assert.Equal(result, ast.Dump(pr))
+		assert.Equal(0, len(parser.errors))
+	})
+
+	t.Run("constraint_interface", func(t *testing.T) {
+		lex, err := lexer.NewLexer(lexer.Config{StringOnly: true})
+		assert.Nil(err)
+		data := `package main
+
+type Number interface {
+  int | int64 | float64
+}
+
+func Sum[N Number | string](a N) {}
+`
+		result := `File
+ Package: "package" @1:1 (kind=8)
+ Name: "main" @1:9 (kind=3)
+ Decls
+  InterfaceDecl:
+   Type: "type" @3:1 (kind=26)
+   Name: "Number" @3:6 (kind=3)
+   Interface: "interface" @3:13 (kind=28)
+   Public: true
+   LBrace: "{" @3:23 (kind=41)
+    Embeds
+     UnionType
+      NamedType
+       Ident: "int" @4:3 (kind=12)
+      NamedType
+       Ident: "int64" @4:9 (kind=15)
+      NamedType
+       Ident: "float64" @4:17 (kind=22)
+   RBrace: "}" @5:1 (kind=42)
+  FuncDecl
+   Function: "func" @7:1 (kind=10)
+   Name: "Sum" @7:6 (kind=3)
+   TypeParams
+    TypeParam
+     Ident: "N" @7:10 (kind=3)
+     Constraint
+      UnionType
+       NamedType
+        Ident: "Number" @7:12 (kind=3)
+       NamedType
+        Ident: "string" @7:21 (kind=24)
+   Params
+    Param
+     Ident: "a" @7:29 (kind=3)
+     Type
+      NamedType
+       Ident: "N" @7:31 (kind=3)
+   Body
+`
+		parser := New(lex.FetchTokensFromString(data))
+		pr, err := parser.ParseFile(context.Background())
+		assert.Nil(err)
+		assert.Equal(result, ast.Dump(pr))
+		assert.Equal(0, len(parser.errors))
+	})
+
+	t.Run("generic_sum", func(t *testing.T) {
+		lex, err := lexer.NewLexer(lexer.Config{StringOnly: true})
+		assert.Nil(err)
+		data := `package main
+
+type Option[T any] sum {
+  Some(v T)
+  None
+}
+`
+		result := `File
+ Package: "package" @1:1 (kind=8)
+ Name: "main" @1:9 (kind=3)
+ Decls
+  SumDecl:
+   Type: "type" @3:1 (kind=26)
+    Name: "Option" @3:6 (kind=3)
+    TypeParams
+     TypeParam
+      Ident: "T" @3:13 (kind=3)
+      Constraint
+       NamedType
+        Ident: "any" @3:15 (kind=3)
+    Sum: "sum" @3:20 (kind=75)
+   Public: true
+   LBrace: "{" @3:24 (kind=41)
+    Variants
+     SumVariant: "Some" @4:3 (kind=3)
+      Params
+       Param
+        Ident: "v" @4:8 (kind=3)
+        Type
+         NamedType
+          Ident: "T" @4:10 (kind=3)
+     SumVariant: "None" @5:3 (kind=3)
+   RBrace: "}" @6:1 (kind=42)
+`
+		parser := New(lex.FetchTokensFromString(data))
+		pr, err := parser.ParseFile(context.Background())
+		assert.Nil(err)
+		assert.Equal(result, ast.Dump(pr))
+		assert.Equal(0, len(parser.errors))
+	})
+
+	t.Run("generic_interface", func(t *testing.T) {
+		lex, err := lexer.NewLexer(lexer.Config{StringOnly: true})
+		assert.Nil(err)
+		data := `package main
+
+type Getter[K comparable, V any] interface {
+  Get(k K) V
+}
+`
+		result := `File
+ Package: "package" @1:1 (kind=8)
+ Name: "main" @1:9 (kind=3)
+ Decls
+  InterfaceDecl:
+   Type: "type" @3:1 (kind=26)
+   Name: "Getter" @3:6 (kind=3)
+   TypeParams
+    TypeParam
+     Ident: "K" @3:13 (kind=3)
+     Constraint
+      NamedType
+       Ident: "comparable" @3:15 (kind=3)
+    TypeParam
+     Ident: "V" @3:27 (kind=3)
+     Constraint
+      NamedType
+       Ident: "any" @3:29 (kind=3)
+   Interface: "interface" @3:34 (kind=28)
+   Public: true
+   LBrace: "{" @3:44 (kind=41)
+   Name: "Get" @4:3 (kind=3)
+   Params
+    Param
+     Ident: "k" @4:7 (kind=3)
+     Type
+      NamedType
+       Ident: "K" @4:9 (kind=3)
+   Results
+     Param
+      Type
+       NamedType
+        Ident: "V" @4:12 (kind=3)
+   RBrace: "}" @5:1 (kind=42)
+`
+		parser := New(lex.FetchTokensFromString(data))
+		pr, err := parser.ParseFile(context.Background())
+		assert.Nil(err)
+		assert.Equal(result, ast.Dump(pr))
+		assert.Equal(0, len(parser.errors))
+	})
+
+	t.Run("generic_call_var", func(t *testing.T) {
+		lex, err := lexer.NewLexer(lexer.Config{StringOnly: true})
+		assert.Nil(err)
+		data := `package main
+
+func main() {
+  var a Pair[string, []int] = Make[string, []int](1)
+}
+`
+		result := `File
+ Package: "package" @1:1 (kind=8)
+ Name: "main" @1:9 (kind=3)
+ Decls
+  FuncDecl
+   Function: "func" @3:1 (kind=10)
+   Name: "main" @3:6 (kind=3)
+   Params
+    (none)
+   Body
+    BlockStmt
+     LBrace: "{" @3:13 (kind=41)
+     Stmts
+      VarDecl
+       Var: "var" @4:3 (kind=11)
+       Name: "a" @4:7 (kind=3)
+       Type
+        NamedType
+         Ident: "Pair" @4:9 (kind=3)
+         TypeArgs
+          NamedType
+           Ident: "string" @4:14 (kind=24)
+          SliceType:
+           LBracket: "[" @4:22 (kind=43)
+           RBracket: "]" @4:23 (kind=44)
+           NamedType
+            Ident: "int" @4:24 (kind=12)
+       Eq: "=" @4:29 (kind=49)
+       Init
+        CallExpr
+         Callee
+          IdentExpr
+           Name: "Make" @4:31 (kind=3)
+         TypeArgs:
+          NamedType
+           Ident: "string" @4:36 (kind=24)
+          SliceType:
+           LBracket: "[" @4:44 (kind=43)
+           RBracket: "]" @4:45 (kind=44)
+           NamedType
+            Ident: "int" @4:46 (kind=12)
+         LParent: "(" @4:50 (kind=39)
+         Args:
+          IntLitExpr
+           Value: "1" @4:51 (kind=4)
+         RParent: ")" @4:52 (kind=40)
+     RBrace: "}" @5:1 (kind=42)
+`
+		parser := New(lex.FetchTokensFromString(data))
+		pr, err := parser.ParseFile(context.Background())
+		assert.Nil(err)
+		assert.Equal(result, ast.Dump(pr))
+		assert.Equal(0, len(parser.errors))
+	})
+
+	t.Run("index_call", func(t *testing.T) {
+		lex, err := lexer.NewLexer(lexer.Config{StringOnly: true})
+		assert.Nil(err)
+		data := `package main
+
+func main() {
+  f[0](x)
+}
+`
+		result := `File
+ Package: "package" @1:1 (kind=8)
+ Name: "main" @1:9 (kind=3)
+ Decls
+  FuncDecl
+   Function: "func" @3:1 (kind=10)
+   Name: "main" @3:6 (kind=3)
+   Params
+    (none)
+   Body
+    BlockStmt
+     LBrace: "{" @3:13 (kind=41)
+     Stmts
+      CallExpr
+       Callee
+        IndexExpr
+         X:
+         IdentExpr
+          Name: "f" @4:3 (kind=3)
+         LBracket: "[" @4:4 (kind=43)
+          IntLitExpr
+           Value: "0" @4:5 (kind=4)
+         RBracket: "]" @4:6 (kind=44)
+       LParent: "(" @4:7 (kind=39)
+       Args:
+        IdentExpr
+         Name: "x" @4:8 (kind=3)
+       RParent: ")" @4:9 (kind=40)
+     RBrace: "}" @5:1 (kind=42)
+`
+		parser := New(lex.FetchTokensFromString(data))
+		pr, err := parser.ParseFile(context.Background())
+		assert.Nil(err)
+		assert.Equal(result, ast.Dump(pr))
+		assert.Equal(0, len(parser.errors))
+	})
+
+	t.Run("index_call_ident", func(t *testing.T) {
+		lex, err := lexer.NewLexer(lexer.Config{StringOnly: true})
+		assert.Nil(err)
+		data := `package main
+
+func main() {
+  fns[i](x)
+}
+`
+		result := `File
+ Package: "package" @1:1 (kind=8)
+ Name: "main" @1:9 (kind=3)
+ Decls
+  FuncDecl
+   Function: "func" @3:1 (kind=10)
+   Name: "main" @3:6 (kind=3)
+   Params
+    (none)
+   Body
+    BlockStmt
+     LBrace: "{" @3:13 (kind=41)
+     Stmts
+      CallExpr
+       Callee
+        IndexExpr
+         X:
+         IdentExpr
+          Name: "fns" @4:3 (kind=3)
+         LBracket: "[" @4:6 (kind=43)
+          IdentExpr
+           Name: "i" @4:7 (kind=3)
+         RBracket: "]" @4:8 (kind=44)
+       LParent: "(" @4:9 (kind=39)
+       Args:
+        IdentExpr
+         Name: "x" @4:10 (kind=3)
+       RParent: ")" @4:11 (kind=40)
+     RBrace: "}" @5:1 (kind=42)
+`
+		parser := New(lex.FetchTokensFromString(data))
+		pr, err := parser.ParseFile(context.Background())
+		assert.Nil(err)
+		assert.Equal(result, ast.Dump(pr))
+		assert.Equal(0, len(parser.errors))
+	})
+
+	t.Run("generic_call_declared", func(t *testing.T) {
+		lex, err := lexer.NewLexer(lexer.Config{StringOnly: true})
+		assert.Nil(err)
+		data := `package main
+
+func Id[T any](v T) T {
+  return v
+}
+
+func main() {
+  Id[Point](p)
+}
+`
+		result := `File
+ Package: "package" @1:1 (kind=8)
+ Name: "main" @1:9 (kind=3)
+ Decls
+  FuncDecl
+   Function: "func" @3:1 (kind=10)
+   Name: "Id" @3:6 (kind=3)
+   TypeParams
+    TypeParam
+     Ident: "T" @3:9 (kind=3)
+     Constraint
+      NamedType
+       Ident: "any" @3:11 (kind=3)
+   Params
+    Param
+     Ident: "v" @3:16 (kind=3)
+     Type
+      NamedType
+       Ident: "T" @3:18 (kind=3)
+   Results
+     Param
+      Type
+       NamedType
+        Ident: "T" @3:21 (kind=3)
+   Body
+    BlockStmt
+     LBrace: "{" @3:23 (kind=41)
+     Stmts
+      ReturnStmt
+       Values
+        IdentExpr
+         Name: "v" @4:10 (kind=3)
+     RBrace: "}" @5:1 (kind=42)
+  FuncDecl
+   Function: "func" @7:1 (kind=10)
+   Name: "main" @7:6 (kind=3)
+   Params
+    (none)
+   Body
+    BlockStmt
+     LBrace: "{" @7:13 (kind=41)
+     Stmts
+      CallExpr
+       Callee
+        IdentExpr
+         Name: "Id" @8:3 (kind=3)
+       TypeArgs:
+        NamedType
+         Ident: "Point" @8:6 (kind=3)
+       LParent: "(" @8:12 (kind=39)
+       Args:
+        IdentExpr
+         Name: "p" @8:13 (kind=3)
+       RParent: ")" @8:14 (kind=40)
+     RBrace: "}" @9:1 (kind=42)
+`
+		parser := New(lex.FetchTokensFromString(data))
+		pr, err := parser.ParseFile(context.Background())
+		assert.Nil(err)
+		assert.Equal(result, ast.Dump(pr))
+		assert.Equal(0, len(parser.errors))
+	})
+
+	t.Run("defined_array_length", func(t *testing.T) {
+		lex, err := lexer.NewLexer(lexer.Config{StringOnly: true})
+		assert.Nil(err)
+		data := `package main
+
+type A [N+1]int
+type B [M / 5]int
+`
+		result := `File
+ Package: "package" @1:1 (kind=8)
+ Name: "main" @1:9 (kind=3)
+ Decls
+  DefinedTypeDecl:
+   TypeDecl: "type" @3:1 (kind=26)
+    Name: "A" @3:6 (kind=3)
+    Type
+     ArrayType:
+      LBracket: "[" @3:8 (kind=43)
+      BinaryExpr
+       IdentExpr
+        Name: "N" @3:9 (kind=3)
+       Operator: "+" @3:10 (kind=51)
+       IntLitExpr
+        Value: "1" @3:11 (kind=4)
+      RBracket: "]" @3:12 (kind=44)
+      NamedType
+       Ident: "int" @3:13 (kind=12)
+  DefinedTypeDecl:
+   TypeDecl: "type" @4:1 (kind=26)
+    Name: "B" @4:6 (kind=3)
+    Type
+     ArrayType:
+      LBracket: "[" @4:8 (kind=43)
+      BinaryExpr
+       IdentExpr
+        Name: "M" @4:9 (kind=3)
+       Operator: "/" @4:11 (kind=59)
+       IntLitExpr
+        Value: "5" @4:13 (kind=4)
+      RBracket: "]" @4:14 (kind=44)
+      NamedType
+       Ident: "int" @4:15 (kind=12)
+`
+		parser := New(lex.FetchTokensFromString(data))
+		pr, err := parser.ParseFile(context.Background())
+		assert.Nil(err)
+		assert.Equal(result, ast.Dump(pr))
+		assert.Equal(0, len(parser.errors))
+	})
+
+	t.Run("errors", func(t *testing.T) {
+		tests := []struct {
+			input    string
+			expected string
+		}{
+			{
+				input:    "package main\n\nfunc F[]() {}\n",
+				expected: `3:8: expected type parameter(s) inside brackets, got 44 "]"`,
+			},
+			{
+				input:    "package main\n\nfunc F[T]() {}\n",
+				expected: `3:9: expected constraint for type parameter, got 44 "]"`,
+			},
+			{
+				input:    "package main\n\ntype A[T any] struct {\n  x Box[]\n}\n",
+				expected: `4:9: expected type argument(s) inside brackets, got 44 "]"`,
+			},
+			{
+				input:    "package main\n\ntype A[T any] int\n",
+				expected: `3:7: type parameters are only allowed on struct, sum and interface types, got 43 "["`,
+			},
+			{
+				input:    "package main\n\nfunc F[T any | ;]() {}\n",
+				expected: `3:16: unsupported type with 46 ";"`,
+			},
+		}
+
+		for _, tc := range tests {
+			lex, err := lexer.NewLexer(lexer.Config{StringOnly: true})
+			assert.Nil(err)
+			parser := New(lex.FetchTokensFromString(tc.input))
+			_, err = parser.ParseFile(context.Background())
+			assert.Nil(err)
+			if assert.Greater(len(parser.errors), 0, tc.input) {
+				assert.Equal(tc.expected, parser.errors[0].Error(), tc.input)
+			}
+		}
+	})
+}
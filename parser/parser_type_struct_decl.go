@@ -11,22 +11,44 @@ import (
 func (p *Parser) parseStructDecl() ast.Decl {
 	kwt := p.expect(token.KWType, "expected 'type'")
 	kwi := p.expectValidIdent(token.Ident, true, "expected 'ident'")
-	kws := p.expect(token.KWStruct, "expected 'struct'")
-	lbrace := p.expect(token.LBrace, "expected '{'")
+	var params []ast.TypeParam
+	if p.kind() == token.LBracket {
+		params = p.parseTypeParams()
+	}
 
 	st := &ast.StructDecl{
-		TypeDecl: kwt,
-		Name:     kwi,
-		Public:   isPublic(kwi),
-		Struct:   kws,
-		LBrace:   lbrace,
+		TypeDecl:   kwt,
+		Name:       kwi,
+		TypeParams: params,
+		Public:     isPublic(kwi),
+		Struct:     p.expect(token.KWStruct, "expected 'struct'"),
 	}
+	st.LBrace, st.Fields, st.RBrace = p.parseStructFields()
+	return st
+}
 
-	for p.kind() != token.RBrace && p.kind() != token.EOF {
-		st.Fields = append(st.Fields, p.parseStructTypeField())
+// parseStructType returns an anonymous struct type like struct { a int }
+func (p *Parser) parseStructType() *ast.StructType {
+	st := &ast.StructType{Struct: p.expect(token.KWStruct, "expected 'struct'")}
+	st.LBrace, st.Fields, st.RBrace = p.parseStructFields()
+	return st
+}
 
-		if p.kind() == token.Comment {
-			_ = p.next()
+// parseStructFields returns the fields of a struct within braces
+// and reports fields declared more than once
+func (p *Parser) parseStructFields() (token.Token, []*ast.FieldDecl, token.Token) {
+	lbrace := p.expect(token.LBrace, "expected '{'")
+
+	var fields []*ast.FieldDecl
+	seen := make(map[string]bool)
+	for p.kind() != token.RBrace && p.kind() != token.EOF {
+		fd := p.parseStructTypeField()
+		fields = append(fields, fd)
+		if name := fieldName(fd); name.Value != "" && name.Value != "_" {
+			if seen[name.Value] {
+				p.errors = append(p.errors, fmt.Errorf("%d:%d: duplicate field '%s'", name.Line, name.Column, name.Value))
+			}
+			seen[name.Value] = true
 		}
 
 		if p.kind() == token.SemiComma {
@@ -47,30 +69,29 @@ func (p *Parser) parseStructDecl() ast.Decl {
 		p.consumeTo(token.RBrace)
 	}
 	rbrace := p.expect(token.RBrace, "expected '}'")
-	st.RBrace = rbrace
-
-	return st
+	return lbrace, fields, rbrace
 }
 
 // parseStructTypeField is in charge of parsing struct field
 func (p *Parser) parseStructTypeField() *ast.FieldDecl {
+	if p.isEmbeddedField() {
+		fd := &ast.FieldDecl{Embedded: true, Type: p.parseNamedType()}
+		name := fieldName(fd)
+		fd.Public = name.Value != "" && isPublic(name)
+		return fd
+	}
+
 	kw := p.expectValidIdent(token.Ident, true, "expected 'ident'")
 	fd := &ast.FieldDecl{
-		Name:   kw,
-		Public: isPublic(kw),
+		Name:      kw,
+		Public:    isPublic(kw),
+		Qualifier: p.parseQualifier(),
 	}
-	tok := p.peek()
-
-	if !token.IsStructFieldTypes(tok.Kind) {
-		fd.Type = &ast.BadType{From: tok, Reason: "unexpected type name"}
-		p.errors = append(p.errors, fmt.Errorf("%d:%d: unsupported type with %v %q", tok.Line, tok.Column, tok.Kind, tok.Value))
-		p.consumeTo(token.EOF)
+	fd.Type = p.parseType()
+	if _, ok := fd.Type.(*ast.BadType); ok {
+		p.syncField()
 		return fd
 	}
-	x := &ast.NamedType{}
-	x.Parts = append(x.Parts, tok)
-	fd.Type = x
-	_ = p.next()
 
 	if p.kind() == token.Assign {
 		kwa := p.expect(token.Assign, "expected '='")
@@ -79,3 +100,57 @@ func (p *Parser) parseStructTypeField() *ast.FieldDecl {
 	}
 	return fd
 }
+
+// isEmbeddedField returns true when the field is only made of
+// a type name like Base, pkg.Base or Box[int]
+func (p *Parser) isEmbeddedField() bool {
+	if p.kind() != token.Ident {
+		return false
+	}
+
+	pos := p.position + 1
+	switch p.kindNext(pos) {
+	case token.Dot:
+		return true
+	case token.LBracket:
+		end := p.closingBracket(pos)
+		if end == -1 || end == pos+1 {
+			return false
+		}
+		pos = end + 1
+	}
+	return p.endsField(pos)
+}
+
+// endsField returns true when the token at pos ends
+// the field started at the line of the previous one
+func (p *Parser) endsField(pos int) bool {
+	switch p.kindNext(pos) {
+	case token.SemiComma, token.RBrace, token.EOF:
+		return true
+	}
+	return p.peekNext(pos).Line > p.peekNext(pos-1).Line
+}
+
+// fieldName returns the name of the field which is
+// the last identifier of the type when it is embedded
+func fieldName(fd *ast.FieldDecl) token.Token {
+	if !fd.Embedded {
+		return fd.Name
+	}
+	if nt, ok := fd.Type.(*ast.NamedType); ok && len(nt.Parts) > 0 {
+		return nt.Parts[len(nt.Parts)-1]
+	}
+	return token.Token{}
+}
+
+// syncField skips tokens up to the end of the struct field
+// which is either a new line, ';' or '}'
+func (p *Parser) syncField() {
+	for p.kind() != token.EOF && p.kind() != token.SemiComma && p.kind() != token.RBrace {
+		_ = p.next()
+		if p.newlineSincePrev() {
+			return
+		}
+	}
+}
@@ -1,6 +1,7 @@
 package parser
 
 import (
+	"context"
 	"testing"
 
 	"github.com/orilang/gori/ast"
@@ -19,7 +20,8 @@ func TestParser_type_struct_decl(t *testing.T) {
 type test struct{}
 `
 		parser := New(lex.FetchTokensFromString(data))
-		pr := parser.ParseFile()
+		pr, err := parser.ParseFile(context.Background())
+		assert.Nil(err)
 		result := `File
  Package: "package" @1:1 (kind=8)
  Name: "main" @1:9 (kind=3)
@@ -43,7 +45,8 @@ type test struct{}
 type Test struct{}
 `
 		parser := New(lex.FetchTokensFromString(data))
-		pr := parser.ParseFile()
+		pr, err := parser.ParseFile(context.Background())
+		assert.Nil(err)
 		result := `File
  Package: "package" @1:1 (kind=8)
  Name: "main" @1:9 (kind=3)
@@ -70,7 +73,8 @@ type test struct{
 }
 `
 		parser := New(lex.FetchTokensFromString(data))
-		pr := parser.ParseFile()
+		pr, err := parser.ParseFile(context.Background())
+		assert.Nil(err)
 		result := `File
  Package: "package" @1:1 (kind=8)
  Name: "main" @1:9 (kind=3)
@@ -98,7 +102,8 @@ type test struct{
 type test struct{x int}
 `
 		parser := New(lex.FetchTokensFromString(data))
-		pr := parser.ParseFile()
+		pr, err := parser.ParseFile(context.Background())
+		assert.Nil(err)
 		result := `File
  Package: "package" @1:1 (kind=8)
  Name: "main" @1:9 (kind=3)
@@ -126,7 +131,8 @@ type test struct{x int}
 type test struct{x int;Y string}
 `
 		parser := New(lex.FetchTokensFromString(data))
-		pr := parser.ParseFile()
+		pr, err := parser.ParseFile(context.Background())
+		assert.Nil(err)
 		result := `File
  Package: "package" @1:1 (kind=8)
  Name: "main" @1:9 (kind=3)
@@ -160,7 +166,8 @@ type test struct{x int
 }
 `
 		parser := New(lex.FetchTokensFromString(data))
-		pr := parser.ParseFile()
+		pr, err := parser.ParseFile(context.Background())
+		assert.Nil(err)
 		result := `File
  Package: "package" @1:1 (kind=8)
  Name: "main" @1:9 (kind=3)
@@ -189,7 +196,8 @@ type test struct { x int // comment
 }
 `
 		parser := New(lex.FetchTokensFromString(data))
-		pr := parser.ParseFile()
+		pr, err := parser.ParseFile(context.Background())
+		assert.Nil(err)
 		result := `File
  Package: "package" @1:1 (kind=8)
  Name: "main" @1:9 (kind=3)
@@ -217,7 +225,8 @@ type test struct { x int // comment
 type test struct{x int = 5}
 `
 		parser := New(lex.FetchTokensFromString(data))
-		pr := parser.ParseFile()
+		pr, err := parser.ParseFile(context.Background())
+		assert.Nil(err)
 		result := `File
  Package: "package" @1:1 (kind=8)
  Name: "main" @1:9 (kind=3)
@@ -251,7 +260,8 @@ type test struct{
 }
 `
 		parser := New(lex.FetchTokensFromString(data))
-		pr := parser.ParseFile()
+		pr, err := parser.ParseFile(context.Background())
+		assert.Nil(err)
 		result := `File
  Package: "package" @1:1 (kind=8)
  Name: "main" @1:9 (kind=3)
@@ -291,7 +301,8 @@ type test struct{
 }
 `
 		parser := New(lex.FetchTokensFromString(data))
-		pr := parser.ParseFile()
+		pr, err := parser.ParseFile(context.Background())
+		assert.Nil(err)
 		result := `File
  Package: "package" @1:1 (kind=8)
  Name: "main" @1:9 (kind=3)
@@ -331,7 +342,8 @@ type test struct{
 type test structt{}
 `
 		parser := New(lex.FetchTokensFromString(data))
-		pr := parser.ParseFile()
+		pr, err := parser.ParseFile(context.Background())
+		assert.Nil(err)
 		assert.NotNil(pr)
 		assert.Greater(len(parser.errors), 0)
 	})
@@ -341,10 +353,11 @@ type test structt{}
 		assert.Nil(err)
 		data := `package main
 
-type test struct{x}
+type test struct{x =}
 `
 		parser := New(lex.FetchTokensFromString(data))
-		pr := parser.ParseFile()
+		pr, err := parser.ParseFile(context.Background())
+		assert.Nil(err)
 		assert.NotNil(pr)
 		assert.Greater(len(parser.errors), 0)
 	})
@@ -357,7 +370,8 @@ type test struct{x}
 type test struct{x int,}
 `
 		parser := New(lex.FetchTokensFromString(data))
-		pr := parser.ParseFile()
+		pr, err := parser.ParseFile(context.Background())
+		assert.Nil(err)
 		assert.NotNil(pr)
 		assert.Greater(len(parser.errors), 0)
 	})
@@ -370,7 +384,8 @@ type test struct{x int,}
 type test struct{x int;x}
 `
 		parser := New(lex.FetchTokensFromString(data))
-		pr := parser.ParseFile()
+		pr, err := parser.ParseFile(context.Background())
+		assert.Nil(err)
 		assert.NotNil(pr)
 		assert.Greater(len(parser.errors), 0)
 	})
@@ -383,7 +398,8 @@ type test struct{x int;x}
 type test struct{x int =}
 `
 		parser := New(lex.FetchTokensFromString(data))
-		pr := parser.ParseFile()
+		pr, err := parser.ParseFile(context.Background())
+		assert.Nil(err)
 		assert.NotNil(pr)
 		assert.Greater(len(parser.errors), 0)
 	})
@@ -396,8 +412,183 @@ type test struct{x int =}
 type _test struct {}
 `
 		parser := New(lex.FetchTokensFromString(data))
-		pr := parser.ParseFile()
+		pr, err := parser.ParseFile(context.Background())
+		assert.Nil(err)
 		assert.NotNil(pr)
 		assert.Greater(len(parser.errors), 0)
 	})
+
+	t.Run("embedded", func(t *testing.T) {
+		lex, err := lexer.NewLexer(lexer.Config{StringOnly: true})
+		assert.Nil(err)
+		data := `package main
+
+type a struct {
+  Base
+  pkg.Other
+  Box[int]
+  b []int
+}
+`
+		result := `File
+ Package: "package" @1:1 (kind=8)
+ Name: "main" @1:9 (kind=3)
+ Decls
+  StructDecl:
+   Type: "type" @3:1 (kind=26)
+   Name: "a" @3:6 (kind=3)
+   Struct: "struct" @3:8 (kind=27)
+   LBrace: "{" @3:15 (kind=41)
+    Embedded: true
+    Public: true
+    Type:
+     NamedType
+      Ident: "Base" @4:3 (kind=3)
+    Embedded: true
+    Public: true
+    Type:
+     NamedType
+      Ident: "pkg" @5:3 (kind=3)
+      Dot: "." @5:6 (kind=48)
+      Ident: "Other" @5:7 (kind=3)
+    Embedded: true
+    Public: true
+    Type:
+     NamedType
+      Ident: "Box" @6:3 (kind=3)
+      TypeArgs
+       NamedType
+        Ident: "int" @6:7 (kind=12)
+    Name: "b" @7:3 (kind=3)
+    Type:
+     SliceType:
+      LBracket: "[" @7:5 (kind=43)
+      RBracket: "]" @7:6 (kind=44)
+      NamedType
+       Ident: "int" @7:7 (kind=12)
+   RBrace: "}" @8:1 (kind=42)
+`
+		parser := New(lex.FetchTokensFromString(data))
+		pr, err := parser.ParseFile(context.Background())
+		assert.Nil(err)
+		assert.Equal(result, ast.Dump(pr))
+		assert.Equal(0, len(parser.errors))
+	})
+
+	t.Run("anonymous_struct", func(t *testing.T) {
+		lex, err := lexer.NewLexer(lexer.Config{StringOnly: true})
+		assert.Nil(err)
+		data := `package main
+
+type a struct {
+  b struct {
+    c int
+    d struct{ e string }
+  }
+  f []struct{ g bool }
+}
+
+func h(i struct{ j int }) {}
+`
+		result := `File
+ Package: "package" @1:1 (kind=8)
+ Name: "main" @1:9 (kind=3)
+ Decls
+  StructDecl:
+   Type: "type" @3:1 (kind=26)
+   Name: "a" @3:6 (kind=3)
+   Struct: "struct" @3:8 (kind=27)
+   LBrace: "{" @3:15 (kind=41)
+    Name: "b" @4:3 (kind=3)
+    Type:
+     StructType:
+      Struct: "struct" @4:5 (kind=27)
+      LBrace: "{" @4:12 (kind=41)
+       Name: "c" @5:5 (kind=3)
+       Type:
+        NamedType
+         Ident: "int" @5:7 (kind=12)
+       Name: "d" @6:5 (kind=3)
+       Type:
+        StructType:
+         Struct: "struct" @6:7 (kind=27)
+         LBrace: "{" @6:13 (kind=41)
+          Name: "e" @6:15 (kind=3)
+          Type:
+           NamedType
+            Ident: "string" @6:17 (kind=24)
+         RBrace: "}" @6:24 (kind=42)
+      RBrace: "}" @7:3 (kind=42)
+    Name: "f" @8:3 (kind=3)
+    Type:
+     SliceType:
+      LBracket: "[" @8:5 (kind=43)
+      RBracket: "]" @8:6 (kind=44)
+      StructType:
+       Struct: "struct" @8:7 (kind=27)
+       LBrace: "{" @8:13 (kind=41)
+        Name: "g" @8:15 (kind=3)
+        Type:
+         NamedType
+          Ident: "bool" @8:17 (kind=25)
+       RBrace: "}" @8:22 (kind=42)
+   RBrace: "}" @9:1 (kind=42)
+  FuncDecl
+   Function: "func" @11:1 (kind=10)
+   Name: "h" @11:6 (kind=3)
+   Params
+    Param
+     Ident: "i" @11:8 (kind=3)
+     Type
+      StructType:
+       Struct: "struct" @11:10 (kind=27)
+       LBrace: "{" @11:16 (kind=41)
+        Name: "j" @11:18 (kind=3)
+        Type:
+         NamedType
+          Ident: "int" @11:20 (kind=12)
+       RBrace: "}" @11:24 (kind=42)
+   Body
+`
+		parser := New(lex.FetchTokensFromString(data))
+		pr, err := parser.ParseFile(context.Background())
+		assert.Nil(err)
+		assert.Equal(result, ast.Dump(pr))
+		assert.Equal(0, len(parser.errors))
+	})
+
+	t.Run("bad_embedded", func(t *testing.T) {
+		tests := []struct {
+			input    string
+			expected string
+		}{
+			{
+				input:    "package main\n\ntype a struct {\n  Base\n  Base int\n}\n",
+				expected: `5:3: duplicate field 'Base'`,
+			},
+			{
+				input:    "package main\n\ntype a struct {\n  pkg.Base\n  Base\n}\n",
+				expected: `5:3: duplicate field 'Base'`,
+			},
+			{
+				input:    "package main\n\ntype a struct {\n  Base = 1\n}\n",
+				expected: `4:8: unsupported type with 49 "="`,
+			},
+			{
+				input:    "package main\n\nvar a struct {\n  b int\n  b string\n} = c\n",
+				expected: `5:3: duplicate field 'b'`,
+			},
+		}
+
+		for _, tc := range tests {
+			lex, err := lexer.NewLexer(lexer.Config{StringOnly: true})
+			assert.Nil(err)
+			parser := New(lex.FetchTokensFromString(tc.input))
+			_, err = parser.ParseFile(context.Background())
+			assert.Nil(err)
+			if assert.Greater(len(parser.errors), 0, tc.input) {
+				assert.Equal(tc.expected, parser.errors[0].Error(), tc.input)
+			}
+		}
+	})
 }
@@ -11,15 +11,20 @@ import (
 func (p *Parser) parseSumDecl() ast.Decl {
 	kwt := p.expect(token.KWType, "expected 'type'")
 	kwi := p.expectValidIdent(token.Ident, true, "expected 'ident'")
+	var params []ast.TypeParam
+	if p.kind() == token.LBracket {
+		params = p.parseTypeParams()
+	}
 	kws := p.expect(token.KWSum, "expected 'sum'")
 	lbrace := p.expect(token.LBrace, "expected '{'")
 
 	st := &ast.SumDecl{
-		TypeDecl: kwt,
-		Name:     kwi,
-		Public:   isPublic(kwi),
-		Sum:      kws,
-		LBrace:   lbrace,
+		TypeDecl:   kwt,
+		Name:       kwi,
+		TypeParams: params,
+		Public:     isPublic(kwi),
+		Sum:        kws,
+		LBrace:     lbrace,
 	}
 
 	for p.kind() != token.RBrace && p.kind() != token.EOF {
@@ -37,10 +42,6 @@ func (p *Parser) parseSumDecl() ast.Decl {
 			}
 		}
 
-		if p.kind() == token.Comment {
-			_ = p.next()
-		}
-
 		if p.kind() == token.SemiComma {
 			_ = p.next()
 			continue
@@ -111,29 +112,12 @@ func (p *Parser) parseSumFuncSignature() ast.SumVariant {
 	}
 
 	_ = p.expect(token.RParen, "expected ')' after function name")
+	p.checkVariadic(f.Params, true)
 	return f
 }
 
 // parseFuncSignatureParam returns function parameter
 func (p *Parser) parseSumFuncSignatureParam() ast.Param {
 	name := p.expectValidIdent(token.Ident, true, "expected parameter identifier")
-	return ast.Param{Name: name, Type: p.parseSumFuncSignatureParamType()}
-}
-
-// parseFuncSignatureParamType returns func parameter type
-func (p *Parser) parseSumFuncSignatureParamType() ast.Type {
-	typ := &ast.NamedType{}
-
-	if token.IsFuncParamTypes(p.kind()) {
-		if p.kind() == token.Ident {
-			typ.Parts = append(typ.Parts, p.expectValidIdent(p.kind(), true, "expected valid ident"))
-		} else {
-			typ.Parts = append(typ.Parts, p.next())
-		}
-	} else {
-		tok := p.next()
-		p.errors = append(p.errors, fmt.Errorf("%d:%d: unsupported type with %v %q", tok.Line, tok.Column, tok.Kind, tok.Value))
-		p.consumeTo(token.RParen)
-	}
-	return typ
+	return ast.Param{Name: name, Ellipsis: p.parseEllipsis(), Type: p.parseType()}
 }
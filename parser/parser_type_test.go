@@ -0,0 +1,310 @@
+package parser
+
+import (
+	"context"
+	"testing"
+
+	"github.com/orilang/gori/ast"
+	"github.com/orilang/gori/lexer"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParser_type(t *testing.T) {
+	assert := assert.New(t)
+
+	t.Run("struct_fields", func(t *testing.T) {
+		lex, err := lexer.NewLexer(lexer.Config{StringOnly: true})
+		assert.Nil(err)
+		data := `package main
+
+type a struct {
+  b []int
+  c map[string][]int
+  d pkg.Type
+  e [4]float
+}
+`
+		result := `File
+ Package: "package" @1:1 (kind=8)
+ Name: "main" @1:9 (kind=3)
+ Decls
+  StructDecl:
+   Type: "type" @3:1 (kind=26)
+   Name: "a" @3:6 (kind=3)
+   Struct: "struct" @3:8 (kind=27)
+   LBrace: "{" @3:15 (kind=41)
+    Name: "b" @4:3 (kind=3)
+    Type:
+     SliceType:
+      LBracket: "[" @4:5 (kind=43)
+      RBracket: "]" @4:6 (kind=44)
+      NamedType
+       Ident: "int" @4:7 (kind=12)
+    Name: "c" @5:3 (kind=3)
+    Type:
+     MapType:
+      Map: "map" @5:5 (kind=79)
+      LBracket: "[" @5:8 (kind=43)
+      KeyType:
+       NamedType
+        Ident: "string" @5:9 (kind=24)
+      RBracket: "]" @5:15 (kind=44)
+      ValueType:
+       SliceType:
+        LBracket: "[" @5:16 (kind=43)
+        RBracket: "]" @5:17 (kind=44)
+        NamedType
+         Ident: "int" @5:18 (kind=12)
+    Name: "d" @6:3 (kind=3)
+    Type:
+     NamedType
+      Ident: "pkg" @6:5 (kind=3)
+      Dot: "." @6:8 (kind=48)
+      Ident: "Type" @6:9 (kind=3)
+    Name: "e" @7:3 (kind=3)
+    Type:
+     ArrayType:
+      LBracket: "[" @7:5 (kind=43)
+      IntLitExpr
+       Value: "4" @7:6 (kind=4)
+      RBracket: "]" @7:7 (kind=44)
+      NamedType
+       Ident: "float" @7:8 (kind=20)
+   RBrace: "}" @8:1 (kind=42)
+`
+		parser := New(lex.FetchTokensFromString(data))
+		pr, err := parser.ParseFile(context.Background())
+		assert.Nil(err)
+		assert.Equal(result, ast.Dump(pr))
+		assert.Equal(0, len(parser.errors))
+	})
+
+	t.Run("func_types", func(t *testing.T) {
+		lex, err := lexer.NewLexer(lexer.Config{StringOnly: true})
+		assert.Nil(err)
+		data := `package main
+
+func a(b func(int) string, c func(x int, y bool) (string, bool)) func() {
+}
+`
+		result := `File
+ Package: "package" @1:1 (kind=8)
+ Name: "main" @1:9 (kind=3)
+ Decls
+  FuncDecl
+   Function: "func" @3:1 (kind=10)
+   Name: "a" @3:6 (kind=3)
+   Params
+    Param
+     Ident: "b" @3:8 (kind=3)
+     Type
+      FuncType
+       Func: "func" @3:10 (kind=10)
+       LParen: "(" @3:14 (kind=39)
+       Params
+        Param
+         Type
+          NamedType
+           Ident: "int" @3:15 (kind=12)
+       RParen: ")" @3:18 (kind=40)
+       Results
+        Param
+         Type
+          NamedType
+           Ident: "string" @3:20 (kind=24)
+    Param
+     Ident: "c" @3:28 (kind=3)
+     Type
+      FuncType
+       Func: "func" @3:30 (kind=10)
+       LParen: "(" @3:34 (kind=39)
+       Params
+        Param
+         Ident: "x" @3:35 (kind=3)
+         Type
+          NamedType
+           Ident: "int" @3:37 (kind=12)
+        Param
+         Ident: "y" @3:42 (kind=3)
+         Type
+          NamedType
+           Ident: "bool" @3:44 (kind=25)
+       RParen: ")" @3:48 (kind=40)
+       Results
+        LParen: "(" @3:50 (kind=39)
+        Param
+         Type
+          NamedType
+           Ident: "string" @3:51 (kind=24)
+        Param
+         Type
+          NamedType
+           Ident: "bool" @3:59 (kind=25)
+        RParen: ")" @3:63 (kind=40)
+   Results
+     Param
+      Type
+       FuncType
+        Func: "func" @3:66 (kind=10)
+        LParen: "(" @3:70 (kind=39)
+        Params
+         (none)
+        RParen: ")" @3:71 (kind=40)
+   Body
+`
+		parser := New(lex.FetchTokensFromString(data))
+		pr, err := parser.ParseFile(context.Background())
+		assert.Nil(err)
+		assert.Equal(result, ast.Dump(pr))
+		assert.Equal(0, len(parser.errors))
+	})
+
+	t.Run("var_and_sum", func(t *testing.T) {
+		lex, err := lexer.NewLexer(lexer.Config{StringOnly: true})
+		assert.Nil(err)
+		data := `package main
+
+var a hashmap[int]Box[string] = b
+
+type c sum {
+  D(e [2][]int)
+}
+`
+		result := `File
+ Package: "package" @1:1 (kind=8)
+ Name: "main" @1:9 (kind=3)
+ Decls
+  VarDecl
+   Var: "var" @3:1 (kind=11)
+   Name: "a" @3:5 (kind=3)
+   Type
+    MapType:
+     Hashmap: "hashmap" @3:7 (kind=80)
+     LBracket: "[" @3:14 (kind=43)
+     KeyType:
+      NamedType
+       Ident: "int" @3:15 (kind=12)
+     RBracket: "]" @3:18 (kind=44)
+     ValueType:
+      NamedType
+       Ident: "Box" @3:19 (kind=3)
+       TypeArgs
+        NamedType
+         Ident: "string" @3:23 (kind=24)
+   Eq: "=" @3:31 (kind=49)
+   Init
+    IdentExpr
+     Name: "b" @3:33 (kind=3)
+  SumDecl:
+   Type: "type" @5:1 (kind=26)
+    Name: "c" @5:6 (kind=3)
+    Sum: "sum" @5:8 (kind=75)
+   LBrace: "{" @5:12 (kind=41)
+    Variants
+     SumVariant: "D" @6:3 (kind=3)
+      Params
+       Param
+        Ident: "e" @6:5 (kind=3)
+        Type
+         ArrayType:
+          LBracket: "[" @6:7 (kind=43)
+          IntLitExpr
+           Value: "2" @6:8 (kind=4)
+          RBracket: "]" @6:9 (kind=44)
+          SliceType:
+           LBracket: "[" @6:10 (kind=43)
+           RBracket: "]" @6:11 (kind=44)
+           NamedType
+            Ident: "int" @6:12 (kind=12)
+   RBrace: "}" @7:1 (kind=42)
+`
+		parser := New(lex.FetchTokensFromString(data))
+		pr, err := parser.ParseFile(context.Background())
+		assert.Nil(err)
+		assert.Equal(result, ast.Dump(pr))
+		assert.Equal(0, len(parser.errors))
+	})
+
+	t.Run("defined_types", func(t *testing.T) {
+		lex, err := lexer.NewLexer(lexer.Config{StringOnly: true})
+		assert.Nil(err)
+		data := `package main
+
+type a []int
+type b map[string]pkg.Type
+type c Other
+`
+		result := `File
+ Package: "package" @1:1 (kind=8)
+ Name: "main" @1:9 (kind=3)
+ Decls
+  DefinedTypeDecl:
+   TypeDecl: "type" @3:1 (kind=26)
+    Name: "a" @3:6 (kind=3)
+    Type
+     SliceType:
+      LBracket: "[" @3:8 (kind=43)
+      RBracket: "]" @3:9 (kind=44)
+      NamedType
+       Ident: "int" @3:10 (kind=12)
+  DefinedTypeDecl:
+   TypeDecl: "type" @4:1 (kind=26)
+    Name: "b" @4:6 (kind=3)
+    Type
+     MapType:
+      Map: "map" @4:8 (kind=79)
+      LBracket: "[" @4:11 (kind=43)
+      KeyType:
+       NamedType
+        Ident: "string" @4:12 (kind=24)
+      RBracket: "]" @4:18 (kind=44)
+      ValueType:
+       NamedType
+        Ident: "pkg" @4:19 (kind=3)
+        Dot: "." @4:22 (kind=48)
+        Ident: "Type" @4:23 (kind=3)
+  DefinedTypeDecl:
+   TypeDecl: "type" @5:1 (kind=26)
+    Name: "c" @5:6 (kind=3)
+    Type
+     NamedType
+      Ident: "Other" @5:8 (kind=3)
+`
+		parser := New(lex.FetchTokensFromString(data))
+		pr, err := parser.ParseFile(context.Background())
+		assert.Nil(err)
+		assert.Equal(result, ast.Dump(pr))
+		assert.Equal(0, len(parser.errors))
+	})
+
+	t.Run("errors", func(t *testing.T) {
+		tests := []struct {
+			input    string
+			expected string
+		}{
+			{
+				input:    "package main\n\nvar a []\n",
+				expected: `4:1: unsupported type with 1 ""`,
+			},
+			{
+				input:    "package main\n\ntype a struct {\n  b map[int\n}\n",
+				expected: `5:1 expected ']' (got 42 "}")`,
+			},
+			{
+				input:    "package main\n\nfunc a(b _) {}\n",
+				expected: `3:10 invalid ident format (got 3 "_")`,
+			},
+		}
+
+		for _, tc := range tests {
+			lex, err := lexer.NewLexer(lexer.Config{StringOnly: true})
+			assert.Nil(err)
+			parser := New(lex.FetchTokensFromString(tc.input))
+			_, err = parser.ParseFile(context.Background())
+			assert.Nil(err)
+			if assert.Greater(len(parser.errors), 0, tc.input) {
+				assert.Equal(tc.expected, parser.errors[0].Error(), tc.input)
+			}
+		}
+	})
+}
@@ -0,0 +1,35 @@
+package parser
+
+import (
+	"fmt"
+
+	"github.com/orilang/gori/ast"
+	"github.com/orilang/gori/token"
+)
+
+// parseEllipsis returns the '...' of a variadic parameter
+// or an empty token when there is none
+func (p *Parser) parseEllipsis() token.Token {
+	if p.kind() != token.Ellipsis {
+		return token.Token{}
+	}
+	return p.next()
+}
+
+// checkVariadic reports variadic parameters which are not the last one.
+// When last is false, like for receivers and results, none can be variadic
+func (p *Parser) checkVariadic(params []ast.Param, last bool) {
+	for i, param := range params {
+		tok := param.Ellipsis
+		if tok.Kind != token.Ellipsis {
+			continue
+		}
+		if !last {
+			p.errors = append(p.errors, fmt.Errorf("%d:%d: unexpected variadic parameter, got %v %q", tok.Line, tok.Column, tok.Kind, tok.Value))
+			continue
+		}
+		if i != len(params)-1 {
+			p.errors = append(p.errors, fmt.Errorf("%d:%d: only the last parameter can be variadic, got %v %q", tok.Line, tok.Column, tok.Kind, tok.Value))
+		}
+	}
+}
@@ -0,0 +1,195 @@
+package parser
+
+import (
+	"context"
+	"testing"
+
+	"github.com/orilang/gori/ast"
+	"github.com/orilang/gori/lexer"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParser_variadic(t *testing.T) {
+	assert := assert.New(t)
+
+	t.Run("func_decl", func(t *testing.T) {
+		lex, err := lexer.NewLexer(lexer.Config{StringOnly: true})
+		assert.Nil(err)
+		data := `package main
+
+func log(format string, args view ...string) {
+  print(format, args...)
+}
+`
+		result := `File
+ Package: "package" @1:1 (kind=8)
+ Name: "main" @1:9 (kind=3)
+ Decls
+  FuncDecl
+   Function: "func" @3:1 (kind=10)
+   Name: "log" @3:6 (kind=3)
+   Params
+    Param
+     Ident: "format" @3:10 (kind=3)
+     Type
+      NamedType
+       Ident: "string" @3:17 (kind=24)
+    Param
+     Ident: "args" @3:25 (kind=3)
+     Qualifier: "view" @3:30 (kind=76)
+     Ellipsis: "..." @3:35 (kind=86)
+     Type
+      NamedType
+       Ident: "string" @3:38 (kind=24)
+   Body
+    BlockStmt
+     LBrace: "{" @3:46 (kind=41)
+     Stmts
+      CallExpr
+       Callee
+        IdentExpr
+         Name: "print" @4:3 (kind=3)
+       LParent: "(" @4:8 (kind=39)
+       Args:
+        IdentExpr
+         Name: "format" @4:9 (kind=3)
+        IdentExpr
+         Name: "args" @4:17 (kind=3)
+       Ellipsis: "..." @4:21 (kind=86)
+       RParent: ")" @4:24 (kind=40)
+     RBrace: "}" @5:1 (kind=42)
+`
+		parser := New(lex.FetchTokensFromString(data))
+		pr, err := parser.ParseFile(context.Background())
+		assert.Nil(err)
+		assert.Equal(result, ast.Dump(pr))
+		assert.Equal(0, len(parser.errors))
+	})
+
+	t.Run("interface_sum_and_func_type", func(t *testing.T) {
+		lex, err := lexer.NewLexer(lexer.Config{StringOnly: true})
+		assert.Nil(err)
+		data := `package main
+
+type Logger interface {
+  Log(args ...string)
+}
+
+type Event sum {
+  Tags(values ...string)
+}
+
+var f func(int, ...string) = g
+`
+		result := `File
+ Package: "package" @1:1 (kind=8)
+ Name: "main" @1:9 (kind=3)
+ Decls
+  InterfaceDecl:
+   Type: "type" @3:1 (kind=26)
+   Name: "Logger" @3:6 (kind=3)
+   Interface: "interface" @3:13 (kind=28)
+   Public: true
+   LBrace: "{" @3:23 (kind=41)
+   Name: "Log" @4:3 (kind=3)
+   Params
+    Param
+     Ident: "args" @4:7 (kind=3)
+     Ellipsis: "..." @4:12 (kind=86)
+     Type
+      NamedType
+       Ident: "string" @4:15 (kind=24)
+   RBrace: "}" @5:1 (kind=42)
+  SumDecl:
+   Type: "type" @7:1 (kind=26)
+    Name: "Event" @7:6 (kind=3)
+    Sum: "sum" @7:12 (kind=75)
+   Public: true
+   LBrace: "{" @7:16 (kind=41)
+    Variants
+     SumVariant: "Tags" @8:3 (kind=3)
+      Params
+       Param
+        Ident: "values" @8:8 (kind=3)
+        Ellipsis: "..." @8:15 (kind=86)
+        Type
+         NamedType
+          Ident: "string" @8:18 (kind=24)
+   RBrace: "}" @9:1 (kind=42)
+  VarDecl
+   Var: "var" @11:1 (kind=11)
+   Name: "f" @11:5 (kind=3)
+   Type
+    FuncType
+     Func: "func" @11:7 (kind=10)
+     LParen: "(" @11:11 (kind=39)
+     Params
+      Param
+       Type
+        NamedType
+         Ident: "int" @11:12 (kind=12)
+      Param
+       Ellipsis: "..." @11:17 (kind=86)
+       Type
+        NamedType
+         Ident: "string" @11:20 (kind=24)
+     RParen: ")" @11:26 (kind=40)
+   Eq: "=" @11:28 (kind=49)
+   Init
+    IdentExpr
+     Name: "g" @11:30 (kind=3)
+`
+		parser := New(lex.FetchTokensFromString(data))
+		pr, err := parser.ParseFile(context.Background())
+		assert.Nil(err)
+		assert.Equal(result, ast.Dump(pr))
+		assert.Equal(0, len(parser.errors))
+	})
+
+	t.Run("errors", func(t *testing.T) {
+		tests := []struct {
+			input    string
+			expected string
+		}{
+			{
+				input:    "package main\n\nfunc a(b ...int, c int) {}\n",
+				expected: `3:10: only the last parameter can be variadic, got 86 "..."`,
+			},
+			{
+				input:    "package main\n\nfunc (s ...Stack) a() {}\n",
+				expected: `3:9: unexpected variadic parameter, got 86 "..."`,
+			},
+			{
+				input:    "package main\n\nfunc a() (b ...int) {}\n",
+				expected: `3:13: unexpected variadic parameter, got 86 "..."`,
+			},
+			{
+				input:    "package main\n\ntype A interface {\n  B(c ...int, d int)\n}\n",
+				expected: `4:7: only the last parameter can be variadic, got 86 "..."`,
+			},
+			{
+				input:    "package main\n\ntype A sum {\n  B(c ...int, d int)\n}\n",
+				expected: `4:7: only the last parameter can be variadic, got 86 "..."`,
+			},
+			{
+				input:    "package main\n\nvar f func(...int, string) = g\n",
+				expected: `3:12: only the last parameter can be variadic, got 86 "..."`,
+			},
+			{
+				input:    "package main\n\nfunc a() {\n  b(c..., d)\n}\n",
+				expected: `4:9: can only use '...' with the last argument, got 45 ","`,
+			},
+		}
+
+		for _, tc := range tests {
+			lex, err := lexer.NewLexer(lexer.Config{StringOnly: true})
+			assert.Nil(err)
+			parser := New(lex.FetchTokensFromString(tc.input))
+			_, err = parser.ParseFile(context.Background())
+			assert.Nil(err)
+			if assert.Greater(len(parser.errors), 0, tc.input) {
+				assert.Equal(tc.expected, parser.errors[0].Error(), tc.input)
+			}
+		}
+	})
+}
@@ -1,6 +1,9 @@
 package parser
 
 import (
+	"context"
+
+	"github.com/orilang/gori/ast"
 	"github.com/orilang/gori/token"
 )
 
@@ -14,6 +17,17 @@ type Config struct {
 
 	// Output when set to true outputs the AST
 	Output bool
+
+	// Jobs is the number of files processed in parallel.
+	// When lower than 1, the number of CPUs is used
+	Jobs int
+
+	// TabWidth is the number of columns a tab advances to.
+	// When lower than 2, a tab counts as a single column
+	TabWidth int
+
+	// Tags are the build tags used to evaluate //ori:build constraints
+	Tags []string
 }
 
 // Files holds all files to use for tokenization
@@ -23,18 +37,106 @@ type Files struct {
 
 	// output when set to true outputs the AST
 	output bool
+
+	// jobs is the number of files processed in parallel
+	jobs int
+
+	// tabWidth is the number of columns a tab advances to
+	tabWidth int
+}
+
+// result holds the outcome of a parsed file
+type result struct {
+	// output holds the dumped AST
+	output []byte
+
+	// errors holds the lexing and parsing errors
+	errors []error
 }
 
 // Parser holds requirements with the tokens from the Lexer to
 // build the Abstract Syntax Tree (AST)
 type Parser struct {
+	// Tokens holds the tokens being parsed.
+	// When the parser reads from a TokenSource, it only holds
+	// the lookahead window starting at base
 	Tokens    []token.Token
+	src       TokenSource
+	ctx       context.Context
 	errors    []error
 	size      int
 	position  int
+	base      int
 	loopDepth int
+
+	// blockDepth is the number of nested blocks being parsed
+	blockDepth int
+
+	// comptime is true while parsing a comptime function
+	comptime bool
+
+	// labels holds the labeled statements enclosing
+	// the statement being parsed
+	labels []label
+
+	// funcLabels holds the labels of the function being parsed
+	funcLabels map[string]bool
+
+	// generics holds the generic functions declared so far
+	generics map[string]bool
+
+	// typeParams holds the type parameters of the function being parsed
+	typeParams map[string]bool
+
+	// labelRefs holds the break and continue labels not found
+	// in labels, resolved at the end of the function
+	labelRefs []labelRef
+
+	// directives holds the file directives found so far
+	directives []*ast.Directive
+
+	// group holds the comment group being read and
+	// groupEnd the line where it ends
+	group    []token.Token
+	groupEnd int
+
+	// prevLine is the line of the last token pushed
+	prevLine int
+
+	// docs holds the comment groups located right above
+	// a token, ordered by token position
+	docs []doc
+}
+
+// label holds the name of a labeled statement
+// and the kind of the statement it labels
+type label struct {
+	name token.Token
+	kind token.Kind
 }
 
+// labelRef holds a label used by a break or continue statement
+type labelRef struct {
+	stmt  token.Token
+	label token.Token
+}
+
+// doc holds the comment group documenting the token at pos
+type doc struct {
+	pos   int
+	group *ast.CommentGroup
+}
+
+// TokenSource is implemented by anything handing out
+// tokens one at a time like *lexer.Lexer
+type TokenSource interface {
+	Next() token.Token
+}
+
+// windowSize is the number of consumed tokens kept in the
+// lookahead buffer before they are dropped
+const windowSize = 256
+
 const (
 	LOWEST int = iota
 	OR
@@ -63,4 +165,5 @@ var precedence = map[token.Kind]int{
 	token.Modulo:   MULTIPLICATIVE,
 	token.Dot:      POSTFIX,
 	token.LBracket: POSTFIX,
+	token.LParen:   POSTFIX,
 }
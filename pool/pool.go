@@ -0,0 +1,91 @@
+package pool
+
+import (
+	"context"
+	"runtime"
+	"sync"
+)
+
+// result holds the outcome of a job
+type result[T any] struct {
+	value T
+	err   error
+}
+
+// Run calls fn for each index in [0, n) with at most jobs running at the same time.
+// When jobs is lower than 1, the number of CPUs is used.
+// emit is called with each value in index order as soon as it is available
+// so output stays deterministic whatever the number of jobs.
+// Jobs never run more than jobs indexes ahead of the last emitted one
+// and emitted values are released so memory stays bounded.
+// Processing stops on the first error returned by fn or emit,
+// or when ctx is cancelled in which case ctx.Err() is returned
+func Run[T any](ctx context.Context, jobs, n int, fn func(ctx context.Context, i int) (T, error), emit func(i int, v T) error) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	if jobs < 1 {
+		jobs = runtime.NumCPU()
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	results := make([]result[T], n)
+	done := make([]chan struct{}, n)
+	for i := range done {
+		done[i] = make(chan struct{})
+	}
+
+	// slots holds the indexes taken but not emitted yet
+	slots := make(chan struct{}, jobs)
+	indexes := make(chan int)
+	go func() {
+		defer close(indexes)
+		for i := range n {
+			select {
+			case slots <- struct{}{}:
+			case <-ctx.Done():
+				return
+			}
+			select {
+			case indexes <- i:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+
+	var wg sync.WaitGroup
+	for range min(jobs, n) {
+		wg.Go(func() {
+			for i := range indexes {
+				v, err := fn(ctx, i)
+				results[i] = result[T]{value: v, err: err}
+				close(done[i])
+			}
+		})
+	}
+	defer func() {
+		cancel()
+		wg.Wait()
+	}()
+
+	for i := range n {
+		select {
+		case <-done[i]:
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+
+		if results[i].err != nil {
+			return results[i].err
+		}
+		if err := emit(i, results[i].value); err != nil {
+			return err
+		}
+		results[i] = result[T]{}
+		<-slots
+	}
+	return nil
+}
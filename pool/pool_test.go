@@ -0,0 +1,135 @@
+package pool
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPool_run(t *testing.T) {
+	assert := assert.New(t)
+
+	t.Run("ordered", func(t *testing.T) {
+		var result []int
+		err := Run(context.Background(), 4, 50,
+			func(_ context.Context, i int) (int, error) {
+				// later indexes complete first
+				time.Sleep(time.Duration(50-i) * 100 * time.Microsecond)
+				return i * 2, nil
+			},
+			func(i int, v int) error {
+				assert.Equal(i*2, v)
+				result = append(result, i)
+				return nil
+			},
+		)
+		assert.Nil(err)
+		for i, v := range result {
+			assert.Equal(i, v)
+		}
+		assert.Equal(50, len(result))
+	})
+
+	t.Run("bounded", func(t *testing.T) {
+		var running, maxRunning atomic.Int32
+		err := Run(context.Background(), 3, 30,
+			func(_ context.Context, _ int) (struct{}, error) {
+				n := running.Add(1)
+				for {
+					m := maxRunning.Load()
+					if n <= m || maxRunning.CompareAndSwap(m, n) {
+						break
+					}
+				}
+				time.Sleep(time.Millisecond)
+				running.Add(-1)
+				return struct{}{}, nil
+			},
+			func(_ int, _ struct{}) error { return nil },
+		)
+		assert.Nil(err)
+		assert.LessOrEqual(maxRunning.Load(), int32(3))
+	})
+
+	t.Run("run_ahead", func(t *testing.T) {
+		var started, ahead atomic.Int32
+		err := Run(context.Background(), 2, 20,
+			func(_ context.Context, i int) (int, error) {
+				started.Add(1)
+				if i == 0 {
+					// a slow first job must not let the others pile up
+					time.Sleep(20 * time.Millisecond)
+				}
+				return i, nil
+			},
+			func(i int, _ int) error {
+				if i == 0 {
+					ahead.Store(started.Load())
+				}
+				return nil
+			},
+		)
+		assert.Nil(err)
+		assert.LessOrEqual(ahead.Load(), int32(2))
+	})
+
+	t.Run("default_jobs", func(t *testing.T) {
+		var count int
+		err := Run(context.Background(), 0, 10,
+			func(_ context.Context, i int) (int, error) { return i, nil },
+			func(_ int, _ int) error {
+				count++
+				return nil
+			},
+		)
+		assert.Nil(err)
+		assert.Equal(10, count)
+	})
+
+	t.Run("error", func(t *testing.T) {
+		errJob := errors.New("job")
+		var emitted []int
+		err := Run(context.Background(), 2, 10,
+			func(_ context.Context, i int) (int, error) {
+				if i == 3 {
+					return 0, errJob
+				}
+				return i, nil
+			},
+			func(i int, _ int) error {
+				emitted = append(emitted, i)
+				return nil
+			},
+		)
+		assert.ErrorIs(err, errJob)
+		assert.Equal([]int{0, 1, 2}, emitted)
+	})
+
+	t.Run("emit_error", func(t *testing.T) {
+		errEmit := errors.New("emit")
+		err := Run(context.Background(), 2, 10,
+			func(_ context.Context, i int) (int, error) { return i, nil },
+			func(_ int, _ int) error { return errEmit },
+		)
+		assert.ErrorIs(err, errEmit)
+	})
+
+	t.Run("cancel", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		err := Run(ctx, 2, 100,
+			func(ctx context.Context, i int) (int, error) {
+				if i == 1 {
+					cancel()
+				}
+				<-ctx.Done()
+				return 0, ctx.Err()
+			},
+			func(_ int, _ int) error { return nil },
+		)
+		assert.ErrorIs(err, context.Canceled)
+	})
+}
@@ -0,0 +1,5 @@
+module github.com/orilang/app
+
+ori 0.1
+
+require github.com/orilang/log
@@ -0,0 +1,11 @@
+// module manifest of the test application
+module github.com/orilang/app
+
+ori 0.1
+
+require (
+  github.com/orilang/lib
+  github.com/orilang/log
+)
+
+replace github.com/orilang/lib => ./lib
@@ -0,0 +1,5 @@
+module github.com/orilang/app
+
+ori v1
+
+replace github.com/orilang/lib => github.com/orilang/fork
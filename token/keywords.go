@@ -44,6 +44,8 @@ var keywords = map[string]Kind{
 	"map":         KWMap,
 	"hashmap":     KWHashMap,
 	"nil":         KWNil,
+	"match":       KWMatch,
+	"defer":       KWDefer,
 }
 
 var builtinTypes = map[Kind]bool{
@@ -67,15 +69,33 @@ var builtinTypes = map[Kind]bool{
 }
 
 var prefix = map[Kind]bool{
-	LParen:    true,
-	Ident:     true,
-	IntLit:    true,
-	FloatLit:  true,
-	StringLit: true,
-	BoolLit:   true,
-	Plus:      true,
-	Minus:     true,
-	Not:       true,
+	LParen:     true,
+	Ident:      true,
+	IntLit:     true,
+	FloatLit:   true,
+	StringLit:  true,
+	BoolLit:    true,
+	Plus:       true,
+	Minus:      true,
+	Not:        true,
+	KWNil:      true,
+	KWComptime: true,
+	KWInt:      true,
+	KWInt8:     true,
+	KWInt32:    true,
+	KWInt64:    true,
+	KWUint:     true,
+	KWUint8:    true,
+	KWUint32:   true,
+	KWUint64:   true,
+	KWFloat:    true,
+	KWFloat32:  true,
+	KWFloat64:  true,
+	KWString:   true,
+	KWBool:     true,
+	LBracket:   true,
+	KWMap:      true,
+	KWHashMap:  true,
 }
 
 var infix = map[Kind]bool{
@@ -137,28 +157,15 @@ var incDec = map[Kind]bool{
 	MMinus: true,
 }
 
-var varConstTypes = map[Kind]bool{
+var validTypeDecl = map[Kind]bool{
 	Ident:       true,
-	KWInt:       true,
-	KWInt8:      true,
-	KWInt32:     true,
-	KWInt64:     true,
-	KWUint:      true,
-	KWUint8:     true,
-	KWUint32:    true,
-	KWUint64:    true,
-	KWFloat:     true,
-	KWFloat32:   true,
-	KWFloat64:   true,
-	KWString:    true,
-	KWBool:      true,
-	KWInterface: true,
+	LBracket:    true,
 	KWMap:       true,
 	KWHashMap:   true,
-}
-
-var funcParamTypes = map[Kind]bool{
-	Ident:       true,
+	KWInterface: true,
+	KWStruct:    true,
+	KWEnum:      true,
+	KWSum:       true,
 	KWInt:       true,
 	KWInt8:      true,
 	KWInt32:     true,
@@ -172,38 +179,57 @@ var funcParamTypes = map[Kind]bool{
 	KWFloat64:   true,
 	KWString:    true,
 	KWBool:      true,
-	KWInterface: true,
 	KWFunc:      true,
-	KWMap:       true,
-	KWHashMap:   true,
 }
 
-var structTypes = map[Kind]bool{
-	Ident:       true,
-	KWInt:       true,
-	KWInt8:      true,
-	KWInt32:     true,
-	KWInt64:     true,
-	KWUint:      true,
-	KWUint8:     true,
-	KWUint32:    true,
-	KWUint64:    true,
-	KWFloat:     true,
-	KWFloat32:   true,
-	KWFloat64:   true,
-	KWString:    true,
-	KWBool:      true,
-	KWInterface: true,
-	KWFunc:      true,
-	KWMap:       true,
-	KWHashMap:   true,
+var mapType = map[Kind]bool{
+	KWMap:     true,
+	KWHashMap: true,
 }
 
-var validTypeDecl = map[Kind]bool{
-	KWInterface: true,
-	KWStruct:    true,
-	KWEnum:      true,
-	KWSum:       true,
+var makeTypes = map[Kind]bool{
+	LBracket:  true,
+	KWMap:     true,
+	KWHashMap: true,
+}
+
+var comments = map[Kind]bool{
+	Comment:    true,
+	DocComment: true,
+	Directive:  true,
+}
+
+var qualifiers = map[Kind]bool{
+	KWView:   true,
+	KWShared: true,
+}
+
+var declStart = map[Kind]bool{
+	KWImport:   true,
+	KWFunc:     true,
+	KWType:     true,
+	KWConst:    true,
+	KWVar:      true,
+	KWComptime: true,
+}
+
+var stmtStart = map[Kind]bool{
+	KWConst:       true,
+	KWVar:         true,
+	KWType:        true,
+	KWReturn:      true,
+	KWIf:          true,
+	KWFor:         true,
+	KWBreak:       true,
+	KWContinue:    true,
+	KWSwitch:      true,
+	KWFallThrough: true,
+	KWMatch:       true,
+	KWDefer:       true,
+}
+
+var typeNames = map[Kind]bool{
+	Ident:       true,
 	KWInt:       true,
 	KWInt8:      true,
 	KWInt32:     true,
@@ -217,36 +243,18 @@ var validTypeDecl = map[Kind]bool{
 	KWFloat64:   true,
 	KWString:    true,
 	KWBool:      true,
-	KWFunc:      true,
-}
-
-var sliceTypes = map[Kind]bool{
-	Ident:     true,
-	KWInt:     true,
-	KWInt8:    true,
-	KWInt32:   true,
-	KWInt64:   true,
-	KWUint:    true,
-	KWUint8:   true,
-	KWUint32:  true,
-	KWUint64:  true,
-	KWFloat:   true,
-	KWFloat32: true,
-	KWFloat64: true,
-	KWString:  true,
-	KWBool:    true,
-	KWFunc:    true,
-	Dot:       true,
-	IntLit:    true,
+	KWInterface: true,
 }
 
-var mapType = map[Kind]bool{
+var typeStart = map[Kind]bool{
+	LBracket:  true,
 	KWMap:     true,
 	KWHashMap: true,
+	KWFunc:    true,
+	KWStruct:  true,
 }
 
-var mapTypes = map[Kind]bool{
-	Ident:     true,
+var conversionTypes = map[Kind]bool{
 	KWInt:     true,
 	KWInt8:    true,
 	KWInt32:   true,
@@ -260,30 +268,16 @@ var mapTypes = map[Kind]bool{
 	KWFloat64: true,
 	KWString:  true,
 	KWBool:    true,
-	KWFunc:    true,
-	Dot:       true,
-	IntLit:    true,
-}
-
-var makeTypes = map[Kind]bool{
 	LBracket:  true,
 	KWMap:     true,
 	KWHashMap: true,
 }
 
-var definedTypes = map[Kind]bool{
-	KWInt:     true,
-	KWInt8:    true,
-	KWInt32:   true,
-	KWInt64:   true,
-	KWUint:    true,
-	KWUint8:   true,
-	KWUint32:  true,
-	KWUint64:  true,
-	KWFloat:   true,
-	KWFloat32: true,
-	KWFloat64: true,
-	KWString:  true,
-	KWBool:    true,
-	KWFunc:    true,
+var builtins = map[string]Builtin{
+	"len":    {Name: "len", Min: 1, Max: 1},
+	"cap":    {Name: "cap", Min: 1, Max: 1},
+	"append": {Name: "append", Min: 1, Max: -1},
+	"delete": {Name: "delete", Min: 2, Max: 2},
+	"copy":   {Name: "copy", Min: 2, Max: 2},
+	"make":   {Name: "make", Min: 1, Max: 3, TypeArg: true},
 }
@@ -101,4 +101,12 @@ const (
 	KWMap
 	KWHashMap
 	KWNil
+
+	DocComment // ///
+	Directive  // //ori:name args
+
+	KWMatch
+	KWDefer
+
+	Ellipsis // ...
 )
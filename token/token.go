@@ -55,47 +55,66 @@ func IsIncDec(k Kind) bool {
 	return incDec[k]
 }
 
-// IsVarConstTypes returns true when the provided kind is found is the list
-func IsVarConstTypes(k Kind) bool {
-	return varConstTypes[k]
+// IsValidTypeDecl returns true when the provided kind found is the a struct or interface
+func IsValidTypeDecl(k Kind) bool {
+	return validTypeDecl[k]
 }
 
-// IsFuncParamTypes returns true when the provided kind is found is the list
-func IsFuncParamTypes(k Kind) bool {
-	return funcParamTypes[k]
+// IsMapType returns true when the provided kind found
+func IsMapType(k Kind) bool {
+	return mapType[k]
 }
 
-// IsStructFieldTypes returns true when the provided kind is found is the list
-func IsStructFieldTypes(k Kind) bool {
-	return structTypes[k]
+// IsMakeTypes returns true when the provided kind found
+func IsMakeTypes(k Kind) bool {
+	return makeTypes[k]
 }
 
-// IsValidTypeDecl returns true when the provided kind found is the a struct or interface
-func IsValidTypeDecl(k Kind) bool {
-	return validTypeDecl[k]
+// IsComment returns true when the provided kind is a comment,
+// a doc comment or a directive
+func IsComment(k Kind) bool {
+	return comments[k]
 }
 
-// IsSliceType returns true when the provided kind found
-func IsSliceType(k Kind) bool {
-	return sliceTypes[k]
+// IsQualifier returns true when the provided kind is
+// an ownership qualifier like view or shared
+func IsQualifier(k Kind) bool {
+	return qualifiers[k]
 }
 
-// IsMapType returns true when the provided kind found
-func IsMapType(k Kind) bool {
-	return mapType[k]
+// IsDeclStart returns true when the provided kind
+// starts a file level declaration
+func IsDeclStart(k Kind) bool {
+	return declStart[k]
 }
 
-// IsMapTypes returns true when the provided kind found
-func IsMapTypes(k Kind) bool {
-	return mapTypes[k]
+// IsStmtStart returns true when the provided kind
+// starts a statement like return or for
+func IsStmtStart(k Kind) bool {
+	return stmtStart[k]
 }
 
-// IsMakeTypes returns true when the provided kind found
-func IsMakeTypes(k Kind) bool {
-	return makeTypes[k]
+// IsTypeName returns true when the provided kind names
+// a type like int or an identifier
+func IsTypeName(k Kind) bool {
+	return typeNames[k]
+}
+
+// IsTypeStart returns true when the provided kind starts
+// a type like a name, '[', map, hashmap or func
+func IsTypeStart(k Kind) bool {
+	return typeNames[k] || typeStart[k]
+}
+
+// IsConversionType returns true when the provided kind starts
+// the type of a conversion like int64(x) or []byte(s)
+func IsConversionType(k Kind) bool {
+	return conversionTypes[k]
 }
 
-// IsDefinedTypes returns true when the provided kind found
-func IsDefinedTypes(k Kind) bool {
-	return definedTypes[k]
+// LookupBuiltin returns the builtin function named name
+// and false when there is none
+func LookupBuiltin(name string) (Builtin, bool) {
+	b, ok := builtins[name]
+	return b, ok
 }
@@ -209,13 +209,13 @@ func TestToken(t *testing.T) {
 		}
 	})
 
-	t.Run("is_var_const_types", func(t *testing.T) {
+	t.Run("is_valid_type_decl", func(t *testing.T) {
 		tests := []struct {
 			input    Kind
 			expected bool
 		}{
 			{
-				input:    Ident,
+				input:    KWStruct,
 				expected: true,
 			},
 			{
@@ -225,17 +225,17 @@ func TestToken(t *testing.T) {
 		}
 
 		for _, tc := range tests {
-			assert.Equal(tc.expected, IsVarConstTypes(tc.input))
+			assert.Equal(tc.expected, IsValidTypeDecl(tc.input))
 		}
 	})
 
-	t.Run("is_return_types", func(t *testing.T) {
+	t.Run("is_map_type", func(t *testing.T) {
 		tests := []struct {
 			input    Kind
 			expected bool
 		}{
 			{
-				input:    KWFunc,
+				input:    KWMap,
 				expected: true,
 			},
 			{
@@ -245,91 +245,62 @@ func TestToken(t *testing.T) {
 		}
 
 		for _, tc := range tests {
-			assert.Equal(tc.expected, IsFuncParamTypes(tc.input))
+			assert.Equal(tc.expected, IsMapType(tc.input))
 		}
 	})
 
-	t.Run("is_struct_types", func(t *testing.T) {
+	t.Run("is_comment", func(t *testing.T) {
 		tests := []struct {
 			input    Kind
 			expected bool
 		}{
 			{
-				input:    KWFunc,
+				input:    Comment,
 				expected: true,
 			},
 			{
-				input:    KWReturn,
-				expected: false,
+				input:    DocComment,
+				expected: true,
 			},
-		}
-
-		for _, tc := range tests {
-			assert.Equal(tc.expected, IsStructFieldTypes(tc.input))
-		}
-	})
-
-	t.Run("is_valid_type_decl", func(t *testing.T) {
-		tests := []struct {
-			input    Kind
-			expected bool
-		}{
 			{
-				input:    KWStruct,
+				input:    Directive,
 				expected: true,
 			},
 			{
-				input:    KWReturn,
+				input:    Ident,
 				expected: false,
 			},
 		}
 
 		for _, tc := range tests {
-			assert.Equal(tc.expected, IsValidTypeDecl(tc.input))
+			assert.Equal(tc.expected, IsComment(tc.input))
 		}
 	})
-
-	t.Run("is_slice_type", func(t *testing.T) {
+	t.Run("is_qualifier", func(t *testing.T) {
 		tests := []struct {
 			input    Kind
 			expected bool
 		}{
 			{
-				input:    KWFunc,
+				input:    KWView,
 				expected: true,
 			},
 			{
-				input:    KWReturn,
-				expected: false,
-			},
-		}
-
-		for _, tc := range tests {
-			assert.Equal(tc.expected, IsSliceType(tc.input))
-		}
-	})
-
-	t.Run("is_map_type", func(t *testing.T) {
-		tests := []struct {
-			input    Kind
-			expected bool
-		}{
-			{
-				input:    KWMap,
+				input:    KWShared,
 				expected: true,
 			},
 			{
-				input:    KWReturn,
+				input:    Ident,
 				expected: false,
 			},
 		}
 
 		for _, tc := range tests {
-			assert.Equal(tc.expected, IsMapType(tc.input))
+			assert.Equal(tc.expected, IsQualifier(tc.input))
 		}
 	})
 
-	t.Run("is_map_types_x1", func(t *testing.T) {
+	t.Run("is_decl_start", func(t *testing.T) {
 		tests := []struct {
 			input    Kind
 			expected bool
@@ -338,6 +309,10 @@ func TestToken(t *testing.T) {
 				input:    KWFunc,
 				expected: true,
 			},
+			{
+				input:    KWComptime,
+				expected: true,
+			},
 			{
 				input:    KWReturn,
 				expected: false,
@@ -345,47 +320,71 @@ func TestToken(t *testing.T) {
 		}
 
 		for _, tc := range tests {
-			assert.Equal(tc.expected, IsMapTypes(tc.input))
+			assert.Equal(tc.expected, IsDeclStart(tc.input))
 		}
 	})
-
-	t.Run("is_map_types_x2", func(t *testing.T) {
+	t.Run("is_stmt_start", func(t *testing.T) {
 		tests := []struct {
 			input    Kind
 			expected bool
 		}{
 			{
-				input:    KWMap,
+				input:    KWReturn,
 				expected: true,
 			},
 			{
-				input:    KWReturn,
+				input:    KWDefer,
+				expected: true,
+			},
+			{
+				input:    KWFunc,
+				expected: false,
+			},
+			{
+				input:    Ident,
 				expected: false,
 			},
 		}
 
 		for _, tc := range tests {
-			assert.Equal(tc.expected, IsMakeTypes(tc.input))
+			assert.Equal(tc.expected, IsStmtStart(tc.input))
 		}
 	})
 
-	t.Run("is_defined_types_x2", func(t *testing.T) {
+	t.Run("is_conversion_type", func(t *testing.T) {
 		tests := []struct {
 			input    Kind
 			expected bool
 		}{
 			{
-				input:    KWInt,
+				input:    KWInt64,
 				expected: true,
 			},
 			{
-				input:    KWReturn,
+				input:    LBracket,
+				expected: true,
+			},
+			{
+				input:    Ident,
 				expected: false,
 			},
 		}
 
 		for _, tc := range tests {
-			assert.Equal(tc.expected, IsDefinedTypes(tc.input))
+			assert.Equal(tc.expected, IsConversionType(tc.input))
 		}
 	})
+
+	t.Run("lookup_builtin", func(t *testing.T) {
+		b, ok := LookupBuiltin("append")
+		assert.True(ok)
+		assert.Equal(Builtin{Name: "append", Min: 1, Max: -1}, b)
+
+		b, ok = LookupBuiltin("make")
+		assert.True(ok)
+		assert.True(b.TypeArg)
+
+		_, ok = LookupBuiltin("print")
+		assert.False(ok)
+	})
 }
@@ -6,4 +6,27 @@ type Token struct {
 	Value  string
 	Line   int
 	Column int
+
+	// Offset is the position in bytes of the token
+	// from the start of the input
+	Offset int
+}
+
+// DirectivePrefix starts a directive comment like //ori:build linux
+const DirectivePrefix = "//ori:"
+
+// Builtin holds a builtin function like len or append
+type Builtin struct {
+	// Name of the function
+	Name string
+
+	// Min is the minimum number of arguments
+	Min int
+
+	// Max is the maximum number of arguments,
+	// -1 when the function is variadic
+	Max int
+
+	// TypeArg is true when the first argument is a type like in make
+	TypeArg bool
 }
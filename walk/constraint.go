@@ -0,0 +1,214 @@
+package walk
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"os"
+	"runtime"
+	"strings"
+
+	"github.com/orilang/gori/ast"
+	"github.com/orilang/gori/token"
+)
+
+// targetTags returns the tags to evaluate build constraints with
+func targetTags(tags []string) map[string]bool {
+	if len(tags) == 0 {
+		tags = []string{runtime.GOOS, runtime.GOARCH}
+	}
+
+	result := make(map[string]bool, len(tags))
+	for _, tag := range tags {
+		result[tag] = true
+	}
+	return result
+}
+
+// matchFile returns true when all build constraints
+// of the file are satisfied by the tags
+func matchFile(file string, tags map[string]bool) (bool, error) {
+	constraints, err := buildConstraints(file)
+	if err != nil {
+		return false, err
+	}
+
+	for _, expr := range constraints {
+		ok, err := matchConstraint(expr, tags)
+		if err != nil {
+			return false, fmt.Errorf("%s: %w", file, err)
+		}
+		if !ok {
+			return false, nil
+		}
+	}
+	return true, nil
+}
+
+// buildConstraints returns the //ori:build expressions located
+// in the file header, before any other statement.
+// Directives are read with ast.ParseDirective like the parser does
+func buildConstraints(file string) ([]string, error) {
+	fd, err := os.Open(file)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		_ = fd.Close()
+	}()
+
+	var result []string
+	scanner := bufio.NewScanner(fd)
+	for number := 1; scanner.Scan(); number++ {
+		line := scanner.Bytes()
+		if number == 1 {
+			line = bytes.TrimPrefix(line, []byte{0xef, 0xbb, 0xbf})
+		}
+		text := strings.TrimSpace(string(line))
+
+		switch {
+		case text == "":
+		case strings.HasPrefix(text, "//"):
+			d, ok := ast.ParseDirective(token.Token{Kind: token.Directive, Value: text, Line: number})
+			if ok && d.Name == "build" {
+				result = append(result, strings.Join(d.Args, " "))
+			}
+		default:
+			return result, nil
+		}
+	}
+	return result, scanner.Err()
+}
+
+// constraint holds requirements to evaluate a build constraint
+// like linux && (amd64 || arm64) && !debug
+type constraint struct {
+	expr     string
+	items    []string
+	position int
+	tags     map[string]bool
+}
+
+// matchConstraint evaluates the build constraint expression with the tags
+func matchConstraint(expr string, tags map[string]bool) (bool, error) {
+	c := &constraint{expr: expr, tags: tags}
+	if err := c.split(); err != nil {
+		return false, err
+	}
+
+	ok, err := c.or()
+	if err != nil {
+		return false, err
+	}
+	if c.position != len(c.items) {
+		return false, fmt.Errorf("%w: unexpected %q in %q", ErrInvalidBuildConstraint, c.items[c.position], expr)
+	}
+	return ok, nil
+}
+
+// split breaks the expression into tags and operators
+func (c *constraint) split() error {
+	for i := 0; i < len(c.expr); {
+		v := c.expr[i]
+		switch {
+		case v == ' ' || v == '\t':
+			i++
+		case v == '(' || v == ')' || v == '!':
+			c.items = append(c.items, string(v))
+			i++
+		case strings.HasPrefix(c.expr[i:], "&&"), strings.HasPrefix(c.expr[i:], "||"):
+			c.items = append(c.items, c.expr[i:i+2])
+			i += 2
+		case isTag(v):
+			start := i
+			for i < len(c.expr) && isTag(c.expr[i]) {
+				i++
+			}
+			c.items = append(c.items, c.expr[start:i])
+		default:
+			return fmt.Errorf("%w: unexpected %q in %q", ErrInvalidBuildConstraint, v, c.expr)
+		}
+	}
+
+	if len(c.items) == 0 {
+		return fmt.Errorf("%w: empty expression", ErrInvalidBuildConstraint)
+	}
+	return nil
+}
+
+// peek returns the current item or an empty string when done
+func (c *constraint) peek() string {
+	if c.position < len(c.items) {
+		return c.items[c.position]
+	}
+	return ""
+}
+
+// or evaluates a || b
+func (c *constraint) or() (bool, error) {
+	left, err := c.and()
+	if err != nil {
+		return false, err
+	}
+
+	for c.peek() == "||" {
+		c.position++
+		right, err := c.and()
+		if err != nil {
+			return false, err
+		}
+		left = left || right
+	}
+	return left, nil
+}
+
+// and evaluates a && b
+func (c *constraint) and() (bool, error) {
+	left, err := c.not()
+	if err != nil {
+		return false, err
+	}
+
+	for c.peek() == "&&" {
+		c.position++
+		right, err := c.not()
+		if err != nil {
+			return false, err
+		}
+		left = left && right
+	}
+	return left, nil
+}
+
+// not evaluates !a, (a) or a
+func (c *constraint) not() (bool, error) {
+	item := c.peek()
+	switch {
+	case item == "!":
+		c.position++
+		ok, err := c.not()
+		return !ok, err
+
+	case item == "(":
+		c.position++
+		ok, err := c.or()
+		if err != nil {
+			return false, err
+		}
+		if c.peek() != ")" {
+			return false, fmt.Errorf("%w: missing ')' in %q", ErrInvalidBuildConstraint, c.expr)
+		}
+		c.position++
+		return ok, nil
+
+	case item != "" && isTag(item[0]):
+		c.position++
+		return c.tags[item], nil
+	}
+	return false, fmt.Errorf("%w: expected tag in %q", ErrInvalidBuildConstraint, c.expr)
+}
+
+// isTag returns true when ch can be part of a tag
+func isTag(ch byte) bool {
+	return (ch >= 'a' && ch <= 'z') || (ch >= 'A' && ch <= 'Z') || (ch >= '0' && ch <= '9') || ch == '_' || ch == '.'
+}
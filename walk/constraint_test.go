@@ -0,0 +1,77 @@
+package walk
+
+import (
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWalk_constraint(t *testing.T) {
+	assert := assert.New(t)
+
+	t.Run("target_tags_default", func(t *testing.T) {
+		tags := targetTags(nil)
+		assert.True(tags[runtime.GOOS])
+		assert.True(tags[runtime.GOARCH])
+	})
+
+	t.Run("match_constraint", func(t *testing.T) {
+		tags := targetTags([]string{"linux", "amd64"})
+		tests := []struct {
+			input    string
+			expected bool
+		}{
+			{input: "linux", expected: true},
+			{input: "windows", expected: false},
+			{input: "!windows", expected: true},
+			{input: "linux && amd64", expected: true},
+			{input: "linux && arm64", expected: false},
+			{input: "windows || amd64", expected: true},
+			{input: "linux && (arm64 || amd64)", expected: true},
+			{input: "!(linux && amd64)", expected: false},
+			{input: "windows || linux && !arm64", expected: true},
+			{input: "go1.26", expected: false},
+		}
+
+		for _, tc := range tests {
+			ok, err := matchConstraint(tc.input, tags)
+			assert.Nil(err, tc.input)
+			assert.Equal(tc.expected, ok, tc.input)
+		}
+	})
+
+	t.Run("match_constraint_errors", func(t *testing.T) {
+		tags := targetTags([]string{"linux"})
+		for _, input := range []string{"", "linux &&", "(linux", "linux)", "linux & amd64", "linux amd64", "!"} {
+			_, err := matchConstraint(input, tags)
+			assert.ErrorIs(err, ErrInvalidBuildConstraint, input)
+		}
+	})
+
+	t.Run("build_constraints", func(t *testing.T) {
+		result, err := buildConstraints("../testdata/build/windows.ori")
+		assert.Nil(err)
+		assert.Equal([]string{"windows && (amd64 || arm64)"}, result)
+
+		result, err = buildConstraints("../testdata/build/all.ori")
+		assert.Nil(err)
+		assert.Equal(0, len(result))
+	})
+
+	t.Run("build_constraints_directives", func(t *testing.T) {
+		file := filepath.Join(t.TempDir(), "main.ori")
+		assert.Nil(os.WriteFile(file, []byte("//ori:build  linux\n//ori: build windows\n//ori:buildx darwin\n\npackage main\n//ori:build arm64\n"), 0o600))
+
+		result, err := buildConstraints(file)
+		assert.Nil(err)
+		assert.Equal([]string{"linux"}, result)
+	})
+
+	t.Run("build_constraints_no_such_file", func(t *testing.T) {
+		_, err := buildConstraints("xxxx.ori")
+		assert.Error(err)
+	})
+}
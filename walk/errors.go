@@ -5,4 +5,5 @@ import "errors"
 var (
 	ErrNoFileOrDirectoryPassed = errors.New("no file or directory passed")
 	ErrNoFilesFound            = errors.New("no files found")
+	ErrInvalidBuildConstraint  = errors.New("invalid build constraint")
 )
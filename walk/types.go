@@ -7,6 +7,11 @@ type Config struct {
 
 	// Directory to take as input and list files to parse
 	Directory string
+
+	// Tags are the build tags of the target used to evaluate
+	// //ori:build constraints. When empty, the current OS and
+	// architecture are used
+	Tags []string
 }
 
 // LexerFiles holds all files to use for tokenization
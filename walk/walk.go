@@ -9,7 +9,9 @@ import (
 )
 
 // walk checks if specified file exist.
-// If directory is specified, it will return all .ori and .mod files
+// If directory is specified, it will return all .ori and .mod files.
+// .ori files whose //ori:build constraints are not satisfied
+// by the target tags are excluded
 func Walk(config Config) (files *Files, err error) {
 	var f Files
 	exentions := []string{".ori", ".mod"}
@@ -39,6 +41,24 @@ func Walk(config Config) (files *Files, err error) {
 		}
 	}
 
+	tags := targetTags(config.Tags)
+	kept := f.Files[:0]
+	for _, file := range f.Files {
+		if filepath.Ext(file) != ".ori" {
+			kept = append(kept, file)
+			continue
+		}
+
+		ok, err := matchFile(file, tags)
+		if err != nil {
+			return nil, err
+		}
+		if ok {
+			kept = append(kept, file)
+		}
+	}
+	f.Files = kept
+
 	if len(f.Files) == 0 {
 		return nil, ErrNoFilesFound
 	}
@@ -58,4 +58,25 @@ func TestLexer_Walk(t *testing.T) {
 		_, err = Walk(Config{Directory: filepath.Join(workingDir, "..", "testdata/fake")})
 		assert.Nil(err)
 	})
+
+	t.Run("build_constraints", func(t *testing.T) {
+		w, err := Walk(Config{Directory: "../testdata/build", Tags: []string{"linux", "amd64"}})
+		assert.Nil(err)
+		assert.Equal([]string{"../testdata/build/all.ori", "../testdata/build/linux.ori"}, w.Files)
+
+		w, err = Walk(Config{Directory: "../testdata/build", Tags: []string{"windows", "arm64"}})
+		assert.Nil(err)
+		assert.Equal([]string{"../testdata/build/all.ori", "../testdata/build/windows.ori"}, w.Files)
+	})
+
+	t.Run("build_constraints_excluded_file", func(t *testing.T) {
+		_, err := Walk(Config{File: "../testdata/build/windows.ori", Tags: []string{"linux"}})
+		assert.ErrorIs(err, ErrNoFilesFound)
+	})
+
+	t.Run("err_invalid_build_constraint", func(t *testing.T) {
+		_, err := Walk(Config{Directory: "../testdata/build_invalid"})
+		assert.ErrorIs(err, ErrInvalidBuildConstraint)
+		assert.Contains(err.Error(), "invalid.ori")
+	})
 }